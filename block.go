@@ -0,0 +1,317 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BlockOptions defines the destination of MoveBlock and CopyBlock
+type BlockOptions struct {
+	// Position where to place the block
+	Position InsertPosition
+
+	// Target overrides Position and Anchor with an insertion point such as
+	// a FindText match, a table cell or a Range
+	Target *Target
+
+	// Anchor text for position-based placement (for PositionAfterText/PositionBeforeText),
+	// or bookmark name for PositionAfterBookmark/PositionBeforeBookmark
+	Anchor string
+
+	// Occurrence selects which paragraph containing Anchor to use (1-based, default: first)
+	Occurrence int
+
+	// AnchorRegex treats Anchor as a regular expression
+	AnchorRegex bool
+}
+
+// MoveBlock moves the paragraphs, tables and drawings covered by src to the
+// destination of opts. For a text range the paragraph containing the range is
+// moved. src follows the moved content and becomes a block range.
+//
+// The destination is resolved before the move and must not lie inside src.
+func (u *Updater) MoveBlock(src *Range, opts BlockOptions) error {
+	if u == nil {
+		return fmt.Errorf("updater is nil")
+	}
+
+	docXML, start, end, err := u.blockSource(src)
+	if err != nil {
+		return err
+	}
+	content := append([]byte{}, docXML[start:end]...)
+
+	insertStart, insertEnd, err := resolveBlockDestination(docXML, content, opts)
+	if err != nil {
+		return err
+	}
+	if insertStart > start && insertStart < end {
+		return NewValidationError("position", "destination lies inside the source range")
+	}
+
+	updated := spliceBytes(docXML, content, insertStart, insertEnd)
+	if insertStart <= start {
+		shift := len(updated) - len(docXML)
+		start += shift
+		end += shift
+	}
+
+	// Remove the source, keeping cells and sections valid
+	beforeDelete := len(updated)
+	updated = deleteElements(updated, childElements(updated, start, end))
+	if insertStart > start {
+		insertStart -= beforeDelete - len(updated)
+	}
+
+	src.block = true
+	return src.store(updated, insertStart, insertStart+len(content))
+}
+
+// CopyBlock copies the paragraphs, tables and drawings covered by src to the
+// destination of opts and returns a block range covering the copy. For a text range the paragraph
+// containing the range is copied. Drawing and bookmark IDs of the copy are
+// reallocated, bookmarks are renamed to stay unique, and copied charts get
+// their own chart parts and embedded workbooks.
+//
+// The destination is resolved as in MoveBlock.
+func (u *Updater) CopyBlock(src *Range, opts BlockOptions) (*Range, error) {
+	if u == nil {
+		return nil, fmt.Errorf("updater is nil")
+	}
+
+	docXML, start, end, err := u.blockSource(src)
+	if err != nil {
+		return nil, err
+	}
+
+	insertStart, insertEnd, err := resolveBlockDestination(docXML, docXML[start:end], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := u.prepareBlockCopy(docXML, docXML[start:end])
+	if err != nil {
+		return nil, fmt.Errorf("copy block: %w", err)
+	}
+
+	updated := spliceBytes(docXML, content, insertStart, insertEnd)
+
+	copyRange := &Range{u: u, block: true}
+	if err := copyRange.store(updated, insertStart, insertStart+len(content)); err != nil {
+		return nil, err
+	}
+
+	// Keep the source range in place
+	if insertStart <= src.start {
+		shift := len(updated) - len(docXML)
+		src.sync(updated, src.start+shift, src.end+shift)
+	} else {
+		src.sync(updated, src.start, src.end)
+	}
+
+	return copyRange, nil
+}
+
+// blockSource loads the document and returns the offsets of the content a
+// block operation acts on: the range itself for a block range, or the
+// paragraph containing a text range
+func (u *Updater) blockSource(src *Range) ([]byte, int, int, error) {
+	if src == nil {
+		return nil, 0, 0, NewValidationError("src", "range cannot be nil")
+	}
+	if src.u != u {
+		return nil, 0, 0, NewValidationError("src", "range belongs to another document")
+	}
+
+	docXML, err := src.load()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if src.block {
+		return docXML, src.start, src.end, nil
+	}
+
+	for _, para := range scanStoryParagraphs(docXML, StoryDocument) {
+		if src.start >= para.start && src.start < para.end {
+			return docXML, para.start, para.end, nil
+		}
+	}
+	return nil, 0, 0, NewStaleRangeError("range is not inside a paragraph")
+}
+
+// resolveBlockDestination resolves the destination of opts for block content,
+// rejecting section breaks inside tables
+func resolveBlockDestination(docXML, content []byte, opts BlockOptions) (int, int, error) {
+	start, end, err := resolveInsertPosition(docXML, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
+	if err != nil {
+		return 0, 0, err
+	}
+	if bytes.Contains(content, []byte("<w:sectPr")) && offsetInTable(docXML, start) {
+		return 0, 0, NewValidationError("position", "content with a section break cannot be placed inside a table")
+	}
+	return start, end, nil
+}
+
+// prepareBlockCopy returns content with reallocated drawing and bookmark IDs
+// and with duplicated charts. Paragraph and drawing anchor IDs are dropped;
+// Word assigns new ones when it saves the document.
+func (u *Updater) prepareBlockCopy(docXML, content []byte) ([]byte, error) {
+	content = append([]byte{}, content...)
+
+	// Drawing object IDs
+	nextDocPrID, err := u.getNextDocPrId()
+	if err != nil {
+		return nil, err
+	}
+	content = docPrIDPattern.ReplaceAllFunc(content, func([]byte) []byte {
+		id := nextDocPrID
+		nextDocPrID++
+		return fmt.Appendf(nil, `docPr id="%d"`, id)
+	})
+	content = uniqueIDAttrPattern.ReplaceAll(content, nil)
+
+	content, err = u.reallocateBookmarks(docXML, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.duplicateCharts(content)
+}
+
+// reallocateBookmarks gives the bookmarks in content new IDs and unique
+// names. Markers whose counterpart is outside content are dropped. Hyperlinks
+// and REF/PAGEREF fields inside content that point to a renamed bookmark are
+// pointed to the new name.
+func (u *Updater) reallocateBookmarks(docXML, content []byte) ([]byte, error) {
+	markers := bookmarkMarkerPattern.FindAll(content, -1)
+	if len(markers) == 0 {
+		return content, nil
+	}
+
+	starts := make(map[string]bool)
+	ends := make(map[string]bool)
+	for _, marker := range markers {
+		id := xmlAttr(marker, "w:id")
+		if bytes.HasPrefix(marker, []byte("<w:bookmarkStart")) {
+			starts[id] = true
+		} else {
+			ends[id] = true
+		}
+	}
+
+	nextID, err := u.getNextBookmarkID()
+	if err != nil {
+		return nil, err
+	}
+
+	newIDs := make(map[string]int)
+	usedNames := make(map[string]bool)
+	renamed := make(map[string]string) // Escaped old name to escaped new name
+	content = bookmarkMarkerPattern.ReplaceAllFunc(content, func(marker []byte) []byte {
+		id := xmlAttr(marker, "w:id")
+		if !starts[id] || !ends[id] {
+			return nil
+		}
+		newID, ok := newIDs[id]
+		if !ok {
+			newID = nextID
+			newIDs[id] = newID
+			nextID++
+		}
+
+		updated := bytes.Replace(marker, fmt.Appendf(nil, `w:id="%s"`, id), fmt.Appendf(nil, `w:id="%d"`, newID), 1)
+		if name := xmlAttr(marker, "w:name"); name != "" {
+			newName := uniqueBookmarkName(docXML, name, usedNames)
+			usedNames[newName] = true
+			renamed[xmlEscape(name)] = xmlEscape(newName)
+			updated = bytes.Replace(updated, fmt.Appendf(nil, `w:name="%s"`, xmlEscape(name)), fmt.Appendf(nil, `w:name="%s"`, xmlEscape(newName)), 1)
+		}
+		return updated
+	})
+	if len(renamed) == 0 {
+		return content, nil
+	}
+
+	content = hyperlinkAnchorPattern.ReplaceAllFunc(content, func(attr []byte) []byte {
+		name := string(hyperlinkAnchorPattern.FindSubmatch(attr)[1])
+		if newName, ok := renamed[name]; ok {
+			return fmt.Appendf(nil, `w:anchor="%s"`, newName)
+		}
+		return attr
+	})
+	content = fieldInstructionPattern.ReplaceAllFunc(content, func(instr []byte) []byte {
+		return bookmarkFieldPattern.ReplaceAllFunc(instr, func(field []byte) []byte {
+			m := bookmarkFieldPattern.FindSubmatch(field)
+			if newName, ok := renamed[string(m[2])]; ok {
+				return slices.Concat(m[1], []byte(newName))
+			}
+			return field
+		})
+	})
+
+	return content, nil
+}
+
+// uniqueBookmarkName returns name with a numeric suffix that is not used in
+// the document, keeping within the 40 character limit of bookmark names
+func uniqueBookmarkName(docXML []byte, name string, used map[string]bool) string {
+	base := name
+	if idx := strings.LastIndex(name, "_"); idx > 0 {
+		if _, err := strconv.Atoi(name[idx+1:]); err == nil {
+			base = name[:idx]
+		}
+	}
+	for n := 2; ; n++ {
+		suffix := "_" + strconv.Itoa(n)
+		candidate := base
+		if len(candidate)+len(suffix) > 40 {
+			candidate = candidate[:40-len(suffix)]
+		}
+		candidate += suffix
+		if !used[candidate] && !bytes.Contains(docXML, fmt.Appendf(nil, `w:name="%s"`, xmlEscape(candidate))) {
+			return candidate
+		}
+	}
+}
+
+// duplicateCharts gives every chart drawing in content its own copy of the
+// chart part, registered with a new document relationship
+func (u *Updater) duplicateCharts(content []byte) ([]byte, error) {
+	chartTags := chartReferencePattern.FindAll(content, -1)
+	if len(chartTags) == 0 {
+		return content, nil
+	}
+
+	rels, err := readRelationships(filepath.Join(u.tempDir, "word", "_rels", "document.xml.rels"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range chartTags {
+		relID := xmlAttr(tag, "r:id")
+		for _, rel := range rels.Relationships {
+			if rel.ID != relID || rel.TargetMode == "External" {
+				continue
+			}
+
+			newPart, err := u.duplicatePart(resolvePartTarget(documentPart, rel.Target))
+			if err != nil {
+				return nil, err
+			}
+			newRelID, err := u.addDocumentRelationship(rel.Type, strings.TrimPrefix(newPart, "word/"))
+			if err != nil {
+				return nil, err
+			}
+
+			newTag := bytes.Replace(tag, fmt.Appendf(nil, `r:id="%s"`, relID), fmt.Appendf(nil, `r:id="%s"`, newRelID), 1)
+			content = bytes.Replace(content, tag, newTag, 1)
+			break
+		}
+	}
+
+	return content, nil
+}
@@ -0,0 +1,212 @@
+package godocx_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+const blockFixtureBody = `<w:p><w:r><w:t>First</w:t></w:r></w:p>` +
+	`<w:p><w:bookmarkStart w:id="4" w:name="Figure"/><w:r><w:t>Second</w:t></w:r><w:bookmarkEnd w:id="4"/></w:p>` +
+	`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Cell</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:p><w:r><w:t>Last</w:t></w:r></w:p>`
+
+func TestMoveBlock(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, blockFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	table, err := u.TableRange(1)
+	if err != nil {
+		t.Fatalf("TableRange failed: %v", err)
+	}
+	if err := u.MoveBlock(table, godocx.BlockOptions{Position: godocx.PositionBeginning}); err != nil {
+		t.Fatalf("MoveBlock failed: %v", err)
+	}
+
+	first, err := u.FindRange("First", godocx.FindOptions{})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if err := u.MoveBlock(first, godocx.BlockOptions{Position: godocx.PositionEnd}); err != nil {
+		t.Fatalf("MoveBlock failed: %v", err)
+	}
+
+	// The moved range follows its content
+	if err := first.InsertAfter("After first"); err != nil {
+		t.Fatalf("InsertAfter failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "<w:body><w:tbl>", "Cell", "</w:tbl>", "Second", "Last", "First", "After first", "<w:sectPr/>")
+	if strings.Count(doc, "First") != 1 || strings.Count(doc, "<w:tbl>") != 1 {
+		t.Error("expected moved content to appear only once")
+	}
+
+	all, err := u.ParagraphRange(0, 2)
+	if err != nil {
+		t.Fatalf("ParagraphRange failed: %v", err)
+	}
+	matches, err := u.FindText("Second", godocx.DefaultFindOptions())
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("FindText: %v, %d matches", err, len(matches))
+	}
+	if err := u.MoveBlock(all, godocx.BlockOptions{Target: godocx.TargetAfterMatch(matches[0])}); err == nil {
+		t.Error("expected error moving a block inside itself")
+	}
+}
+
+func TestCopyBlock(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, blockFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	if err := u.InsertChart(godocx.ChartOptions{
+		Position:   godocx.PositionAfterText,
+		Anchor:     "Second",
+		Title:      "Copied chart",
+		Categories: []string{"A", "B"},
+		Series:     []godocx.SeriesData{{Name: "S", Values: []float64{1, 2}}},
+	}); err != nil {
+		t.Fatalf("InsertChart failed: %v", err)
+	}
+
+	// Paragraphs 1-2: the bookmarked paragraph and the chart
+	src, err := u.ParagraphRange(1, 2)
+	if err != nil {
+		t.Fatalf("ParagraphRange failed: %v", err)
+	}
+	copied, err := u.CopyBlock(src, godocx.BlockOptions{Position: godocx.PositionEnd})
+	if err != nil {
+		t.Fatalf("CopyBlock failed: %v", err)
+	}
+	if text, _ := copied.Text(); !strings.HasPrefix(text, "Second") {
+		t.Errorf("unexpected copy text %q", text)
+	}
+
+	// The source range is still usable
+	if err := src.InsertBefore("Before source"); err != nil {
+		t.Fatalf("InsertBefore failed: %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "out.docx")
+	if err := u.Save(outPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	doc := readZipEntry(t, outPath, "word/document.xml")
+
+	assertOrder(t, doc, "First", "Before source", `w:name="Figure"`, "Second", `name="Chart 1"`, "Last", `<w:bookmarkStart w:id="5" w:name="Figure_2"/>`, "Second", `<w:bookmarkEnd w:id="5"/>`, `docPr id="2"`)
+
+	entries := strings.Join(listZipEntries(t, outPath), "\n")
+	for _, part := range []string{"word/charts/chart2.xml", "word/charts/_rels/chart2.xml.rels", "word/embeddings/Microsoft_Excel_Worksheet2.xlsx"} {
+		if !strings.Contains(entries, part) {
+			t.Errorf("expected duplicated part %s", part)
+		}
+	}
+	chartRels := readZipEntry(t, outPath, "word/charts/_rels/chart2.xml.rels")
+	if !strings.Contains(chartRels, "Microsoft_Excel_Worksheet2.xlsx") {
+		t.Errorf("expected copied chart to use its own workbook: %s", chartRels)
+	}
+	if ct := readZipEntry(t, outPath, "[Content_Types].xml"); !strings.Contains(ct, `/word/charts/chart2.xml`) {
+		t.Error("expected content type override for the copied chart")
+	}
+	rels := readZipEntry(t, outPath, "word/_rels/document.xml.rels")
+	if strings.Count(rels, "charts/chart") != 2 {
+		t.Errorf("expected a relationship per chart: %s", rels)
+	}
+}
+
+func TestMoveBlockAfterAnchor(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, blockFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	first, err := u.ParagraphRange(0, 0)
+	if err != nil {
+		t.Fatalf("ParagraphRange failed: %v", err)
+	}
+	if err := u.MoveBlock(first, godocx.BlockOptions{Position: godocx.PositionAfterText, Anchor: "La.t", AnchorRegex: true}); err != nil {
+		t.Fatalf("MoveBlock failed: %v", err)
+	}
+	second, err := u.FindRange("Second", godocx.FindOptions{})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if err := u.MoveBlock(second, godocx.BlockOptions{Position: godocx.PositionBeforeBookmark, Anchor: "Missing"}); err == nil {
+		t.Error("expected error for a missing bookmark")
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "Second", "Cell", "Last", "First", "<w:sectPr/>")
+}
+
+func TestCopyBlockRenamesReferences(t *testing.T) {
+	body := `<w:p><w:bookmarkStart w:id="1" w:name="Result"/><w:r><w:t>Result text</w:t></w:r><w:bookmarkEnd w:id="1"/></w:p>` +
+		`<w:p><w:hyperlink w:anchor="Result"><w:r><w:t>see result</w:t></w:r></w:hyperlink>` +
+		`<w:r><w:fldChar w:fldCharType="begin"/></w:r><w:r><w:instrText xml:space="preserve"> PAGEREF Result \h </w:instrText></w:r><w:r><w:fldChar w:fldCharType="end"/></w:r>` +
+		`<w:fldSimple w:instr=" REF Result \h "><w:r><w:t>Result text</w:t></w:r></w:fldSimple>` +
+		`<w:hyperlink w:anchor="Elsewhere"><w:r><w:t>other</w:t></w:r></w:hyperlink></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	src, err := u.ParagraphRange(0, 1)
+	if err != nil {
+		t.Fatalf("ParagraphRange failed: %v", err)
+	}
+	if _, err := u.CopyBlock(src, godocx.BlockOptions{Position: godocx.PositionEnd}); err != nil {
+		t.Fatalf("CopyBlock failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc,
+		`w:name="Result"`, `w:anchor="Result"`, `PAGEREF Result \h`, `w:instr=" REF Result \h "`,
+		`w:name="Result_2"`, `w:anchor="Result_2"`, `PAGEREF Result_2 \h`, `w:instr=" REF Result_2 \h "`)
+	if strings.Count(doc, `w:anchor="Elsewhere"`) != 2 {
+		t.Error("expected references to bookmarks outside the copy to be kept")
+	}
+}
+
+func TestCopyBlockDropsUniqueIDs(t *testing.T) {
+	body := `<w:p w14:paraId="1A2B3C4D" w14:textId="77777777"><w:r><w:t>Tagged</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Last</w:t></w:r></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	imagePath := filepath.Join(t.TempDir(), "logo.png")
+	createTestImage(t, imagePath, 20, 10)
+	if err := u.InsertImage(godocx.ImageOptions{Path: imagePath, Position: godocx.PositionAfterText, Anchor: "Tagged"}); err != nil {
+		t.Fatalf("InsertImage failed: %v", err)
+	}
+
+	src, err := u.ParagraphRange(0, 1)
+	if err != nil {
+		t.Fatalf("ParagraphRange failed: %v", err)
+	}
+	if _, err := u.CopyBlock(src, godocx.BlockOptions{Position: godocx.PositionEnd}); err != nil {
+		t.Fatalf("CopyBlock failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if strings.Count(doc, "Tagged") != 2 {
+		t.Fatalf("expected the paragraph to be copied: %s", doc)
+	}
+	for _, attr := range []string{`w14:paraId="1A2B3C4D"`, `w14:textId="77777777"`, "wp14:anchorId="} {
+		if got := strings.Count(doc, attr); got != 1 {
+			t.Errorf("expected %s only on the original, found %d", attr, got)
+		}
+	}
+}
@@ -14,9 +14,20 @@ type BookmarkOptions struct {
 	// Position where to insert the bookmark
 	Position InsertPosition
 
-	// Anchor text for position-based insertion (for PositionAfterText/PositionBeforeText)
+	// Target overrides Position and Anchor with an insertion point such as
+	// a FindText match, a table cell or a Range
+	Target *Target
+
+	// Anchor text for position-based insertion (for PositionAfterText/PositionBeforeText),
+	// or bookmark name for PositionAfterBookmark/PositionBeforeBookmark
 	Anchor string
 
+	// Which paragraph containing Anchor to use (1-based, default: first)
+	Occurrence int
+
+	// Treat Anchor as a regular expression
+	AnchorRegex bool
+
 	// Style to apply to the bookmarked text paragraph
 	Style ParagraphStyle
 
@@ -335,22 +346,5 @@ func wrapExistingTextInBookmark(docXML []byte, name, anchorText string, id int)
 
 // insertBookmarkAtPosition inserts bookmark at the specified position
 func insertBookmarkAtPosition(docXML, bookmarkXML []byte, opts BookmarkOptions) ([]byte, error) {
-	switch opts.Position {
-	case PositionBeginning:
-		return insertAtBodyStart(docXML, bookmarkXML)
-	case PositionEnd:
-		return insertAtBodyEnd(docXML, bookmarkXML)
-	case PositionAfterText:
-		if opts.Anchor == "" {
-			return nil, NewValidationError("anchor", "anchor text required for PositionAfterText")
-		}
-		return insertAfterText(docXML, bookmarkXML, opts.Anchor)
-	case PositionBeforeText:
-		if opts.Anchor == "" {
-			return nil, NewValidationError("anchor", "anchor text required for PositionBeforeText")
-		}
-		return insertBeforeText(docXML, bookmarkXML, opts.Anchor)
-	default:
-		return insertAtBodyEnd(docXML, bookmarkXML)
-	}
+	return insertAtPosition(docXML, bookmarkXML, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
 }
@@ -141,101 +141,7 @@ func validateSectionBreakType(breakType SectionBreakType) error {
 
 // insertBreakAtPosition inserts a break (page or section) at the specified position
 func insertBreakAtPosition(raw []byte, breakXML []byte, opts BreakOptions) ([]byte, error) {
-	bodyStart := []byte("<w:body>")
-	bodyEnd := []byte("</w:body>")
-
-	startIdx := bytes.Index(raw, bodyStart)
-	endIdx := bytes.LastIndex(raw, bodyEnd)
-	if startIdx == -1 || endIdx == -1 {
-		return nil, fmt.Errorf("invalid document.xml: missing <w:body>")
-	}
-	startIdx += len(bodyStart)
-
-	switch opts.Position {
-	case PositionBeginning:
-		// Insert at the beginning of body
-		result := make([]byte, 0, len(raw)+len(breakXML))
-		result = append(result, raw[:startIdx]...)
-		result = append(result, breakXML...)
-		result = append(result, raw[startIdx:]...)
-		return result, nil
-
-	case PositionEnd:
-		// Insert at the end of body (before </w:body>)
-		result := make([]byte, 0, len(raw)+len(breakXML))
-		result = append(result, raw[:endIdx]...)
-		result = append(result, breakXML...)
-		result = append(result, raw[endIdx:]...)
-		return result, nil
-
-	case PositionAfterText:
-		if opts.Anchor == "" {
-			return nil, fmt.Errorf("anchor text required for PositionAfterText")
-		}
-		// Find the anchor text and insert after its paragraph
-		return insertBreakAfterAnchor(raw, breakXML, opts.Anchor)
-
-	case PositionBeforeText:
-		if opts.Anchor == "" {
-			return nil, fmt.Errorf("anchor text required for PositionBeforeText")
-		}
-		// Find the anchor text and insert before its paragraph
-		return insertBreakBeforeAnchor(raw, breakXML, opts.Anchor)
-
-	default:
-		return nil, fmt.Errorf("invalid position: %d", opts.Position)
-	}
-}
-
-// insertBreakAfterAnchor inserts a break after the paragraph containing the anchor text
-func insertBreakAfterAnchor(raw []byte, breakXML []byte, anchor string) ([]byte, error) {
-	anchorBytes := []byte(anchor)
-	pos := bytes.Index(raw, anchorBytes)
-	if pos == -1 {
-		return nil, fmt.Errorf("anchor text not found: %s", anchor)
-	}
-
-	// Find the end of the paragraph containing the anchor
-	paraEnd := []byte("</w:p>")
-	endPos := bytes.Index(raw[pos:], paraEnd)
-	if endPos == -1 {
-		return nil, fmt.Errorf("paragraph end not found after anchor text")
-	}
-	insertPos := pos + endPos + len(paraEnd)
-
-	result := make([]byte, 0, len(raw)+len(breakXML))
-	result = append(result, raw[:insertPos]...)
-	result = append(result, breakXML...)
-	result = append(result, raw[insertPos:]...)
-	return result, nil
-}
-
-// insertBreakBeforeAnchor inserts a break before the paragraph containing the anchor text
-func insertBreakBeforeAnchor(raw []byte, breakXML []byte, anchor string) ([]byte, error) {
-	anchorBytes := []byte(anchor)
-	before, _, ok := bytes.Cut(raw, anchorBytes)
-	if !ok {
-		return nil, fmt.Errorf("anchor text not found: %s", anchor)
-	}
-
-	// Find the start of the paragraph containing the anchor
-	paraStart := []byte("<w:p>")
-	// Search backwards from anchor position
-	startPos := bytes.LastIndex(before, paraStart)
-	if startPos == -1 {
-		// Try with paragraph properties
-		paraStart = []byte("<w:p ")
-		startPos = bytes.LastIndex(before, paraStart)
-		if startPos == -1 {
-			return nil, fmt.Errorf("paragraph start not found before anchor text")
-		}
-	}
-
-	result := make([]byte, 0, len(raw)+len(breakXML))
-	result = append(result, raw[:startPos]...)
-	result = append(result, breakXML...)
-	result = append(result, raw[startPos:]...)
-	return result, nil
+	return insertAtPosition(raw, breakXML, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
 }
 
 // SetPageLayout sets the page layout for the current or last section in the document
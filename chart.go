@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ChartKind defines the type of chart
@@ -19,13 +21,24 @@ const (
 	ChartKindLine   ChartKind = "lineChart" // Line chart
 	ChartKindPie    ChartKind = "pieChart"  // Pie chart
 	ChartKindArea   ChartKind = "areaChart" // Area chart
+
+	ChartKindScatter  ChartKind = "scatterChart"  // Scatter (XY) chart with numeric X values
+	ChartKindBubble   ChartKind = "bubbleChart"   // Bubble chart with X values and bubble sizes
+	ChartKindDoughnut ChartKind = "doughnutChart" // Doughnut chart
+	ChartKindRadar    ChartKind = "radarChart"    // Radar chart
+	ChartKindStock    ChartKind = "stockChart"    // Stock chart: high-low-close or open-high-low-close series
+	ChartKindColumn3D ChartKind = "bar3DChart"    // 3-D column chart (3-D bar chart with BarDirectionBar)
+	ChartKindPie3D    ChartKind = "pie3DChart"    // 3-D pie chart
 )
 
 // ChartOptions defines comprehensive options for chart creation
 type ChartOptions struct {
 	// Position where to insert the chart
-	Position InsertPosition
-	Anchor   string // Text anchor for relative positioning
+	Position    InsertPosition
+	Target      *Target // Overrides Position and Anchor (match, table cell, Range...)
+	Anchor      string  // Text anchor for relative positioning
+	Occurrence  int     // Which paragraph containing Anchor to use (1-based, default: first)
+	AnchorRegex bool    // Treat Anchor as a regular expression
 
 	// Chart type (default: Column)
 	ChartKind ChartKind
@@ -41,6 +54,15 @@ type ChartOptions struct {
 	ShowLegend     bool         // Show legend (default: true)
 	LegendPosition string       // Legend position: "r" (right), "l" (left), "t" (top), "b" (bottom)
 
+	// Multi-level, date and numeric categories (see ExtendedChartOptions)
+	CategoryLevels  [][]string
+	CategoryDates   []time.Time
+	CategoryNumbers []float64
+	CategoryFormat  string
+
+	// How Missing values are drawn: "gap", "zero" or "span" (default: "gap")
+	DisplayBlanksAs string
+
 	// Chart dimensions (default: spans between margins)
 	Width  int // Width in EMUs (English Metric Units), 0 for default (6099523 = ~6.5")
 	Height int // Height in EMUs, 0 for default (3340467 = ~3.5")
@@ -63,6 +85,12 @@ func (u *Updater) InsertChart(opts ChartOptions) error {
 	// Apply defaults
 	opts = applyChartDefaults(opts)
 
+	// Chart types other than column are drawn by the extended chart
+	// generator
+	if opts.ChartKind != ChartKindColumn {
+		return u.InsertChartExtended(extendedFromChartOptions(opts))
+	}
+
 	// Find next available chart index
 	chartIndex := u.findNextChartIndex()
 
@@ -103,11 +131,68 @@ func (u *Updater) InsertChart(opts ChartOptions) error {
 	return nil
 }
 
+// DeleteChart removes a chart by index (1-based, as in UpdateChart) from the
+// document. The chart part, its relationships, its embedded workbook and the
+// related content type overrides are removed from the package as well.
+func (u *Updater) DeleteChart(chartIndex int) error {
+	if u == nil {
+		return fmt.Errorf("updater is nil")
+	}
+	if chartIndex < 1 {
+		return NewValidationError("chartIndex", "chart index must be >= 1")
+	}
+
+	chartPart := fmt.Sprintf("word/charts/chart%d.xml", chartIndex)
+	if _, err := os.Stat(filepath.Join(u.tempDir, filepath.FromSlash(chartPart))); err != nil {
+		return NewChartNotFoundError(chartIndex)
+	}
+
+	rels, err := readRelationships(filepath.Join(u.tempDir, "word", "_rels", "document.xml.rels"))
+	if err != nil {
+		return err
+	}
+	var relIDs []string
+	for _, rel := range rels.Relationships {
+		if rel.TargetMode != "External" && resolvePartTarget(documentPart, rel.Target) == chartPart {
+			relIDs = append(relIDs, rel.ID)
+		}
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return err
+	}
+
+	updated, _ := removeDrawings(docXML, func(drawingXML []byte) bool {
+		chartStart := bytes.Index(drawingXML, []byte("<c:chart "))
+		if chartStart == -1 {
+			return false
+		}
+		chartTag := drawingXML[chartStart : chartStart+bytes.IndexByte(drawingXML[chartStart:], '>')]
+		return slices.Contains(relIDs, xmlAttr(chartTag, "r:id"))
+	}, 0)
+
+	if err := u.writeDocumentXML(updated); err != nil {
+		return err
+	}
+	if err := u.releaseRelationships(updated, relIDs); err != nil {
+		return fmt.Errorf("remove chart parts: %w", err)
+	}
+
+	// A chart not referenced by the document is removed all the same
+	return u.removePartIfUnreferenced(chartPart)
+}
+
 // validateChartOptions validates chart creation options
 func validateChartOptions(opts ChartOptions) error {
-	if len(opts.Categories) == 0 {
+	xy := isXYChartKind(opts.ChartKind)
+	categories := opts.categoryData()
+	if categories.count() == 0 && !xy {
 		return fmt.Errorf("categories cannot be empty")
 	}
+	if err := categories.validate(); err != nil {
+		return err
+	}
 	if len(opts.Series) == 0 {
 		return fmt.Errorf("at least one series is required")
 	}
@@ -117,12 +202,19 @@ func validateChartOptions(opts ChartOptions) error {
 		if strings.TrimSpace(series.Name) == "" {
 			return fmt.Errorf("series[%d] name cannot be empty", i)
 		}
-		if len(series.Values) != len(opts.Categories) {
-			return fmt.Errorf("series[%d] values length (%d) must match categories length (%d)", i, len(series.Values), len(opts.Categories))
+		if xy {
+			if err := validateXYSeries(i, opts.ChartKind, series.Values, series.XValues, series.BubbleSizes); err != nil {
+				return err
+			}
+		} else if len(series.Values) != categories.count() {
+			return fmt.Errorf("series[%d] values length (%d) must match categories length (%d)", i, len(series.Values), categories.count())
+		}
+		if err := validateSeriesValues(i, series.Values, series.XValues, series.BubbleSizes); err != nil {
+			return err
 		}
 	}
 
-	return nil
+	return validateDisplayBlanksAs(opts.DisplayBlanksAs)
 }
 
 // applyChartDefaults sets default values for unspecified options
@@ -139,6 +231,9 @@ func applyChartDefaults(opts ChartOptions) ChartOptions {
 	if opts.ShowLegend && opts.LegendPosition == "" {
 		opts.LegendPosition = "r" // Right by default
 	}
+	if opts.DisplayBlanksAs == "" {
+		opts.DisplayBlanksAs = "gap"
+	}
 	return opts
 }
 
@@ -270,7 +365,7 @@ func generateChartXML(opts ChartOptions) []byte {
 	}
 
 	buf.WriteString(`<c:plotVisOnly val="1"/>`)
-	buf.WriteString(`<c:dispBlanksAs val="gap"/>`)
+	buf.WriteString(fmt.Sprintf(`<c:dispBlanksAs val="%s"/>`, opts.DisplayBlanksAs))
 	buf.WriteString(`<c:showDLblsOverMax val="0"/>`)
 
 	buf.WriteString(`</c:chart>`)
@@ -295,7 +390,9 @@ func generateColumnChartXML(opts ChartOptions) string {
 	buf.WriteString(`<c:varyColors val="0"/>`)
 
 	// Series
+	categories := opts.categoryData()
 	for i, series := range opts.Series {
+		_, col, _ := seriesColumns(ChartKindColumn, i, categories.columns())
 		buf.WriteString(fmt.Sprintf(`<c:ser>
 <c:idx val="%d"/>
 <c:order val="%d"/>
@@ -307,32 +404,20 @@ func generateColumnChartXML(opts ChartOptions) string {
       <c:pt idx="0"><c:v>%s</c:v></c:pt>
     </c:strCache>
   </c:strRef>
-</c:tx>`, i, i, columnLetter(i+1), xmlEscape(series.Name)))
+</c:tx>`, i, i, columnLetter(col), xmlEscape(series.Name)))
 
-		buf.WriteString(`<c:cat>
-  <c:strRef>
-    <c:f>Sheet1!$A$2:$A$`)
-		buf.WriteString(fmt.Sprintf("%d", len(opts.Categories)+1))
-		buf.WriteString(`</c:f>
-    <c:strCache>
-      <c:ptCount val="`)
-		buf.WriteString(fmt.Sprintf("%d", len(opts.Categories)))
-		buf.WriteString(`"/>`)
-		for j, cat := range opts.Categories {
-			buf.WriteString(fmt.Sprintf(`<c:pt  idx="%d"><c:v>%s</c:v></c:pt>`, j, xmlEscape(cat)))
-		}
-		buf.WriteString(`</c:strCache>
-  </c:strRef>
-</c:cat>`)
+		buf.WriteString(`<c:cat>`)
+		buf.WriteString(categories.refXML("c:", categories.formula("Sheet1")))
+		buf.WriteString(`</c:cat>`)
 
 		buf.WriteString(`<c:val>
   <c:numRef>
     <c:f>Sheet1!$`)
-		buf.WriteString(columnLetter(i + 1))
+		buf.WriteString(columnLetter(col))
 		buf.WriteString(`$2:$`)
-		buf.WriteString(columnLetter(i + 1))
+		buf.WriteString(columnLetter(col))
 		buf.WriteString(`$`)
-		buf.WriteString(fmt.Sprintf("%d", len(opts.Categories)+1))
+		buf.WriteString(fmt.Sprintf("%d", categories.count()+1))
 		buf.WriteString(`</c:f>
     <c:numCache>
       <c:formatCode>General</c:formatCode>
@@ -340,6 +425,9 @@ func generateColumnChartXML(opts ChartOptions) string {
 		buf.WriteString(fmt.Sprintf("%d", len(series.Values)))
 		buf.WriteString(`"/>`)
 		for j, val := range series.Values {
+			if IsMissing(val) {
+				continue
+			}
 			buf.WriteString(fmt.Sprintf(`<c:pt idx="%d"><c:v>%g</c:v></c:pt>`, j, val))
 		}
 		buf.WriteString(`</c:numCache>
@@ -419,7 +507,7 @@ func (u *Updater) createEmbeddedWorkbook(workbookPath string, opts ChartOptions)
 	}
 
 	// Create xl/styles.xml
-	if err := addZipFile(zipWriter, "xl/styles.xml", generateStylesXML()); err != nil {
+	if err := addZipFile(zipWriter, "xl/styles.xml", generateStylesXML(opts.categoryData().cellFormat())); err != nil {
 		return err
 	}
 
@@ -479,32 +567,60 @@ func generateWorkbookXMLRels() []byte {
 
 // generateSheetXML creates the xl/worksheets/sheet1.xml with chart data
 func generateSheetXML(opts ChartOptions) []byte {
+	if isXYChartKind(opts.ChartKind) {
+		return generateXYSheetXML(opts)
+	}
+
 	var buf bytes.Buffer
 
 	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
 <worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
   <sheetData>`)
 
-	// Header row with series names
+	categories := opts.categoryData()
+	catCol := categories.columns()
+
+	// Header row with series names after the empty category headers
 	buf.WriteString(`<row r="1">`)
-	buf.WriteString(`<c r="A1" t="str"><v></v></c>`) // Empty cell at A1
+	for c := 1; c <= catCol; c++ {
+		buf.WriteString(fmt.Sprintf(`<c r="%s1" t="str"><v></v></c>`, columnLetter(c)))
+	}
 	for i, series := range opts.Series {
-		col := columnLetter(i + 2) // B, C, D, etc.
+		col := columnLetter(catCol + i + 1)
 		buf.WriteString(fmt.Sprintf(`<c r="%s1" t="str"><v>%s</v></c>`, col, xmlEscape(series.Name)))
 	}
 	buf.WriteString(`</row>`)
 
+	// Date and numeric categories take the category number format
+	style := ""
+	if categories.cellFormat() != "" {
+		style = ` s="1"`
+	}
+	values := categories.values()
+
 	// Data rows
-	for i, category := range opts.Categories {
+	for i := range categories.count() {
 		rowNum := i + 2
 		buf.WriteString(fmt.Sprintf(`<row r="%d">`, rowNum))
 
-		// Category in column A
-		buf.WriteString(fmt.Sprintf(`<c r="A%d" t="str"><v>%s</v></c>`, rowNum, xmlEscape(category)))
+		// Outer levels at the start of their groups, then the category
+		for level := range categories.levels {
+			if label, ok := categories.groupLabel(level, i); ok {
+				buf.WriteString(fmt.Sprintf(`<c r="%s%d" t="str"><v>%s</v></c>`, columnLetter(level+1), rowNum, xmlEscape(label)))
+			}
+		}
+		if categories.numeric() {
+			buf.WriteString(fmt.Sprintf(`<c r="%s%d"%s><v>%s</v></c>`, columnLetter(catCol), rowNum, style, formatFloat(values[i])))
+		} else {
+			buf.WriteString(fmt.Sprintf(`<c r="%s%d" t="str"><v>%s</v></c>`, columnLetter(catCol), rowNum, xmlEscape(categories.labels[i])))
+		}
 
 		// Values for each series
 		for j, series := range opts.Series {
-			col := columnLetter(j + 2)
+			if IsMissing(series.Values[i]) {
+				continue // Empty cell
+			}
+			col := columnLetter(catCol + j + 1)
 			buf.WriteString(fmt.Sprintf(`<c r="%s%d"><v>%g</v></c>`, col, rowNum, series.Values[i]))
 		}
 
@@ -517,11 +633,26 @@ func generateSheetXML(opts ChartOptions) []byte {
 	return buf.Bytes()
 }
 
-// generateStylesXML creates a minimal xl/styles.xml
-func generateStylesXML() []byte {
+// generateStylesXML creates a minimal xl/styles.xml. A number format adds
+// a second cell style (s="1") using it, for date and numeric categories.
+func generateStylesXML(numberFormat string) []byte {
+	numFmts := `<numFmts count="0"/>`
+	cellXfs := `<cellXfs count="1">
+    <xf numFmtId="0" fontId="0" fillId="0" borderId="0"/>
+  </cellXfs>`
+	if numberFormat != "" {
+		numFmts = `<numFmts count="1">
+    <numFmt numFmtId="164" formatCode="` + xmlEscape(numberFormat) + `"/>
+  </numFmts>`
+		cellXfs = `<cellXfs count="2">
+    <xf numFmtId="0" fontId="0" fillId="0" borderId="0"/>
+    <xf numFmtId="164" fontId="0" fillId="0" borderId="0" applyNumberFormat="1"/>
+  </cellXfs>`
+	}
+
 	return []byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
 <styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
-  <numFmts count="0"/>
+  ` + numFmts + `
   <fonts count="1">
     <font><sz val="11"/><name val="Calibri"/></font>
   </fonts>
@@ -532,9 +663,7 @@ func generateStylesXML() []byte {
   <borders count="1">
     <border><left/><right/><top/><bottom/><diagonal/></border>
   </borders>
-  <cellXfs count="1">
-    <xf numFmtId="0" fontId="0" fillId="0" borderId="0"/>
-  </cellXfs>
+  ` + cellXfs + `
 </styleSheet>`)
 }
 
@@ -601,26 +730,7 @@ func (u *Updater) insertChartDrawing(chartIndex int, relID string, opts ChartOpt
 	}
 
 	// Insert based on position
-	var updated []byte
-	switch opts.Position {
-	case PositionBeginning:
-		updated, err = insertAtBodyStart(raw, contentToInsert)
-	case PositionEnd:
-		updated, err = insertAtBodyEnd(raw, contentToInsert)
-	case PositionAfterText:
-		if opts.Anchor == "" {
-			return fmt.Errorf("anchor text required for PositionAfterText")
-		}
-		updated, err = insertAfterText(raw, contentToInsert, opts.Anchor)
-	case PositionBeforeText:
-		if opts.Anchor == "" {
-			return fmt.Errorf("anchor text required for PositionBeforeText")
-		}
-		updated, err = insertBeforeText(raw, contentToInsert, opts.Anchor)
-	default:
-		return fmt.Errorf("invalid insert position")
-	}
-
+	updated, err := insertAtPosition(raw, contentToInsert, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
 	if err != nil {
 		return fmt.Errorf("insert chart: %w", err)
 	}
@@ -708,9 +818,14 @@ func (u *Updater) InsertChartExtended(opts ExtendedChartOptions) error {
 
 // validateExtendedChartOptions validates extended chart options
 func validateExtendedChartOptions(opts ExtendedChartOptions) error {
-	if len(opts.Categories) == 0 {
+	xy := len(opts.Series) > 0 && isXYChartKind(seriesChartKind(opts.Series[0], opts))
+	categories := opts.categoryData()
+	if categories.count() == 0 && !xy {
 		return fmt.Errorf("categories cannot be empty")
 	}
+	if err := categories.validate(); err != nil {
+		return err
+	}
 	if len(opts.Series) == 0 {
 		return fmt.Errorf("at least one series is required")
 	}
@@ -720,22 +835,123 @@ func validateExtendedChartOptions(opts ExtendedChartOptions) error {
 		if strings.TrimSpace(series.Name) == "" {
 			return fmt.Errorf("series[%d] name cannot be empty", i)
 		}
-		if len(series.Values) != len(opts.Categories) {
+		if err := validateSeriesAnalysis(i, seriesChartKind(series, opts), series); err != nil {
+			return err
+		}
+		for point := range series.PointColors {
+			if point < 0 || point >= len(series.Values) {
+				return fmt.Errorf("series[%d] PointColors: point %d out of range", i, point)
+			}
+		}
+		if xy {
+			if err := validateXYSeries(i, seriesChartKind(series, opts), series.Values, series.XValues, series.BubbleSizes); err != nil {
+				return err
+			}
+		} else if len(series.Values) != categories.count() {
 			return fmt.Errorf("series[%d] values length (%d) must match categories length (%d)",
-				i, len(series.Values), len(opts.Categories))
+				i, len(series.Values), categories.count())
+		}
+		if err := validateSeriesValues(i, series.Values, series.XValues, series.BubbleSizes); err != nil {
+			return err
+		}
+	}
+	if opts.Properties != nil {
+		if err := validateDisplayBlanksAs(opts.Properties.DisplayBlanksAs); err != nil {
+			return err
 		}
 	}
 
+	if err := validateComboSeries(opts); err != nil {
+		return err
+	}
+
 	// Validate axes if provided
 	if opts.CategoryAxis != nil {
 		if err := validateAxisOptions("CategoryAxis", opts.CategoryAxis); err != nil {
 			return err
 		}
+		if opts.CategoryAxis.DateAxis && xy {
+			return fmt.Errorf("CategoryAxis: scatter and bubble charts cannot use a date axis")
+		}
 	}
 	if opts.ValueAxis != nil {
 		if err := validateAxisOptions("ValueAxis", opts.ValueAxis); err != nil {
 			return err
 		}
+		if opts.ValueAxis.DateAxis {
+			return fmt.Errorf("ValueAxis: only the category axis can be a date axis")
+		}
+	}
+	if opts.SecondaryValueAxis != nil {
+		if err := validateAxisOptions("SecondaryValueAxis", opts.SecondaryValueAxis); err != nil {
+			return err
+		}
+		if opts.SecondaryValueAxis.DateAxis {
+			return fmt.Errorf("SecondaryValueAxis: only the category axis can be a date axis")
+		}
+	}
+
+	// Validate scatter and bubble chart options if provided
+	if opts.ScatterChartOptions != nil {
+		switch opts.ScatterChartOptions.Style {
+		case "", ScatterStyleMarker, ScatterStyleLine, ScatterStyleLineMarker, ScatterStyleSmooth, ScatterStyleSmoothMarker:
+		default:
+			return fmt.Errorf("ScatterChartOptions.Style %q is not supported", opts.ScatterChartOptions.Style)
+		}
+	}
+	if opts.BubbleChartOptions != nil {
+		if opts.BubbleChartOptions.Scale < 0 || opts.BubbleChartOptions.Scale > 300 {
+			return fmt.Errorf("BubbleChartOptions.Scale must be between 0 and 300")
+		}
+	}
+
+	// Validate doughnut, radar, stock and 3-D chart options if provided
+	if opts.DoughnutChartOptions != nil {
+		if h := opts.DoughnutChartOptions.HoleSize; h != 0 && (h < 10 || h > 90) {
+			return fmt.Errorf("DoughnutChartOptions.HoleSize must be between 10 and 90")
+		}
+		if a := opts.DoughnutChartOptions.FirstSliceAngle; a < 0 || a > 360 {
+			return fmt.Errorf("DoughnutChartOptions.FirstSliceAngle must be between 0 and 360")
+		}
+		for point, explosion := range opts.DoughnutChartOptions.ExplodedPoints {
+			if point < 0 || point >= categories.count() {
+				return fmt.Errorf("DoughnutChartOptions.ExplodedPoints: point %d out of range", point)
+			}
+			if explosion < 0 || explosion > 400 {
+				return fmt.Errorf("DoughnutChartOptions.ExplodedPoints: explosion must be between 0 and 400")
+			}
+		}
+	}
+	if opts.RadarChartOptions != nil {
+		switch opts.RadarChartOptions.Style {
+		case "", RadarStyleStandard, RadarStyleMarker, RadarStyleFilled:
+		default:
+			return fmt.Errorf("RadarChartOptions.Style %q is not supported", opts.RadarChartOptions.Style)
+		}
+	}
+	if seriesChartKind(opts.Series[0], opts) == ChartKindStock {
+		if len(opts.Series) != 3 && len(opts.Series) != 4 {
+			return fmt.Errorf("stock charts need 3 series (high, low, close) or 4 (open, high, low, close), got %d", len(opts.Series))
+		}
+		if opts.StockChartOptions != nil && opts.StockChartOptions.UpDownBars && len(opts.Series) != 4 {
+			return fmt.Errorf("StockChartOptions.UpDownBars needs open, high, low and close series")
+		}
+	}
+	if opts.Chart3DOptions != nil {
+		if r := opts.Chart3DOptions.RotationX; r < -90 || r > 90 {
+			return fmt.Errorf("Chart3DOptions.RotationX must be between -90 and 90")
+		}
+		if r := opts.Chart3DOptions.RotationY; r < 0 || r > 360 {
+			return fmt.Errorf("Chart3DOptions.RotationY must be between 0 and 360")
+		}
+		if d := opts.Chart3DOptions.DepthPercent; d != 0 && (d < 20 || d > 2000) {
+			return fmt.Errorf("Chart3DOptions.DepthPercent must be between 20 and 2000")
+		}
+		switch opts.Chart3DOptions.Shape {
+		case "", BarShapeBox, BarShapeCylinder, BarShapeCone, BarShapePyramid:
+		default:
+			return fmt.Errorf("Chart3DOptions.Shape %q is not supported", opts.Chart3DOptions.Shape)
+		}
 	}
 
 	// Validate bar chart options if provided
@@ -765,6 +981,33 @@ func validateAxisOptions(name string, axis *AxisOptions) error {
 	if axis.MajorUnit != nil && axis.MinorUnit != nil && *axis.MinorUnit >= *axis.MajorUnit {
 		return fmt.Errorf("%s: MinorUnit must be less than MajorUnit", name)
 	}
+	if axis.LogBase != 0 {
+		if axis.LogBase < 2 || axis.LogBase > 1000 {
+			return fmt.Errorf("%s: LogBase must be between 2 and 1000", name)
+		}
+		if axis.Min != nil && *axis.Min <= 0 {
+			return fmt.Errorf("%s: Min must be positive on a logarithmic axis", name)
+		}
+	}
+	switch axis.Orientation {
+	case "", AxisOrientationMinMax, AxisOrientationMaxMin:
+	default:
+		return fmt.Errorf("%s: Orientation %q is not supported", name, axis.Orientation)
+	}
+	switch axis.DisplayUnits {
+	case "", DisplayUnitsHundreds, DisplayUnitsThousands, DisplayUnitsTenThousands, DisplayUnitsHundredThousands,
+		DisplayUnitsMillions, DisplayUnitsTenMillions, DisplayUnitsHundredMillions, DisplayUnitsBillions, DisplayUnitsTrillions:
+	default:
+		return fmt.Errorf("%s: DisplayUnits %q is not supported", name, axis.DisplayUnits)
+	}
+	if axis.DisplayUnitsLabel != "" && axis.DisplayUnits == "" {
+		return fmt.Errorf("%s: DisplayUnitsLabel needs DisplayUnits", name)
+	}
+	switch axis.BaseTimeUnit {
+	case "", TimeUnitDays, TimeUnitMonths, TimeUnitYears:
+	default:
+		return fmt.Errorf("%s: BaseTimeUnit %q is not supported", name, axis.BaseTimeUnit)
+	}
 	return nil
 }
 
@@ -774,6 +1017,11 @@ func applyExtendedChartDefaults(opts ExtendedChartOptions) ExtendedChartOptions
 	if opts.ChartKind == "" {
 		opts.ChartKind = ChartKindColumn
 	}
+	// Series of chart types that cannot be combined with others (pie,
+	// scatter, etc.) set the chart type of the whole chart
+	if len(opts.Series) > 0 && opts.Series[0].ChartKind != "" && !isComboChartKind(opts.Series[0].ChartKind) {
+		opts.ChartKind = opts.Series[0].ChartKind
+	}
 	if opts.Width == 0 {
 		opts.Width = 6099523 // ~6.5 inches
 	}
@@ -794,6 +1042,10 @@ func applyExtendedChartDefaults(opts ExtendedChartOptions) ExtendedChartOptions
 	if opts.CategoryAxis == nil {
 		opts.CategoryAxis = &AxisOptions{}
 	}
+	// Date and numeric category labels show in the category number format
+	if categories := opts.categoryData(); categories.numeric() && opts.CategoryAxis.NumberFormat == "" {
+		opts.CategoryAxis.NumberFormat = categories.numberFormat()
+	}
 	opts.CategoryAxis = applyAxisDefaults(opts.CategoryAxis, true)
 
 	// Apply value axis defaults
@@ -802,6 +1054,20 @@ func applyExtendedChartDefaults(opts ExtendedChartOptions) ExtendedChartOptions
 	}
 	opts.ValueAxis = applyAxisDefaults(opts.ValueAxis, false)
 
+	// Apply secondary value axis defaults: on the right, without gridlines
+	// unless asked for
+	if slices.ContainsFunc(opts.Series, func(s SeriesOptions) bool { return s.SecondaryAxis }) {
+		if opts.SecondaryValueAxis == nil {
+			opts.SecondaryValueAxis = &AxisOptions{}
+		}
+		if opts.SecondaryValueAxis.Position == "" {
+			opts.SecondaryValueAxis.Position = AxisPositionRight
+		}
+		gridlines := opts.SecondaryValueAxis.MajorGridlines
+		opts.SecondaryValueAxis = applyAxisDefaults(opts.SecondaryValueAxis, false)
+		opts.SecondaryValueAxis.MajorGridlines = gridlines
+	}
+
 	// Apply chart properties defaults
 	if opts.Properties == nil {
 		opts.Properties = &ChartProperties{}
@@ -817,17 +1083,13 @@ func applyExtendedChartDefaults(opts ExtendedChartOptions) ExtendedChartOptions
 	}
 	opts.Properties.PlotVisibleOnly = true // Always true
 
-	// Apply bar chart defaults if chart is bar/column type
-	if opts.ChartKind == ChartKindColumn || opts.ChartKind == ChartKindBar {
+	// Apply bar chart defaults if chart or any series is bar/column type
+	if opts.ChartKind == ChartKindColumn || opts.ChartKind == ChartKindColumn3D || slices.ContainsFunc(opts.Series, func(s SeriesOptions) bool { return s.ChartKind == ChartKindColumn }) {
 		if opts.BarChartOptions == nil {
 			opts.BarChartOptions = &BarChartOptions{}
 		}
 		if opts.BarChartOptions.Direction == "" {
-			if opts.ChartKind == ChartKindColumn {
-				opts.BarChartOptions.Direction = BarDirectionColumn
-			} else {
-				opts.BarChartOptions.Direction = BarDirectionBar
-			}
+			opts.BarChartOptions.Direction = BarDirectionColumn
 		}
 		if opts.BarChartOptions.Grouping == "" {
 			opts.BarChartOptions.Grouping = BarGroupingClustered
@@ -837,6 +1099,71 @@ func applyExtendedChartDefaults(opts ExtendedChartOptions) ExtendedChartOptions
 		}
 	}
 
+	// Apply scatter and bubble chart defaults
+	switch opts.ChartKind {
+	case ChartKindScatter:
+		if opts.ScatterChartOptions == nil {
+			opts.ScatterChartOptions = &ScatterChartOptions{}
+		}
+		if opts.ScatterChartOptions.Style == "" {
+			opts.ScatterChartOptions.Style = ScatterStyleMarker
+		}
+	case ChartKindBubble:
+		if opts.BubbleChartOptions == nil {
+			opts.BubbleChartOptions = &BubbleChartOptions{}
+		}
+		if opts.BubbleChartOptions.Scale == 0 {
+			opts.BubbleChartOptions.Scale = 100
+		}
+	case ChartKindDoughnut:
+		if opts.DoughnutChartOptions == nil {
+			opts.DoughnutChartOptions = &DoughnutChartOptions{}
+		}
+		if opts.DoughnutChartOptions.HoleSize == 0 {
+			opts.DoughnutChartOptions.HoleSize = 50
+		}
+	case ChartKindRadar:
+		if opts.RadarChartOptions == nil {
+			opts.RadarChartOptions = &RadarChartOptions{}
+		}
+		if opts.RadarChartOptions.Style == "" {
+			opts.RadarChartOptions.Style = RadarStyleMarker
+		}
+	case ChartKindStock:
+		// High-low lines by default, with up/down bars for open-high-low-close
+		if opts.StockChartOptions == nil {
+			opts.StockChartOptions = &StockChartOptions{
+				HighLowLines: true,
+				UpDownBars:   len(opts.Series) == 4,
+			}
+		}
+	case ChartKindColumn3D, ChartKindPie3D:
+		if opts.Chart3DOptions == nil {
+			opts.Chart3DOptions = &Chart3DOptions{}
+		}
+		if opts.Chart3DOptions.RotationX == 0 {
+			opts.Chart3DOptions.RotationX = 15
+			if opts.ChartKind == ChartKindPie3D {
+				opts.Chart3DOptions.RotationX = 30
+			}
+		}
+		if opts.Chart3DOptions.RotationY == 0 && opts.ChartKind == ChartKindColumn3D {
+			opts.Chart3DOptions.RotationY = 20
+		}
+		if opts.Chart3DOptions.DepthPercent == 0 {
+			opts.Chart3DOptions.DepthPercent = 100
+		}
+		if opts.Chart3DOptions.Shape == "" {
+			opts.Chart3DOptions.Shape = BarShapeBox
+		}
+	}
+
+	// Apply the palette to series without their own color, or to the points
+	// of pie and doughnut series
+	if len(opts.Palette) > 0 {
+		opts.Series = applyChartPalette(opts.Series, opts)
+	}
+
 	// Apply data label defaults if specified
 	if opts.DataLabels != nil {
 		if opts.DataLabels.Position == "" {
@@ -872,6 +1199,14 @@ func applyAxisDefaults(axis *AxisOptions, isCategoryAxis bool) *AxisOptions {
 	if axis.TickLabelPos == "" {
 		axis.TickLabelPos = TickLabelNextTo
 	}
+	if axis.DateAxis {
+		if axis.BaseTimeUnit == "" {
+			axis.BaseTimeUnit = TimeUnitDays
+		}
+		if axis.NumberFormat == "" {
+			axis.NumberFormat = "m/d/yyyy"
+		}
+	}
 	if axis.NumberFormat == "" {
 		axis.NumberFormat = "General"
 	}
@@ -890,20 +1225,28 @@ func convertToChartOptions(opts ExtendedChartOptions) ChartOptions {
 	series := make([]SeriesData, len(opts.Series))
 	for i, s := range opts.Series {
 		series[i] = SeriesData{
-			Name:   s.Name,
-			Values: s.Values,
-			Color:  s.Color,
+			Name:        s.Name,
+			Values:      s.Values,
+			Color:       s.Color,
+			XValues:     s.XValues,
+			BubbleSizes: s.BubbleSizes,
 		}
 	}
 
 	return ChartOptions{
-		Position:   opts.Position,
-		Anchor:     opts.Anchor,
-		ChartKind:  opts.ChartKind,
-		Title:      opts.Title,
-		Categories: opts.Categories,
-		Series:     series,
-		ShowLegend: opts.Legend != nil && opts.Legend.Show,
+		Position:        opts.Position,
+		Anchor:          opts.Anchor,
+		Occurrence:      opts.Occurrence,
+		AnchorRegex:     opts.AnchorRegex,
+		ChartKind:       opts.ChartKind,
+		Title:           opts.Title,
+		Categories:      opts.Categories,
+		CategoryLevels:  opts.CategoryLevels,
+		CategoryDates:   opts.CategoryDates,
+		CategoryNumbers: opts.CategoryNumbers,
+		CategoryFormat:  opts.CategoryFormat,
+		Series:          series,
+		ShowLegend:      opts.Legend != nil && opts.Legend.Show,
 		LegendPosition: func() string {
 			if opts.Legend != nil {
 				return opts.Legend.Position
@@ -959,32 +1302,78 @@ func generateExtendedChartXML(opts ExtendedChartOptions) []byte {
 
 	// Chart title
 	if opts.Title != "" {
-		buf.WriteString(generateTitleXML(opts.Title, opts.TitleOverlay))
+		buf.WriteString(generateTitleXML(opts.Title, opts.TitleOverlay, opts.TitleFont))
 	}
 
 	buf.WriteString(`<c:autoTitleDeleted val="0"/>`)
+
+	// 3-D view
+	groups := extendedPlotGroups(opts)
+	if groups[0].kind == ChartKindColumn3D || groups[0].kind == ChartKindPie3D {
+		buf.WriteString(generateView3DXML(opts.Chart3DOptions, groups[0].kind))
+	}
+
 	buf.WriteString(`<c:plotArea>`)
 	buf.WriteString(`<c:layout/>`)
 
-	// Generate chart type specific content
-	switch opts.ChartKind {
-	case "barChart": // ChartKindColumn and ChartKindBar both use barChart
-		buf.WriteString(generateExtendedBarChartXML(opts))
-	case ChartKindLine:
-		buf.WriteString(generateExtendedLineChartXML(opts))
-	case ChartKindPie:
-		buf.WriteString(generateExtendedPieChartXML(opts))
-	case ChartKindArea:
-		buf.WriteString(generateExtendedAreaChartXML(opts))
+	// Generate chart type specific content, one plot per chart type and axis
+	secondary := false
+	for _, group := range groups {
+		switch group.kind {
+		case "barChart": // ChartKindColumn and ChartKindBar both use barChart
+			buf.WriteString(generateExtendedBarChartXML(opts, group))
+		case ChartKindLine:
+			buf.WriteString(generateExtendedLineChartXML(opts, group))
+		case ChartKindPie:
+			buf.WriteString(generateExtendedPieChartXML(opts, group))
+		case ChartKindArea:
+			buf.WriteString(generateExtendedAreaChartXML(opts, group))
+		case ChartKindScatter:
+			buf.WriteString(generateExtendedScatterChartXML(opts, group))
+		case ChartKindBubble:
+			buf.WriteString(generateExtendedBubbleChartXML(opts, group))
+		case ChartKindDoughnut:
+			buf.WriteString(generateExtendedDoughnutChartXML(opts, group))
+		case ChartKindRadar:
+			buf.WriteString(generateExtendedRadarChartXML(opts, group))
+		case ChartKindStock:
+			buf.WriteString(generateExtendedStockChartXML(opts, group))
+		case ChartKindColumn3D:
+			buf.WriteString(generateExtendedBar3DChartXML(opts, group))
+		case ChartKindPie3D:
+			buf.WriteString(generateExtendedPie3DChartXML(opts, group))
+		default:
+			buf.WriteString(generateExtendedBarChartXML(opts, group)) // Default to bar/column
+		}
+		secondary = secondary || group.secondary
+	}
+
+	// Axes (category and value for most chart types, except pie and
+	// doughnut; scatter and bubble charts have value axes for X and Y). The
+	// secondary category axis is hidden; its value axis crosses at the
+	// maximum so it is drawn on the right.
+	switch {
+	case isPieChartKind(groups[0].kind):
+	case isXYChartKind(groups[0].kind):
+		buf.WriteString(generateValueAxisXML(opts.CategoryAxis, primaryCategoryAxisID, primaryValueAxisID, "autoZero", "midCat"))
+		buf.WriteString(generateValueAxisXML(opts.ValueAxis, primaryValueAxisID, primaryCategoryAxisID, "autoZero", "midCat"))
 	default:
-		buf.WriteString(generateExtendedBarChartXML(opts)) // Default to bar/column
+		buf.WriteString(generateCategoryAxisXML(opts.CategoryAxis, primaryCategoryAxisID, primaryValueAxisID))
+		buf.WriteString(generateValueAxisXML(opts.ValueAxis, primaryValueAxisID, primaryCategoryAxisID, "autoZero", "between"))
+		if secondary {
+			buf.WriteString(generateCategoryAxisXML(&AxisOptions{
+				Position:      AxisPositionBottom,
+				MajorTickMark: TickMarkOut,
+				MinorTickMark: TickMarkNone,
+				TickLabelPos:  TickLabelNextTo,
+				NumberFormat:  "General",
+			}, secondaryCategoryAxisID, secondaryValueAxisID))
+			buf.WriteString(generateValueAxisXML(opts.SecondaryValueAxis, secondaryValueAxisID, secondaryCategoryAxisID, "max", "between"))
+		}
 	}
 
-	// Axes (category and value for most chart types, except pie)
-	if opts.ChartKind != ChartKindPie {
-		buf.WriteString(generateCategoryAxisXML(opts.CategoryAxis))
-		buf.WriteString(generateValueAxisXML(opts.ValueAxis))
-	}
+	// Plot area fill and border
+	buf.WriteString(generateAreaStyleXML(opts.PlotArea))
 
 	buf.WriteString(`</c:plotArea>`)
 
@@ -999,6 +1388,9 @@ func generateExtendedChartXML(opts ExtendedChartOptions) []byte {
 
 	buf.WriteString(`</c:chart>`)
 
+	// Chart area fill and border
+	buf.WriteString(generateAreaStyleXML(opts.ChartArea))
+
 	// External data reference
 	buf.WriteString(`<c:externalData r:id="rId1">`)
 	buf.WriteString(`<c:autoUpdate val="0"/>`)
@@ -1009,8 +1401,9 @@ func generateExtendedChartXML(opts ExtendedChartOptions) []byte {
 	return buf.Bytes()
 }
 
-// generateTitleXML generates chart title XML
-func generateTitleXML(title string, overlay bool) string {
+// generateTitleXML generates chart title XML in the given font (nil for the
+// default font)
+func generateTitleXML(title string, overlay bool, font *ChartFont) string {
 	var buf bytes.Buffer
 	buf.WriteString(`<c:title>`)
 	buf.WriteString(`<c:tx>`)
@@ -1018,8 +1411,8 @@ func generateTitleXML(title string, overlay bool) string {
 	buf.WriteString(`<a:bodyPr/>`)
 	buf.WriteString(`<a:lstStyle/>`)
 	buf.WriteString(`<a:p>`)
-	buf.WriteString(`<a:pPr><a:defRPr/></a:pPr>`)
-	buf.WriteString(`<a:r><a:rPr lang="en-US"/><a:t>`)
+	buf.WriteString(`<a:pPr>` + fontRunPropertiesXML("a:defRPr", "", font) + `</a:pPr>`)
+	buf.WriteString(`<a:r>` + fontRunPropertiesXML("a:rPr", `lang="en-US"`, font) + `<a:t>`)
 	buf.WriteString(xmlEscape(title))
 	buf.WriteString(`</a:t></a:r>`)
 	buf.WriteString(`</a:p>`)
@@ -1038,12 +1431,13 @@ func generateLegendXML(legend *LegendOptions) string {
 	buf.WriteString(fmt.Sprintf(`<c:legendPos val="%s"/>`, legend.Position))
 	buf.WriteString(`<c:layout/>`)
 	buf.WriteString(fmt.Sprintf(`<c:overlay val="%d"/>`, boolToInt(legend.Overlay)))
+	buf.WriteString(generateTextPropertiesXML(legend.Font))
 	buf.WriteString(`</c:legend>`)
 	return buf.String()
 }
 
 // generateExtendedBarChartXML generates bar/column chart XML with extended options
-func generateExtendedBarChartXML(opts ExtendedChartOptions) string {
+func generateExtendedBarChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
 	var buf bytes.Buffer
 
 	buf.WriteString(`<c:barChart>`)
@@ -1052,8 +1446,8 @@ func generateExtendedBarChartXML(opts ExtendedChartOptions) string {
 	buf.WriteString(fmt.Sprintf(`<c:varyColors val="%d"/>`, boolToInt(opts.BarChartOptions.VaryColors)))
 
 	// Series
-	for i, series := range opts.Series {
-		buf.WriteString(generateSeriesXML(i, series, opts))
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
 	}
 
 	// Data labels (chart-level default)
@@ -1065,15 +1459,14 @@ func generateExtendedBarChartXML(opts ExtendedChartOptions) string {
 
 	buf.WriteString(fmt.Sprintf(`<c:gapWidth val="%d"/>`, opts.BarChartOptions.GapWidth))
 	buf.WriteString(fmt.Sprintf(`<c:overlap val="%d"/>`, opts.BarChartOptions.Overlap))
-	buf.WriteString(`<c:axId val="2071991400"/>`)
-	buf.WriteString(`<c:axId val="2071991240"/>`)
+	buf.WriteString(group.axisIDsXML())
 	buf.WriteString(`</c:barChart>`)
 
 	return buf.String()
 }
 
 // generateExtendedLineChartXML generates line chart XML with extended options
-func generateExtendedLineChartXML(opts ExtendedChartOptions) string {
+func generateExtendedLineChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
 	var buf bytes.Buffer
 
 	buf.WriteString(`<c:lineChart>`)
@@ -1081,8 +1474,8 @@ func generateExtendedLineChartXML(opts ExtendedChartOptions) string {
 	buf.WriteString(`<c:varyColors val="0"/>`)
 
 	// Series
-	for i, series := range opts.Series {
-		buf.WriteString(generateSeriesXML(i, series, opts))
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
 	}
 
 	// Data labels
@@ -1092,23 +1485,22 @@ func generateExtendedLineChartXML(opts ExtendedChartOptions) string {
 		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="0"/><c:showBubbleSize val="0"/></c:dLbls>`)
 	}
 
-	buf.WriteString(`<c:axId val="2071991400"/>`)
-	buf.WriteString(`<c:axId val="2071991240"/>`)
+	buf.WriteString(group.axisIDsXML())
 	buf.WriteString(`</c:lineChart>`)
 
 	return buf.String()
 }
 
 // generateExtendedPieChartXML generates pie chart XML with extended options
-func generateExtendedPieChartXML(opts ExtendedChartOptions) string {
+func generateExtendedPieChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
 	var buf bytes.Buffer
 
 	buf.WriteString(`<c:pieChart>`)
 	buf.WriteString(`<c:varyColors val="1"/>`) // Pie charts typically vary colors
 
 	// Series (pie charts usually have one series)
-	for i, series := range opts.Series {
-		buf.WriteString(generateSeriesXML(i, series, opts))
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
 	}
 
 	// Data labels
@@ -1124,8 +1516,165 @@ func generateExtendedPieChartXML(opts ExtendedChartOptions) string {
 	return buf.String()
 }
 
+// generateExtendedDoughnutChartXML generates doughnut chart XML with extended options
+func generateExtendedDoughnutChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:doughnutChart>`)
+	buf.WriteString(`<c:varyColors val="1"/>`)
+
+	// Series (one ring per series)
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
+	}
+
+	// Data labels
+	if opts.DataLabels != nil {
+		buf.WriteString(generateDataLabelsXML(opts.DataLabels))
+	} else {
+		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="1"/><c:showBubbleSize val="0"/>`)
+		buf.WriteString(`<c:showLeaderLines val="1"/></c:dLbls>`)
+	}
+
+	buf.WriteString(fmt.Sprintf(`<c:firstSliceAng val="%d"/>`, opts.DoughnutChartOptions.FirstSliceAngle))
+	buf.WriteString(fmt.Sprintf(`<c:holeSize val="%d"/>`, opts.DoughnutChartOptions.HoleSize))
+	buf.WriteString(`</c:doughnutChart>`)
+
+	return buf.String()
+}
+
+// generateExtendedPie3DChartXML generates 3-D pie chart XML with extended options
+func generateExtendedPie3DChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:pie3DChart>`)
+	buf.WriteString(`<c:varyColors val="1"/>`)
+
+	// Series
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
+	}
+
+	// Data labels
+	if opts.DataLabels != nil {
+		buf.WriteString(generateDataLabelsXML(opts.DataLabels))
+	} else {
+		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="1"/><c:showBubbleSize val="0"/>`)
+		buf.WriteString(`<c:showLeaderLines val="1"/></c:dLbls>`)
+	}
+
+	buf.WriteString(`</c:pie3DChart>`)
+
+	return buf.String()
+}
+
+// generateExtendedBar3DChartXML generates 3-D bar/column chart XML with extended options
+func generateExtendedBar3DChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:bar3DChart>`)
+	buf.WriteString(fmt.Sprintf(`<c:barDir val="%s"/>`, opts.BarChartOptions.Direction))
+	buf.WriteString(fmt.Sprintf(`<c:grouping val="%s"/>`, opts.BarChartOptions.Grouping))
+	buf.WriteString(fmt.Sprintf(`<c:varyColors val="%d"/>`, boolToInt(opts.BarChartOptions.VaryColors)))
+
+	// Series
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
+	}
+
+	// Data labels (chart-level default)
+	if opts.DataLabels != nil {
+		buf.WriteString(generateDataLabelsXML(opts.DataLabels))
+	} else {
+		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="0"/><c:showBubbleSize val="0"/></c:dLbls>`)
+	}
+
+	buf.WriteString(fmt.Sprintf(`<c:gapWidth val="%d"/>`, opts.BarChartOptions.GapWidth))
+	buf.WriteString(fmt.Sprintf(`<c:shape val="%s"/>`, opts.Chart3DOptions.Shape))
+	buf.WriteString(group.axisIDsXML())
+	buf.WriteString(`</c:bar3DChart>`)
+
+	return buf.String()
+}
+
+// generateExtendedRadarChartXML generates radar chart XML with extended options
+func generateExtendedRadarChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:radarChart>`)
+	buf.WriteString(fmt.Sprintf(`<c:radarStyle val="%s"/>`, opts.RadarChartOptions.Style))
+	buf.WriteString(`<c:varyColors val="0"/>`)
+
+	// Series
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
+	}
+
+	// Data labels
+	if opts.DataLabels != nil {
+		buf.WriteString(generateDataLabelsXML(opts.DataLabels))
+	} else {
+		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="0"/><c:showBubbleSize val="0"/></c:dLbls>`)
+	}
+
+	buf.WriteString(group.axisIDsXML())
+	buf.WriteString(`</c:radarChart>`)
+
+	return buf.String()
+}
+
+// generateExtendedStockChartXML generates stock chart XML with extended
+// options. The series are the high, low and close prices, optionally
+// preceded by the open prices.
+func generateExtendedStockChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:stockChart>`)
+
+	// Series
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
+	}
+
+	// Data labels
+	if opts.DataLabels != nil {
+		buf.WriteString(generateDataLabelsXML(opts.DataLabels))
+	} else {
+		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="0"/><c:showBubbleSize val="0"/></c:dLbls>`)
+	}
+
+	// High-low lines and up/down bars
+	if opts.StockChartOptions.HighLowLines {
+		buf.WriteString(`<c:hiLowLines/>`)
+	}
+	if opts.StockChartOptions.UpDownBars {
+		buf.WriteString(`<c:upDownBars><c:gapWidth val="150"/><c:upBars/><c:downBars/></c:upDownBars>`)
+	}
+
+	buf.WriteString(group.axisIDsXML())
+	buf.WriteString(`</c:stockChart>`)
+
+	return buf.String()
+}
+
+// generateView3DXML generates the 3-D view of 3-D column and pie charts
+func generateView3DXML(view *Chart3DOptions, kind ChartKind) string {
+	var buf bytes.Buffer
+	buf.WriteString(`<c:view3D>`)
+	buf.WriteString(fmt.Sprintf(`<c:rotX val="%d"/>`, view.RotationX))
+	buf.WriteString(fmt.Sprintf(`<c:rotY val="%d"/>`, view.RotationY))
+	if kind == ChartKindColumn3D {
+		buf.WriteString(fmt.Sprintf(`<c:depthPercent val="%d"/>`, view.DepthPercent))
+		buf.WriteString(`<c:rAngAx val="1"/>`)
+	} else {
+		buf.WriteString(`<c:rAngAx val="0"/>`)
+	}
+	buf.WriteString(`</c:view3D>`)
+	return buf.String()
+}
+
 // generateExtendedAreaChartXML generates area chart XML with extended options
-func generateExtendedAreaChartXML(opts ExtendedChartOptions) string {
+func generateExtendedAreaChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
 	var buf bytes.Buffer
 
 	buf.WriteString(`<c:areaChart>`)
@@ -1133,8 +1682,8 @@ func generateExtendedAreaChartXML(opts ExtendedChartOptions) string {
 	buf.WriteString(`<c:varyColors val="0"/>`)
 
 	// Series
-	for i, series := range opts.Series {
-		buf.WriteString(generateSeriesXML(i, series, opts))
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
 	}
 
 	// Data labels
@@ -1144,72 +1693,126 @@ func generateExtendedAreaChartXML(opts ExtendedChartOptions) string {
 		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="0"/><c:showBubbleSize val="0"/></c:dLbls>`)
 	}
 
-	buf.WriteString(`<c:axId val="2071991400"/>`)
-	buf.WriteString(`<c:axId val="2071991240"/>`)
+	buf.WriteString(group.axisIDsXML())
 	buf.WriteString(`</c:areaChart>`)
 
 	return buf.String()
 }
 
-// generateSeriesXML generates series XML with extended options
-func generateSeriesXML(index int, series SeriesOptions, opts ExtendedChartOptions) string {
+// generateSeriesXML generates series XML with extended options for a plot of
+// the given chart type
+func generateSeriesXML(index int, series SeriesOptions, kind ChartKind, opts ExtendedChartOptions) string {
 	var buf bytes.Buffer
 
+	categories := opts.categoryData()
+	xCol, yCol, sizeCol := seriesColumns(kind, index, categories.columns())
+	rows := categories.count()
+	if isXYChartKind(kind) {
+		rows = len(series.Values)
+	}
+
 	buf.WriteString(fmt.Sprintf(`<c:ser><c:idx val="%d"/><c:order val="%d"/>`, index, index))
 
 	// Series name
-	buf.WriteString(fmt.Sprintf(`<c:tx><c:strRef><c:f>Sheet1!$%s$1</c:f>`, columnLetter(index+2)))
+	buf.WriteString(fmt.Sprintf(`<c:tx><c:strRef><c:f>Sheet1!$%s$1</c:f>`, columnLetter(yCol)))
 	buf.WriteString(fmt.Sprintf(`<c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>%s</c:v></c:pt></c:strCache></c:strRef></c:tx>`,
 		xmlEscape(series.Name)))
 
-	// Shape properties (color, etc.)
-	if series.Color != "" || series.InvertIfNegative {
-		buf.WriteString(`<c:spPr>`)
-		if series.Color != "" {
-			color := normalizeHexColor(series.Color)
-			buf.WriteString(fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color))
+	switch kind {
+	case ChartKindScatter:
+		// Scatter series draw lines and markers by style
+		buf.WriteString(scatterSeriesShapeXML(normalizeHexColor(series.Color), opts.ScatterChartOptions.Style))
+	case ChartKindRadar:
+		buf.WriteString(radarSeriesShapeXML(normalizeHexColor(series.Color), opts.RadarChartOptions.Style))
+	case ChartKindStock:
+		// Prices are drawn as markers on the high-low lines: a marker for
+		// the close without up/down bars, none otherwise
+		showMarker := index == len(opts.Series)-1 && !opts.StockChartOptions.UpDownBars
+		buf.WriteString(stockSeriesShapeXML(normalizeHexColor(series.Color), showMarker))
+	default:
+		// Shape properties (color, etc.)
+		if series.Color != "" || series.InvertIfNegative {
+			buf.WriteString(`<c:spPr>`)
+			if series.Color != "" {
+				color := normalizeHexColor(series.Color)
+				fill := fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color)
+				if kind == ChartKindLine {
+					// Line series take the color as their line
+					fill = `<a:ln w="28575" cap="rnd">` + fill + `<a:round/></a:ln>`
+				}
+				buf.WriteString(fill)
+			}
+			buf.WriteString(`</c:spPr>`)
+		}
+
+		// Invert if negative
+		if series.InvertIfNegative {
+			buf.WriteString(`<c:invertIfNegative val="1"/>`)
+		}
+
+		// Line chart specific: markers
+		if kind == ChartKindLine {
+			if series.ShowMarkers {
+				buf.WriteString(`<c:marker><c:symbol val="circle"/></c:marker>`)
+			} else {
+				buf.WriteString(`<c:marker><c:symbol val="none"/></c:marker>`)
+			}
 		}
-		buf.WriteString(`</c:spPr>`)
 	}
 
-	// Invert if negative
-	if series.InvertIfNegative {
-		buf.WriteString(`<c:invertIfNegative val="1"/>`)
+	// Data point overrides: colors and exploded doughnut points
+	var explosions map[int]int
+	if kind == ChartKindDoughnut {
+		explosions = opts.DoughnutChartOptions.ExplodedPoints
 	}
+	buf.WriteString(generateDataPointsXML(kind, series.PointColors, explosions))
 
-	// Categories
-	buf.WriteString(fmt.Sprintf(`<c:cat><c:strRef><c:f>Sheet1!$A$2:$A$%d</c:f>`, len(opts.Categories)+1))
-	buf.WriteString(fmt.Sprintf(`<c:strCache><c:ptCount val="%d"/>`, len(opts.Categories)))
-	for j, cat := range opts.Categories {
-		buf.WriteString(fmt.Sprintf(`<c:pt idx="%d"><c:v>%s</c:v></c:pt>`, j, xmlEscape(cat)))
+	// Per-series data labels (overrides chart-level)
+	if series.DataLabels != nil {
+		buf.WriteString(generateDataLabelsXML(series.DataLabels))
 	}
-	buf.WriteString(`</c:strCache></c:strRef></c:cat>`)
 
-	// Values
-	colLetter := columnLetter(index + 2)
-	buf.WriteString(fmt.Sprintf(`<c:val><c:numRef><c:f>Sheet1!$%s$2:$%s$%d</c:f>`,
-		colLetter, colLetter, len(opts.Categories)+1))
-	buf.WriteString(fmt.Sprintf(`<c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="%d"/>`, len(series.Values)))
-	for j, val := range series.Values {
-		buf.WriteString(fmt.Sprintf(`<c:pt idx="%d"><c:v>%g</c:v></c:pt>`, j, val))
+	// Trendline and error bars
+	if series.Trendline != nil {
+		buf.WriteString(generateTrendlineXML(series.Trendline))
+	}
+	if series.ErrorBars != nil {
+		buf.WriteString(generateErrorBarsXML(series.ErrorBars, isXYChartKind(kind)))
 	}
-	buf.WriteString(`</c:numCache></c:numRef></c:val>`)
 
-	// Line chart specific: smooth and markers
-	if opts.ChartKind == ChartKindLine {
-		if series.Smooth {
-			buf.WriteString(`<c:smooth val="1"/>`)
+	if isXYChartKind(kind) {
+		// X values, Y values and bubble sizes
+		buf.WriteString(`<c:xVal>` + buildNumRefXML("c:", sheetColumnRange(xCol, rows), "General", series.XValues) + `</c:xVal>`)
+		buf.WriteString(`<c:yVal>` + buildNumRefXML("c:", sheetColumnRange(yCol, rows), "General", series.Values) + `</c:yVal>`)
+		if kind == ChartKindBubble {
+			buf.WriteString(`<c:bubbleSize>` + buildNumRefXML("c:", sheetColumnRange(sizeCol, rows), "General", series.BubbleSizes) + `</c:bubbleSize>`)
+			buf.WriteString(`<c:bubble3D val="0"/>`)
 		}
-		if series.ShowMarkers {
-			buf.WriteString(`<c:marker><c:symbol val="circle"/></c:marker>`)
-		} else {
-			buf.WriteString(`<c:marker><c:symbol val="none"/></c:marker>`)
+	} else {
+		// Categories
+		buf.WriteString(`<c:cat>` + categories.refXML("c:", categories.formula("Sheet1")) + `</c:cat>`)
+
+		// Values
+		colLetter := columnLetter(yCol)
+		buf.WriteString(fmt.Sprintf(`<c:val><c:numRef><c:f>Sheet1!$%s$2:$%s$%d</c:f>`,
+			colLetter, colLetter, rows+1))
+		buf.WriteString(fmt.Sprintf(`<c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="%d"/>`, len(series.Values)))
+		for j, val := range series.Values {
+			if IsMissing(val) {
+				continue
+			}
+			buf.WriteString(fmt.Sprintf(`<c:pt idx="%d"><c:v>%g</c:v></c:pt>`, j, val))
 		}
+		buf.WriteString(`</c:numCache></c:numRef></c:val>`)
 	}
 
-	// Per-series data labels (overrides chart-level)
-	if series.DataLabels != nil {
-		buf.WriteString(generateDataLabelsXML(series.DataLabels))
+	// Smooth lines
+	switch {
+	case kind == ChartKindLine && series.Smooth:
+		buf.WriteString(`<c:smooth val="1"/>`)
+	case kind == ChartKindScatter:
+		style := opts.ScatterChartOptions.Style
+		buf.WriteString(fmt.Sprintf(`<c:smooth val="%d"/>`, boolToInt(style == ScatterStyleSmooth || style == ScatterStyleSmoothMarker)))
 	}
 
 	buf.WriteString(`</c:ser>`)
@@ -1217,19 +1820,65 @@ func generateSeriesXML(index int, series SeriesOptions, opts ExtendedChartOption
 	return buf.String()
 }
 
+// radarSeriesShapeXML returns the shape properties and marker of a radar
+// series drawn in the given style: filled series are filled with the series
+// color, other series draw it as their line
+func radarSeriesShapeXML(color string, style RadarStyle) string {
+	var buf bytes.Buffer
+
+	if color != "" {
+		fill := fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color)
+		if style == RadarStyleFilled {
+			buf.WriteString(`<c:spPr>` + fill + `</c:spPr>`)
+		} else {
+			buf.WriteString(`<c:spPr><a:ln w="28575">` + fill + `</a:ln></c:spPr>`)
+		}
+	}
+
+	if style == RadarStyleMarker {
+		buf.WriteString(`<c:marker><c:symbol val="circle"/><c:size val="5"/></c:marker>`)
+	} else {
+		buf.WriteString(`<c:marker><c:symbol val="none"/></c:marker>`)
+	}
+
+	return buf.String()
+}
+
+// stockSeriesShapeXML returns the shape properties and marker of a stock
+// series, which has no line of its own
+func stockSeriesShapeXML(color string, showMarker bool) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:spPr><a:ln w="19050"><a:noFill/></a:ln></c:spPr>`)
+	if !showMarker {
+		buf.WriteString(`<c:marker><c:symbol val="none"/></c:marker>`)
+		return buf.String()
+	}
+
+	buf.WriteString(`<c:marker><c:symbol val="dash"/><c:size val="7"/>`)
+	if color != "" {
+		fill := fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color)
+		buf.WriteString(`<c:spPr>` + fill + `<a:ln>` + fill + `</a:ln></c:spPr>`)
+	}
+	buf.WriteString(`</c:marker>`)
+
+	return buf.String()
+}
+
 // generateDataLabelsXML generates data labels XML
 func generateDataLabelsXML(labels *DataLabelOptions) string {
 	var buf bytes.Buffer
 	buf.WriteString(`<c:dLbls>`)
+	buf.WriteString(generateTextPropertiesXML(labels.Font))
+	if labels.Position != "" {
+		buf.WriteString(fmt.Sprintf(`<c:dLblPos val="%s"/>`, labels.Position))
+	}
 	buf.WriteString(fmt.Sprintf(`<c:showLegendKey val="%d"/>`, boolToInt(labels.ShowLegendKey)))
 	buf.WriteString(fmt.Sprintf(`<c:showVal val="%d"/>`, boolToInt(labels.ShowValue)))
 	buf.WriteString(fmt.Sprintf(`<c:showCatName val="%d"/>`, boolToInt(labels.ShowCategoryName)))
 	buf.WriteString(fmt.Sprintf(`<c:showSerName val="%d"/>`, boolToInt(labels.ShowSeriesName)))
 	buf.WriteString(fmt.Sprintf(`<c:showPercent val="%d"/>`, boolToInt(labels.ShowPercent)))
 	buf.WriteString(`<c:showBubbleSize val="0"/>`)
-	if labels.Position != "" {
-		buf.WriteString(fmt.Sprintf(`<c:dLblPos val="%s"/>`, labels.Position))
-	}
 	if labels.ShowLeaderLines {
 		buf.WriteString(`<c:showLeaderLines val="1"/>`)
 	}
@@ -1237,43 +1886,43 @@ func generateDataLabelsXML(labels *DataLabelOptions) string {
 	return buf.String()
 }
 
-// generateCategoryAxisXML generates category axis XML with extended options
-func generateCategoryAxisXML(axis *AxisOptions) string {
+// generateCategoryAxisXML generates category axis XML with extended
+// options, as a date axis when axis.DateAxis is set
+func generateCategoryAxisXML(axis *AxisOptions, axID, crossAxID int) string {
 	var buf bytes.Buffer
 
-	buf.WriteString(`<c:catAx>`)
-	buf.WriteString(`<c:axId val="2071991400"/>`)
-
-	// Scaling
-	buf.WriteString(`<c:scaling>`)
-	if axis.Min != nil {
-		buf.WriteString(fmt.Sprintf(`<c:min val="%g"/>`, *axis.Min))
+	tag := "c:catAx"
+	if axis.DateAxis {
+		tag = "c:dateAx"
 	}
-	if axis.Max != nil {
-		buf.WriteString(fmt.Sprintf(`<c:max val="%g"/>`, *axis.Max))
-	}
-	buf.WriteString(`<c:orientation val="minMax"/>`)
-	buf.WriteString(`</c:scaling>`)
+
+	buf.WriteString(`<` + tag + `>`)
+	buf.WriteString(fmt.Sprintf(`<c:axId val="%d"/>`, axID))
+	buf.WriteString(generateAxisScalingXML(axis))
 
 	buf.WriteString(fmt.Sprintf(`<c:delete val="%d"/>`, boolToInt(!axis.Visible)))
 	buf.WriteString(fmt.Sprintf(`<c:axPos val="%s"/>`, axis.Position))
 
-	// Major gridlines
+	// Gridlines
 	if axis.MajorGridlines {
 		buf.WriteString(`<c:majorGridlines/>`)
 	}
+	if axis.MinorGridlines {
+		buf.WriteString(`<c:minorGridlines/>`)
+	}
 
 	// Title
 	if axis.Title != "" {
 		buf.WriteString(generateAxisTitleXML(axis.Title, axis.TitleOverlay))
 	}
 
-	buf.WriteString(fmt.Sprintf(`<c:numFmt formatCode="%s" sourceLinked="0"/>`, axis.NumberFormat))
+	buf.WriteString(fmt.Sprintf(`<c:numFmt formatCode="%s" sourceLinked="0"/>`, xmlEscape(axis.NumberFormat)))
 	buf.WriteString(fmt.Sprintf(`<c:majorTickMark val="%s"/>`, axis.MajorTickMark))
 	buf.WriteString(fmt.Sprintf(`<c:minorTickMark val="%s"/>`, axis.MinorTickMark))
 	buf.WriteString(fmt.Sprintf(`<c:tickLblPos val="%s"/>`, axis.TickLabelPos))
+	buf.WriteString(generateTextPropertiesXML(axis.Font))
 
-	buf.WriteString(`<c:crossAx val="2071991240"/>`)
+	buf.WriteString(fmt.Sprintf(`<c:crossAx val="%d"/>`, crossAxID))
 
 	if axis.CrossesAt != nil {
 		buf.WriteString(fmt.Sprintf(`<c:crossesAt val="%g"/>`, *axis.CrossesAt))
@@ -1281,77 +1930,87 @@ func generateCategoryAxisXML(axis *AxisOptions) string {
 		buf.WriteString(`<c:crosses val="autoZero"/>`)
 	}
 
-	buf.WriteString(`<c:auto val="1"/>`)
-	buf.WriteString(`<c:lblAlgn val="ctr"/>`)
-	buf.WriteString(`<c:lblOffset val="100"/>`)
-
-	// Minor gridlines
-	if axis.MinorGridlines {
-		buf.WriteString(`<c:minorGridlines/>`)
+	if axis.DateAxis {
+		// Date axes step in time units of the base unit
+		buf.WriteString(`<c:auto val="0"/>`)
+		buf.WriteString(`<c:lblOffset val="100"/>`)
+		buf.WriteString(fmt.Sprintf(`<c:baseTimeUnit val="%s"/>`, axis.BaseTimeUnit))
+		if axis.MajorUnit != nil {
+			buf.WriteString(fmt.Sprintf(`<c:majorUnit val="%g"/><c:majorTimeUnit val="%s"/>`, *axis.MajorUnit, axis.BaseTimeUnit))
+		}
+		if axis.MinorUnit != nil {
+			buf.WriteString(fmt.Sprintf(`<c:minorUnit val="%g"/><c:minorTimeUnit val="%s"/>`, *axis.MinorUnit, axis.BaseTimeUnit))
+		}
+	} else {
+		buf.WriteString(`<c:auto val="1"/>`)
+		buf.WriteString(`<c:lblAlgn val="ctr"/>`)
+		buf.WriteString(`<c:lblOffset val="100"/>`)
 	}
 
-	buf.WriteString(`</c:catAx>`)
+	buf.WriteString(`</` + tag + `>`)
 
 	return buf.String()
 }
 
-// generateValueAxisXML generates value axis XML with extended options
-func generateValueAxisXML(axis *AxisOptions) string {
+// generateValueAxisXML generates value axis XML with extended options.
+// crosses is where the axis crosses the other axis when CrossesAt is not
+// set ("autoZero" or "max"); crossBetween is "between" for category charts
+// and "midCat" for scatter and bubble charts.
+func generateValueAxisXML(axis *AxisOptions, axID, crossAxID int, crosses, crossBetween string) string {
 	var buf bytes.Buffer
 
 	buf.WriteString(`<c:valAx>`)
-	buf.WriteString(`<c:axId val="2071991240"/>`)
-
-	// Scaling
-	buf.WriteString(`<c:scaling>`)
-	if axis.Min != nil {
-		buf.WriteString(fmt.Sprintf(`<c:min val="%g"/>`, *axis.Min))
-	}
-	if axis.Max != nil {
-		buf.WriteString(fmt.Sprintf(`<c:max val="%g"/>`, *axis.Max))
-	}
-	buf.WriteString(`<c:orientation val="minMax"/>`)
-	buf.WriteString(`</c:scaling>`)
+	buf.WriteString(fmt.Sprintf(`<c:axId val="%d"/>`, axID))
+	buf.WriteString(generateAxisScalingXML(axis))
 
 	buf.WriteString(fmt.Sprintf(`<c:delete val="%d"/>`, boolToInt(!axis.Visible)))
 	buf.WriteString(fmt.Sprintf(`<c:axPos val="%s"/>`, axis.Position))
 
-	// Major gridlines
+	// Gridlines
 	if axis.MajorGridlines {
 		buf.WriteString(`<c:majorGridlines/>`)
 	}
+	if axis.MinorGridlines {
+		buf.WriteString(`<c:minorGridlines/>`)
+	}
 
 	// Title
 	if axis.Title != "" {
 		buf.WriteString(generateAxisTitleXML(axis.Title, axis.TitleOverlay))
 	}
 
-	buf.WriteString(fmt.Sprintf(`<c:numFmt formatCode="%s" sourceLinked="0"/>`, axis.NumberFormat))
-
-	if axis.MajorUnit != nil {
-		buf.WriteString(fmt.Sprintf(`<c:majorUnit val="%g"/>`, *axis.MajorUnit))
-	}
-	if axis.MinorUnit != nil {
-		buf.WriteString(fmt.Sprintf(`<c:minorUnit val="%g"/>`, *axis.MinorUnit))
-	}
-
+	buf.WriteString(fmt.Sprintf(`<c:numFmt formatCode="%s" sourceLinked="0"/>`, xmlEscape(axis.NumberFormat)))
 	buf.WriteString(fmt.Sprintf(`<c:majorTickMark val="%s"/>`, axis.MajorTickMark))
 	buf.WriteString(fmt.Sprintf(`<c:minorTickMark val="%s"/>`, axis.MinorTickMark))
 	buf.WriteString(fmt.Sprintf(`<c:tickLblPos val="%s"/>`, axis.TickLabelPos))
+	buf.WriteString(generateTextPropertiesXML(axis.Font))
 
-	buf.WriteString(`<c:crossAx val="2071991400"/>`)
+	buf.WriteString(fmt.Sprintf(`<c:crossAx val="%d"/>`, crossAxID))
 
 	if axis.CrossesAt != nil {
 		buf.WriteString(fmt.Sprintf(`<c:crossesAt val="%g"/>`, *axis.CrossesAt))
 	} else {
-		buf.WriteString(`<c:crosses val="autoZero"/>`)
+		buf.WriteString(fmt.Sprintf(`<c:crosses val="%s"/>`, crosses))
 	}
 
-	buf.WriteString(`<c:crossBetween val="between"/>`)
+	buf.WriteString(fmt.Sprintf(`<c:crossBetween val="%s"/>`, crossBetween))
 
-	// Minor gridlines
-	if axis.MinorGridlines {
-		buf.WriteString(`<c:minorGridlines/>`)
+	if axis.MajorUnit != nil {
+		buf.WriteString(fmt.Sprintf(`<c:majorUnit val="%g"/>`, *axis.MajorUnit))
+	}
+	if axis.MinorUnit != nil {
+		buf.WriteString(fmt.Sprintf(`<c:minorUnit val="%g"/>`, *axis.MinorUnit))
+	}
+
+	// Display units
+	if axis.DisplayUnits != "" {
+		buf.WriteString(fmt.Sprintf(`<c:dispUnits><c:builtInUnit val="%s"/>`, axis.DisplayUnits))
+		if axis.DisplayUnitsLabel != "" {
+			buf.WriteString(`<c:dispUnitsLbl><c:layout/><c:tx><c:rich><a:bodyPr/><a:lstStyle/><a:p><a:r><a:t>`)
+			buf.WriteString(xmlEscape(axis.DisplayUnitsLabel))
+			buf.WriteString(`</a:t></a:r></a:p></c:rich></c:tx></c:dispUnitsLbl>`)
+		}
+		buf.WriteString(`</c:dispUnits>`)
 	}
 
 	buf.WriteString(`</c:valAx>`)
@@ -1359,6 +2018,31 @@ func generateValueAxisXML(axis *AxisOptions) string {
 	return buf.String()
 }
 
+// generateAxisScalingXML generates the scaling of an axis: logarithmic
+// base, orientation and bounds
+func generateAxisScalingXML(axis *AxisOptions) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:scaling>`)
+	if axis.LogBase != 0 {
+		buf.WriteString(`<c:logBase val="` + formatFloat(axis.LogBase) + `"/>`)
+	}
+	orientation := axis.Orientation
+	if orientation == "" {
+		orientation = AxisOrientationMinMax
+	}
+	buf.WriteString(fmt.Sprintf(`<c:orientation val="%s"/>`, orientation))
+	if axis.Max != nil {
+		buf.WriteString(fmt.Sprintf(`<c:max val="%g"/>`, *axis.Max))
+	}
+	if axis.Min != nil {
+		buf.WriteString(fmt.Sprintf(`<c:min val="%g"/>`, *axis.Min))
+	}
+	buf.WriteString(`</c:scaling>`)
+
+	return buf.String()
+}
+
 // generateAxisTitleXML generates axis title XML
 func generateAxisTitleXML(title string, overlay bool) string {
 	var buf bytes.Buffer
@@ -1409,26 +2093,7 @@ func (u *Updater) insertExtendedChartDrawing(chartIndex int, relId string, opts
 	}
 
 	// Insert based on position
-	var updated []byte
-	switch opts.Position {
-	case PositionBeginning:
-		updated, err = insertAtBodyStart(raw, contentToInsert)
-	case PositionEnd:
-		updated, err = insertAtBodyEnd(raw, contentToInsert)
-	case PositionAfterText:
-		if opts.Anchor == "" {
-			return fmt.Errorf("anchor text required for PositionAfterText")
-		}
-		updated, err = insertAfterText(raw, contentToInsert, opts.Anchor)
-	case PositionBeforeText:
-		if opts.Anchor == "" {
-			return fmt.Errorf("anchor text required for PositionBeforeText")
-		}
-		updated, err = insertBeforeText(raw, contentToInsert, opts.Anchor)
-	default:
-		return fmt.Errorf("invalid insert position")
-	}
-
+	updated, err := insertAtPosition(raw, contentToInsert, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
 	if err != nil {
 		return fmt.Errorf("insert chart: %w", err)
 	}
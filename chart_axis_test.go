@@ -0,0 +1,132 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+// axisSection returns the XML of the first axis element with the given name
+// (e.g. "c:valAx") of a chart
+func axisSection(chart, name string) string {
+	start := strings.Index(chart, "<"+name+">")
+	if start == -1 {
+		return ""
+	}
+	return chart[start : start+strings.Index(chart[start:], "</"+name+">")]
+}
+
+func TestInsertChartAxisScaling(t *testing.T) {
+	minimum := 10.0
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		Categories:   []string{"A", "B", "C"},
+		Series:       []godocx.SeriesOptions{{Name: "Revenue", Values: []float64{1200, 45000, 2300000}}},
+		CategoryAxis: &godocx.AxisOptions{Orientation: godocx.AxisOrientationMaxMin},
+		ValueAxis: &godocx.AxisOptions{
+			Min:               &minimum,
+			LogBase:           10,
+			DisplayUnits:      godocx.DisplayUnitsThousands,
+			DisplayUnitsLabel: "Thousands",
+		},
+	})
+
+	chart := readChartPart(t, u, 1)
+	cat := axisSection(chart, "c:catAx")
+	if !strings.Contains(cat, `<c:scaling><c:orientation val="maxMin"/></c:scaling>`) {
+		t.Error("expected a reversed category axis")
+	}
+
+	val := axisSection(chart, "c:valAx")
+	for _, want := range []string{
+		`<c:scaling><c:logBase val="10"/><c:orientation val="minMax"/><c:min val="10"/></c:scaling>`,
+		`<c:dispUnits><c:builtInUnit val="thousands"/><c:dispUnitsLbl>`,
+		`<a:t>Thousands</a:t>`,
+	} {
+		if !strings.Contains(val, want) {
+			t.Errorf("expected %s in value axis", want)
+		}
+	}
+
+	// Value axis children follow the schema order
+	order := []string{"<c:scaling>", "<c:majorGridlines/>", "<c:numFmt", "<c:majorTickMark", "<c:crossAx", "<c:crossBetween", "<c:dispUnits>"}
+	for i := 1; i < len(order); i++ {
+		if strings.Index(val, order[i-1]) > strings.Index(val, order[i]) {
+			t.Errorf("expected %s before %s", order[i-1], order[i])
+		}
+	}
+}
+
+func TestInsertChartDateAxis(t *testing.T) {
+	major := 1.0
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindLine,
+		Categories: []string{"45292", "45323", "45352"},
+		Series:     []godocx.SeriesOptions{{Name: "Price", Values: []float64{10, 11, 12}}},
+		CategoryAxis: &godocx.AxisOptions{
+			DateAxis:     true,
+			BaseTimeUnit: godocx.TimeUnitMonths,
+			MajorUnit:    &major,
+			NumberFormat: "mmm yy",
+		},
+	})
+
+	chart := readChartPart(t, u, 1)
+	if strings.Contains(chart, "<c:catAx>") {
+		t.Fatal("expected a date axis instead of a category axis")
+	}
+	date := axisSection(chart, "c:dateAx")
+	for _, want := range []string{
+		`<c:numFmt formatCode="mmm yy" sourceLinked="0"/>`,
+		`<c:auto val="0"/><c:lblOffset val="100"/><c:baseTimeUnit val="months"/><c:majorUnit val="1"/><c:majorTimeUnit val="months"/>`,
+	} {
+		if !strings.Contains(date, want) {
+			t.Errorf("expected %s in date axis", want)
+		}
+	}
+	if !strings.Contains(chart, `<c:axId val="2071991400"/><c:axId val="2071991240"/></c:lineChart>`) {
+		t.Error("expected the line plot to reference the date axis")
+	}
+}
+
+func TestInsertChartAxisValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	zero := 0.0
+	tests := []struct {
+		name          string
+		categoryAxis  *godocx.AxisOptions
+		valueAxis     *godocx.AxisOptions
+		secondaryAxis *godocx.AxisOptions
+	}{
+		{"log base", nil, &godocx.AxisOptions{LogBase: 1}, nil},
+		{"log min", nil, &godocx.AxisOptions{LogBase: 10, Min: &zero}, nil},
+		{"orientation", &godocx.AxisOptions{Orientation: "reverse"}, nil, nil},
+		{"display units", nil, &godocx.AxisOptions{DisplayUnits: "dozens"}, nil},
+		{"label without units", nil, &godocx.AxisOptions{DisplayUnitsLabel: "K"}, nil},
+		{"date value axis", nil, nil, &godocx.AxisOptions{DateAxis: true}},
+		{"time unit", &godocx.AxisOptions{DateAxis: true, BaseTimeUnit: "weeks"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.InsertChartExtended(godocx.ExtendedChartOptions{
+				Position:   godocx.PositionEnd,
+				Categories: []string{"A"},
+				Series: []godocx.SeriesOptions{
+					{Name: "S", Values: []float64{1}},
+					{Name: "T", Values: []float64{1}, ChartKind: godocx.ChartKindLine, SecondaryAxis: true},
+				},
+				CategoryAxis:       tt.categoryAxis,
+				ValueAxis:          tt.valueAxis,
+				SecondaryValueAxis: tt.secondaryAxis,
+			})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
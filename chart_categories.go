@@ -0,0 +1,221 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// chartCategories holds the categories of a chart in one of their forms:
+// text labels, optionally grouped by outer levels (e.g. Year > Quarter),
+// dates or numbers
+type chartCategories struct {
+	labels  []string
+	levels  [][]string // Outer levels, outermost first
+	dates   []time.Time
+	numbers []float64
+	format  string // Number format of dates and numbers
+}
+
+// categoryData returns the categories of the chart options
+func (opts ChartOptions) categoryData() chartCategories {
+	return chartCategories{opts.Categories, opts.CategoryLevels, opts.CategoryDates, opts.CategoryNumbers, opts.CategoryFormat}
+}
+
+// categoryData returns the categories of the chart options
+func (opts ExtendedChartOptions) categoryData() chartCategories {
+	return chartCategories{opts.Categories, opts.CategoryLevels, opts.CategoryDates, opts.CategoryNumbers, opts.CategoryFormat}
+}
+
+// categoryData returns the categories of the chart data
+func (data ChartData) categoryData() chartCategories {
+	return chartCategories{data.Categories, data.CategoryLevels, data.CategoryDates, data.CategoryNumbers, data.CategoryFormat}
+}
+
+// excelEpoch is day 0 of the 1900 date system. Serials from March 1, 1900
+// on count the days since this date, as Excel counts February 29, 1900.
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// firstExcelDate is the first date whose serial is counted from excelEpoch
+var firstExcelDate = time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+// count returns the number of categories
+func (c chartCategories) count() int {
+	switch {
+	case len(c.dates) > 0:
+		return len(c.dates)
+	case len(c.numbers) > 0:
+		return len(c.numbers)
+	}
+	return len(c.labels)
+}
+
+// columns returns the number of worksheet columns of the categories: one
+// per outer level and one for the categories themselves
+func (c chartCategories) columns() int {
+	return len(c.levels) + 1
+}
+
+// numeric reports whether the categories are dates or numbers
+func (c chartCategories) numeric() bool {
+	return len(c.dates) > 0 || len(c.numbers) > 0
+}
+
+// validate checks that only one form of categories is set and that the
+// outer levels have a label per category
+func (c chartCategories) validate() error {
+	forms := 0
+	for _, n := range []int{len(c.labels), len(c.dates), len(c.numbers)} {
+		if n > 0 {
+			forms++
+		}
+	}
+	if forms > 1 {
+		return fmt.Errorf("use only one of Categories, CategoryDates and CategoryNumbers")
+	}
+	if len(c.levels) > 0 && len(c.labels) == 0 {
+		return fmt.Errorf("CategoryLevels need Categories")
+	}
+	for i, level := range c.levels {
+		if len(level) != len(c.labels) {
+			return fmt.Errorf("CategoryLevels[%d] length (%d) must match categories length (%d)", i, len(level), len(c.labels))
+		}
+	}
+	if c.format != "" && !c.numeric() {
+		return fmt.Errorf("CategoryFormat needs CategoryDates or CategoryNumbers")
+	}
+	for i, d := range c.dates {
+		if excelDate(d).Before(firstExcelDate) {
+			return fmt.Errorf("CategoryDates[%d] is before March 1, 1900", i)
+		}
+	}
+	for i, v := range c.numbers {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("CategoryNumbers[%d] must be a finite number", i)
+		}
+	}
+	return nil
+}
+
+// excelDate returns the wall clock time of t in UTC, so that a date is
+// stored as the day it shows in its own location
+func excelDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+	return time.Date(year, month, day, hour, minute, sec, t.Nanosecond(), time.UTC)
+}
+
+// excelSerial returns the serial number of a date in the 1900 date system:
+// whole days since the epoch plus the time of day as a fraction
+func excelSerial(t time.Time) float64 {
+	d := excelDate(t)
+	seconds := d.Unix() - excelEpoch.Unix()
+	return float64(seconds)/86400 + float64(d.Nanosecond())/(86400*1e9)
+}
+
+// values returns the dates as serial numbers, or the numbers
+func (c chartCategories) values() []float64 {
+	if len(c.dates) == 0 {
+		return c.numbers
+	}
+	values := make([]float64, len(c.dates))
+	for i, d := range c.dates {
+		values[i] = excelSerial(d)
+	}
+	return values
+}
+
+// numberFormat returns the number format of the categories, defaulting to
+// m/d/yyyy for dates
+func (c chartCategories) numberFormat() string {
+	switch {
+	case c.format != "":
+		return c.format
+	case len(c.dates) > 0:
+		return "m/d/yyyy"
+	}
+	return "General"
+}
+
+// cellFormat returns the number format of the category cells, or "" when
+// the cells need no style
+func (c chartCategories) cellFormat() string {
+	if !c.numeric() || c.numberFormat() == "General" {
+		return ""
+	}
+	return c.numberFormat()
+}
+
+// groupLabel returns the label of an outer level at category i. Only the
+// first category of a group has one: a group starts where the label or a
+// group of an enclosing level changes.
+func (c chartCategories) groupLabel(level, i int) (string, bool) {
+	if i == 0 {
+		return c.levels[level][0], true
+	}
+	for l := 0; l <= level; l++ {
+		if c.levels[l][i] != c.levels[l][i-1] {
+			return c.levels[level][i], true
+		}
+	}
+	return "", false
+}
+
+// allLabels returns every text label of the categories
+func (c chartCategories) allLabels() []string {
+	var labels []string
+	for _, level := range c.levels {
+		labels = append(labels, level...)
+	}
+	return append(labels, c.labels...)
+}
+
+// formula returns the reference of the category cells on the given
+// (escaped) sheet: all category columns from row 2
+func (c chartCategories) formula(sheet string) string {
+	return fmt.Sprintf("%s!$A$2:$%s$%d", sheet, columnLetter(c.columns()), c.count()+1)
+}
+
+// refXML builds the category reference with its cache: a multi-level string
+// reference for grouped labels, a number reference for dates and numbers and
+// a string reference otherwise. The formula must already be escaped; an
+// empty formula is left out.
+func (c chartCategories) refXML(p, formula string) string {
+	switch {
+	case c.numeric():
+		return buildNumRefXML(p, formula, xmlEscape(c.numberFormat()), c.values())
+	case len(c.levels) == 0:
+		return buildStrRefXML(p, formula, c.labels)
+	}
+
+	pt := func(buf *bytes.Buffer, i int, label string) {
+		buf.WriteString("<" + p + "pt idx=\"" + strconv.Itoa(i) + "\"><" + p + "v>" + xmlEscape(label) + "</" + p + "v></" + p + "pt>")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<" + p + "multiLvlStrRef>")
+	if formula != "" {
+		buf.WriteString("<" + p + "f>" + formula + "</" + p + "f>")
+	}
+	buf.WriteString("<" + p + "multiLvlStrCache><" + p + "ptCount val=\"" + strconv.Itoa(len(c.labels)) + "\"/>")
+
+	// Levels go from the categories outwards
+	buf.WriteString("<" + p + "lvl>")
+	for i, label := range c.labels {
+		pt(&buf, i, label)
+	}
+	buf.WriteString("</" + p + "lvl>")
+	for level := len(c.levels) - 1; level >= 0; level-- {
+		buf.WriteString("<" + p + "lvl>")
+		for i := range c.labels {
+			if label, ok := c.groupLabel(level, i); ok {
+				pt(&buf, i, label)
+			}
+		}
+		buf.WriteString("</" + p + "lvl>")
+	}
+	buf.WriteString("</" + p + "multiLvlStrCache></" + p + "multiLvlStrRef>")
+	return buf.String()
+}
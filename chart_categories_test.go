@@ -0,0 +1,234 @@
+package godocx_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func readChartStyles(t *testing.T, u *godocx.Updater, workbook string) string {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join(u.TempDir(), "word", "embeddings", workbook))
+	if err != nil {
+		t.Fatalf("read workbook: %v", err)
+	}
+	return readWorkbookEntry(t, raw, "xl/styles.xml")
+}
+
+func TestInsertChartMultiLevelCategories(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		Categories:     []string{"Q1", "Q2", "Q1", "Q2"},
+		CategoryLevels: [][]string{{"2023", "2023", "2024", "2024"}},
+		Series:         []godocx.SeriesOptions{{Name: "Sales", Values: []float64{1, 2, 3, 4}}},
+	})
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:f>Sheet1!$C$1</c:f>`,
+		`<c:cat><c:multiLvlStrRef><c:f>Sheet1!$A$2:$B$5</c:f><c:multiLvlStrCache><c:ptCount val="4"/>` +
+			`<c:lvl><c:pt idx="0"><c:v>Q1</c:v></c:pt><c:pt idx="1"><c:v>Q2</c:v></c:pt><c:pt idx="2"><c:v>Q1</c:v></c:pt><c:pt idx="3"><c:v>Q2</c:v></c:pt></c:lvl>` +
+			`<c:lvl><c:pt idx="0"><c:v>2023</c:v></c:pt><c:pt idx="2"><c:v>2024</c:v></c:pt></c:lvl></c:multiLvlStrCache></c:multiLvlStrRef></c:cat>`,
+		`<c:f>Sheet1!$C$2:$C$5</c:f>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+
+	// Outer labels start their groups, values follow the category columns
+	sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+	for _, want := range []string{
+		`<c r="A2" t="str"><v>2023</v></c><c r="B2" t="str"><v>Q1</v></c><c r="C2"><v>1</v></c>`,
+		`<row r="3"><c r="B3" t="str"><v>Q2</v></c>`,
+		`<c r="A4" t="str"><v>2024</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("expected %s in sheet", want)
+		}
+	}
+}
+
+func TestInsertChartDateCategories(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind: godocx.ChartKindLine,
+		CategoryDates: []time.Time{
+			time.Date(2024, time.January, 1, 0, 0, 0, 0, tokyo),
+			time.Date(2024, time.January, 2, 12, 0, 0, 0, tokyo),
+		},
+		Series: []godocx.SeriesOptions{{Name: "Visits", Values: []float64{120, 135}}},
+	})
+
+	chart := readChartPart(t, u, 1)
+	want := `<c:cat><c:numRef><c:f>Sheet1!$A$2:$A$3</c:f><c:numCache><c:formatCode>m/d/yyyy</c:formatCode><c:ptCount val="2"/>` +
+		`<c:pt idx="0"><c:v>45292</c:v></c:pt><c:pt idx="1"><c:v>45293.5</c:v></c:pt></c:numCache></c:numRef></c:cat>`
+	if !strings.Contains(chart, want) {
+		t.Errorf("expected %s in chart", want)
+	}
+	if cat := axisSection(chart, "c:catAx"); !strings.Contains(cat, `<c:numFmt formatCode="m/d/yyyy" sourceLinked="0"/>`) {
+		t.Error("expected the category axis to show dates")
+	}
+
+	// The workbook stores real dates
+	sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+	if !strings.Contains(sheet, `<c r="A2" s="1"><v>45292</v></c>`) {
+		t.Error("expected a date cell in the sheet")
+	}
+	styles := readChartStyles(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+	if !strings.Contains(styles, `<numFmt numFmtId="164" formatCode="m/d/yyyy"/>`) || !strings.Contains(styles, `<cellXfs count="2">`) {
+		t.Error("expected a date style in the workbook")
+	}
+}
+
+func TestInsertChartNumericCategories(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertChart(godocx.ChartOptions{
+		Position:        godocx.PositionEnd,
+		CategoryNumbers: []float64{0.5, 1.5},
+		CategoryFormat:  "0.0",
+		Series:          []godocx.SeriesData{{Name: "Load", Values: []float64{3, 4}}},
+	})
+	if err != nil {
+		t.Fatalf("InsertChart failed: %v", err)
+	}
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:formatCode>0.0</c:formatCode><c:ptCount val="2"/><c:pt idx="0"><c:v>0.5</c:v></c:pt>`,
+		`<c:f>Sheet1!$B$1</c:f>`,
+		`<c:f>Sheet1!$B$2:$B$3</c:f>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+	if sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx"); !strings.Contains(sheet, `<c r="A3" s="1"><v>1.5</v></c><c r="B3"><v>4</v></c>`) {
+		t.Error("expected numeric category cells in the sheet")
+	}
+}
+
+func TestUpdateChartCategories(t *testing.T) {
+	for _, preserve := range []bool{false, true} {
+		u := newStyledChartUpdater(t)
+		err := u.UpdateChart(1, godocx.ChartData{
+			Categories:     []string{"Jan", "Feb", "Jan"},
+			CategoryLevels: [][]string{{"2024", "2024", "2025"}},
+			Series: []godocx.SeriesData{
+				{Name: "Old A", Values: []float64{1, 2, 3}},
+				{Name: "Old B", Values: []float64{4, 5, 6}},
+			},
+			PreserveFormatting: preserve,
+		})
+		if err != nil {
+			t.Fatalf("UpdateChart failed: %v", err)
+		}
+
+		chart := readChartPart(t, u, 1)
+		if strings.Count(chart, `<c:lvl><c:pt idx="0"><c:v>2024</c:v></c:pt><c:pt idx="2"><c:v>2025</c:v></c:pt></c:lvl>`) != 2 {
+			t.Errorf("preserve %v: expected multi-level categories for both series", preserve)
+		}
+		if preserve && (!strings.Contains(chart, `<c:f>Data!$A$2:$B$4</c:f>`) || !strings.Contains(chart, `<c:f>Data!$D$2:$D$4</c:f>`)) {
+			t.Error("expected formulas past the category columns")
+		}
+
+		sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+		for _, want := range []string{
+			`<c r="B1" t="inlineStr"><is><t></t></is></c><c r="C1" t="inlineStr"><is><t>Old A</t></is></c>`,
+			`<row r="3"><c r="B3" t="inlineStr">`,
+			`<c r="A4" t="inlineStr"><is><t>2025</t></is></c>`,
+			`<c r="D4"><v>6</v></c>`,
+		} {
+			if !strings.Contains(sheet, want) {
+				t.Errorf("preserve %v: expected %s in sheet", preserve, want)
+			}
+		}
+	}
+}
+
+func TestUpdateChartDateCategories(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindLine,
+		Categories: []string{"A", "B"},
+		Series:     []godocx.SeriesOptions{{Name: "Visits", Values: []float64{1, 2}}},
+	})
+
+	// Updating twice reuses the date style of the workbook
+	for range 2 {
+		err := u.UpdateChart(1, godocx.ChartData{
+			CategoryDates:      []time.Time{time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)},
+			CategoryFormat:     "mmm yyyy",
+			Series:             []godocx.SeriesData{{Name: "Visits", Values: []float64{3, 4}}},
+			PreserveFormatting: true,
+		})
+		if err != nil {
+			t.Fatalf("UpdateChart failed: %v", err)
+		}
+	}
+
+	chart := readChartPart(t, u, 1)
+	if !strings.Contains(chart, `<c:formatCode>mmm yyyy</c:formatCode><c:ptCount val="2"/><c:pt idx="0"><c:v>45352</c:v></c:pt>`) {
+		t.Error("expected date categories in the chart")
+	}
+	if cat := axisSection(chart, "c:catAx"); !strings.Contains(cat, `<c:numFmt formatCode="mmm yyyy" sourceLinked="1"/>`) || strings.Count(cat, "<c:numFmt") != 1 {
+		t.Error("expected the category axis to take the date format")
+	}
+
+	styles := readChartStyles(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+	if strings.Count(styles, `formatCode="mmm yyyy"`) != 1 || !strings.Contains(styles, `<cellXfs count="2">`) {
+		t.Errorf("expected one date style, got %s", styles)
+	}
+	if sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx"); !strings.Contains(sheet, `<c r="A2" s="1"><v>45352</v></c>`) {
+		t.Error("expected a date cell in the sheet")
+	}
+}
+
+func TestChartCategoryValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	series := []godocx.SeriesOptions{{Name: "S", Values: []float64{1, 2}}}
+	tests := []struct {
+		name string
+		opts godocx.ExtendedChartOptions
+	}{
+		{"two forms", godocx.ExtendedChartOptions{Categories: []string{"A", "B"}, CategoryNumbers: []float64{1, 2}}},
+		{"level length", godocx.ExtendedChartOptions{Categories: []string{"A", "B"}, CategoryLevels: [][]string{{"X"}}}},
+		{"levels without labels", godocx.ExtendedChartOptions{CategoryNumbers: []float64{1, 2}, CategoryLevels: [][]string{{"X", "X"}}}},
+		{"format of labels", godocx.ExtendedChartOptions{Categories: []string{"A", "B"}, CategoryFormat: "0.0"}},
+		{"number", godocx.ExtendedChartOptions{CategoryNumbers: []float64{1, math.Inf(1)}}},
+		{"early date", godocx.ExtendedChartOptions{CategoryDates: []time.Time{time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC), time.Now()}}},
+		{"count", godocx.ExtendedChartOptions{CategoryNumbers: []float64{1, 2, 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Position = godocx.PositionEnd
+			tt.opts.Series = series
+			if err := u.InsertChartExtended(tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	err = u.UpdateChart(1, godocx.ChartData{
+		Categories:     []string{"A", "B"},
+		CategoryLevels: [][]string{{"X", "Y", "Z"}},
+		Series:         []godocx.SeriesData{{Name: "S", Values: []float64{1, 2}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "CategoryLevels[0]") {
+		t.Errorf("expected a level length error from UpdateChart, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package godocx
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Axis IDs of generated charts
+const (
+	primaryCategoryAxisID   = 2071991400
+	primaryValueAxisID      = 2071991240
+	secondaryCategoryAxisID = 2071991560
+	secondaryValueAxisID    = 2071991720
+)
+
+// chartPlotGroup is one plot of a chart (e.g. <c:barChart>): the series of
+// one chart type drawn against the same axes
+type chartPlotGroup struct {
+	kind      ChartKind
+	secondary bool  // Plotted against the secondary axes
+	series    []int // Indexes into ExtendedChartOptions.Series
+}
+
+// axisIDsXML returns the axis references of the plot
+func (g chartPlotGroup) axisIDsXML() string {
+	if g.secondary {
+		return fmt.Sprintf(`<c:axId val="%d"/><c:axId val="%d"/>`, secondaryCategoryAxisID, secondaryValueAxisID)
+	}
+	return fmt.Sprintf(`<c:axId val="%d"/><c:axId val="%d"/>`, primaryCategoryAxisID, primaryValueAxisID)
+}
+
+// seriesChartKind returns the chart type of a series, which defaults to the
+// chart type of the chart
+func seriesChartKind(series SeriesOptions, opts ExtendedChartOptions) ChartKind {
+	if series.ChartKind != "" {
+		return series.ChartKind
+	}
+	if opts.ChartKind != "" {
+		return opts.ChartKind
+	}
+	return ChartKindColumn
+}
+
+// extendedPlotGroups groups the series of a chart into plots by chart type
+// and axis, in the order the plots first appear in the series
+func extendedPlotGroups(opts ExtendedChartOptions) []chartPlotGroup {
+	var groups []chartPlotGroup
+	for i, series := range opts.Series {
+		kind := seriesChartKind(series, opts)
+		at := slices.IndexFunc(groups, func(g chartPlotGroup) bool {
+			return g.kind == kind && g.secondary == series.SecondaryAxis
+		})
+		if at == -1 {
+			groups = append(groups, chartPlotGroup{kind: kind, secondary: series.SecondaryAxis})
+			at = len(groups) - 1
+		}
+		groups[at].series = append(groups[at].series, i)
+	}
+	return groups
+}
+
+// isComboChartKind reports whether series of a chart type can share a chart
+// with series of other chart types
+func isComboChartKind(kind ChartKind) bool {
+	return kind == ChartKindColumn || kind == ChartKindLine || kind == ChartKindArea
+}
+
+// isPieChartKind reports whether a chart type is drawn without axes
+func isPieChartKind(kind ChartKind) bool {
+	return kind == ChartKindPie || kind == ChartKindDoughnut || kind == ChartKindPie3D
+}
+
+// validateComboSeries validates the chart types and axes of the series of a
+// combo chart. Bar, line and area series can share a chart; series of the
+// other chart types cannot be combined with other chart types.
+func validateComboSeries(opts ExtendedChartOptions) error {
+	kinds := make(map[ChartKind]bool)
+	primary := false
+	for i, series := range opts.Series {
+		kind := seriesChartKind(series, opts)
+		switch kind {
+		case ChartKindColumn, ChartKindLine, ChartKindArea:
+		case ChartKindPie, ChartKindScatter, ChartKindBubble, ChartKindDoughnut,
+			ChartKindRadar, ChartKindStock, ChartKindColumn3D, ChartKindPie3D:
+			if series.SecondaryAxis {
+				return fmt.Errorf("series[%d]: %s series cannot use a secondary axis", i, kind)
+			}
+		default:
+			return fmt.Errorf("series[%d]: unsupported chart kind %q", i, kind)
+		}
+		kinds[kind] = true
+		primary = primary || !series.SecondaryAxis
+	}
+	if len(kinds) > 1 {
+		for _, series := range opts.Series {
+			if kind := seriesChartKind(series, opts); !isComboChartKind(kind) {
+				return fmt.Errorf("%s series cannot be combined with other chart kinds", kind)
+			}
+		}
+	}
+	if !primary {
+		return fmt.Errorf("at least one series must use the primary axis")
+	}
+	return nil
+}
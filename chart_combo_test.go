@@ -0,0 +1,155 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func insertComboChart(t *testing.T) *godocx.Updater {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertChartExtended(godocx.ExtendedChartOptions{
+		Position:   godocx.PositionEnd,
+		ChartKind:  godocx.ChartKindColumn,
+		Categories: []string{"Q1", "Q2", "Q3"},
+		Series: []godocx.SeriesOptions{
+			{Name: "Revenue", Values: []float64{100, 120, 140}},
+			{Name: "Margin", Values: []float64{0.2, 0.25, 0.3}, ChartKind: godocx.ChartKindLine, SecondaryAxis: true},
+			{Name: "Costs", Values: []float64{80, 90, 98}},
+		},
+		SecondaryValueAxis: &godocx.AxisOptions{Title: "Margin", NumberFormat: "0%"},
+	})
+	if err != nil {
+		t.Fatalf("InsertChartExtended failed: %v", err)
+	}
+	return u
+}
+
+// plotSection returns the XML of the plot element (e.g. "c:lineChart") of a
+// chart
+func plotSection(chart, name string) string {
+	start := strings.Index(chart, "<"+name+">")
+	end := strings.Index(chart, "</"+name+">")
+	if start == -1 || end == -1 {
+		return ""
+	}
+	return chart[start:end]
+}
+
+func TestInsertComboChart(t *testing.T) {
+	u := insertComboChart(t)
+	chart := readChartPart(t, u, 1)
+
+	bars := plotSection(chart, "c:barChart")
+	lines := plotSection(chart, "c:lineChart")
+	if !strings.Contains(bars, "Revenue") || !strings.Contains(bars, "Costs") || strings.Contains(bars, "Margin") {
+		t.Error("expected the revenue and costs series in the bar plot")
+	}
+	if !strings.Contains(lines, "Margin") || strings.Count(lines, "<c:ser>") != 1 {
+		t.Error("expected the margin series in the line plot")
+	}
+
+	// Series keep their index and worksheet column across plots
+	if !strings.Contains(lines, `<c:idx val="1"/>`) || !strings.Contains(lines, `Sheet1!$C$2:$C$4`) {
+		t.Error("expected the margin series to keep index 1 and column C")
+	}
+	if !strings.Contains(bars, `<c:idx val="2"/>`) || !strings.Contains(bars, `Sheet1!$D$2:$D$4`) {
+		t.Error("expected the costs series to keep index 2 and column D")
+	}
+
+	// The line plot uses the secondary axes: a hidden category axis and a
+	// value axis on the right crossing at the maximum
+	if !strings.Contains(lines, `<c:axId val="2071991560"/><c:axId val="2071991720"/>`) {
+		t.Error("expected the line plot to reference the secondary axes")
+	}
+	if strings.Count(chart, "<c:valAx>") != 2 || strings.Count(chart, "<c:catAx>") != 2 {
+		t.Fatal("expected two category and two value axes")
+	}
+	secondary := chart[strings.LastIndex(chart, "<c:valAx>"):]
+	for _, want := range []string{`<c:axPos val="r"/>`, `<c:crosses val="max"/>`, `formatCode="0%"`, `<a:t>Margin</a:t>`} {
+		if !strings.Contains(secondary, want) {
+			t.Errorf("expected %s in the secondary value axis", want)
+		}
+	}
+	if strings.Contains(secondary, "<c:majorGridlines/>") {
+		t.Error("expected no gridlines on the secondary value axis by default")
+	}
+}
+
+func TestInsertComboChartValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	tests := []struct {
+		name   string
+		series []godocx.SeriesOptions
+	}{
+		{"pie with bars", []godocx.SeriesOptions{
+			{Name: "A", Values: []float64{1}},
+			{Name: "B", Values: []float64{2}, ChartKind: godocx.ChartKindPie},
+		}},
+		{"unsupported kind", []godocx.SeriesOptions{
+			{Name: "A", Values: []float64{1}, ChartKind: "surfaceChart"},
+		}},
+		{"no primary series", []godocx.SeriesOptions{
+			{Name: "A", Values: []float64{1}, SecondaryAxis: true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.InsertChartExtended(godocx.ExtendedChartOptions{
+				Position:   godocx.PositionEnd,
+				Categories: []string{"X"},
+				Series:     tt.series,
+			})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestUpdateComboChart(t *testing.T) {
+	for _, preserve := range []bool{false, true} {
+		u := insertComboChart(t)
+
+		// Series are routed by name, whatever their order; the new series
+		// takes the place of the unmatched costs series
+		err := u.UpdateChart(1, godocx.ChartData{
+			Categories: []string{"Jan", "Feb"},
+			Series: []godocx.SeriesData{
+				{Name: "Margin", Values: []float64{0.4, 0.5}},
+				{Name: "Revenue", Values: []float64{10, 20}},
+				{Name: "Headcount", Values: []float64{5, 6}},
+			},
+			PreserveFormatting: preserve,
+		})
+		if err != nil {
+			t.Fatalf("UpdateChart failed: %v", err)
+		}
+
+		chart := readChartPart(t, u, 1)
+		bars := plotSection(chart, "c:barChart")
+		lines := plotSection(chart, "c:lineChart")
+		if !strings.Contains(lines, "Margin") || !strings.Contains(lines, "<c:v>0.5</c:v>") || strings.Count(lines, "<c:ser>") != 1 {
+			t.Errorf("preserve=%v: expected the margin series to stay in the line plot", preserve)
+		}
+		if !strings.Contains(bars, "Revenue") || !strings.Contains(bars, "Headcount") || strings.Contains(bars, "Costs") {
+			t.Errorf("preserve=%v: expected revenue and headcount in the bar plot", preserve)
+		}
+		if !strings.Contains(lines, `<c:axId val="2071991720"/>`) {
+			t.Errorf("preserve=%v: expected the line plot to keep its axes", preserve)
+		}
+	}
+}
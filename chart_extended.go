@@ -1,5 +1,7 @@
 package godocx
 
+import "time"
+
 // ChartStyle represents predefined chart styles (1-48 in Office)
 type ChartStyle int
 
@@ -33,6 +35,38 @@ const (
 	AxisPositionTop    AxisPosition = "t" // Top
 )
 
+// AxisOrientation defines the direction of an axis
+type AxisOrientation string
+
+const (
+	AxisOrientationMinMax AxisOrientation = "minMax" // Minimum to maximum (default)
+	AxisOrientationMaxMin AxisOrientation = "maxMin" // Maximum to minimum (reversed)
+)
+
+// DisplayUnits defines the built-in units values of an axis are shown in
+type DisplayUnits string
+
+const (
+	DisplayUnitsHundreds         DisplayUnits = "hundreds"
+	DisplayUnitsThousands        DisplayUnits = "thousands"
+	DisplayUnitsTenThousands     DisplayUnits = "tenThousands"
+	DisplayUnitsHundredThousands DisplayUnits = "hundredThousands"
+	DisplayUnitsMillions         DisplayUnits = "millions"
+	DisplayUnitsTenMillions      DisplayUnits = "tenMillions"
+	DisplayUnitsHundredMillions  DisplayUnits = "hundredMillions"
+	DisplayUnitsBillions         DisplayUnits = "billions"
+	DisplayUnitsTrillions        DisplayUnits = "trillions"
+)
+
+// TimeUnit defines the time unit of a date axis
+type TimeUnit string
+
+const (
+	TimeUnitDays   TimeUnit = "days"   // Days (default)
+	TimeUnitMonths TimeUnit = "months" // Months
+	TimeUnitYears  TimeUnit = "years"  // Years
+)
+
 // TickMark defines tick mark type
 type TickMark string
 
@@ -80,6 +114,7 @@ type DataLabelOptions struct {
 	ShowLegendKey    bool              // Show legend key (default: false)
 	Position         DataLabelPosition // Label position (default: bestFit)
 	ShowLeaderLines  bool              // Show leader lines for pie charts (default: true)
+	Font             *ChartFont        // Label font (nil for default)
 }
 
 // AxisOptions defines comprehensive axis customization
@@ -89,10 +124,20 @@ type AxisOptions struct {
 	TitleOverlay bool   // Title overlays chart area (default: false)
 
 	// Scale properties
-	Min       *float64 // Minimum value (nil for auto)
-	Max       *float64 // Maximum value (nil for auto)
-	MajorUnit *float64 // Major unit interval (nil for auto)
-	MinorUnit *float64 // Minor unit interval (nil for auto)
+	Min         *float64        // Minimum value (nil for auto)
+	Max         *float64        // Maximum value (nil for auto)
+	MajorUnit   *float64        // Major unit interval (nil for auto; in BaseTimeUnit for date axes)
+	MinorUnit   *float64        // Minor unit interval (nil for auto; in BaseTimeUnit for date axes)
+	LogBase     float64         // Logarithmic scale base (2-1000, 0 for a linear scale)
+	Orientation AxisOrientation // minMax, or maxMin for a reversed axis (default: minMax)
+
+	// Display units (value axes)
+	DisplayUnits      DisplayUnits // Show values in hundreds, thousands, millions, etc. (default: none)
+	DisplayUnitsLabel string       // Label shown next to the axis for the display units (default: none)
+
+	// Date axis (category axis)
+	DateAxis     bool     // Draw the category axis as a date axis (default: false)
+	BaseTimeUnit TimeUnit // Time unit of the date axis (default: days)
 
 	// Display properties
 	Visible  bool         // Show axis (default: true)
@@ -114,6 +159,9 @@ type AxisOptions struct {
 
 	// Crossing
 	CrossesAt *float64 // Where axis crosses (nil for auto)
+
+	// Font of the tick labels (nil for default)
+	Font *ChartFont
 }
 
 // LegendOptions defines legend customization
@@ -121,6 +169,8 @@ type LegendOptions struct {
 	Show     bool   // Show legend (default: true)
 	Position string // Position: "r" (right), "l" (left), "t" (top), "b" (bottom), "tr" (top right)
 	Overlay  bool   // Legend overlays chart (default: false)
+
+	Font *ChartFont // Legend font (nil for default)
 }
 
 // SeriesOptions defines per-series customization
@@ -132,6 +182,109 @@ type SeriesOptions struct {
 	Smooth           bool              // Smooth lines (for line charts) (default: false)
 	ShowMarkers      bool              // Show markers (for line charts) (default: false)
 	DataLabels       *DataLabelOptions // Data labels for this series (nil for default)
+
+	// Combo charts
+	ChartKind     ChartKind // Chart type of this series: column/bar, line or area (default: the chart's ChartKind)
+	SecondaryAxis bool      // Plot against a secondary value axis on the right (default: false)
+
+	// Scatter and bubble charts
+	XValues     []float64 // X values, one per value (required for scatter and bubble charts)
+	BubbleSizes []float64 // Bubble sizes, one per value (required for bubble charts)
+
+	// Data point colors: point index to hex color, e.g. to highlight the
+	// current month
+	PointColors map[int]string
+
+	// Analysis (not for pie, doughnut and radar charts)
+	Trendline *TrendlineOptions // Trendline fitted to the values (nil for none)
+	ErrorBars *ErrorBarOptions  // Error bars on the values (nil for none)
+}
+
+// TrendlineType defines the regression type of a trendline
+type TrendlineType string
+
+const (
+	TrendlineLinear        TrendlineType = "linear"    // Linear (default)
+	TrendlineExponential   TrendlineType = "exp"       // Exponential
+	TrendlineLogarithmic   TrendlineType = "log"       // Logarithmic
+	TrendlinePower         TrendlineType = "power"     // Power
+	TrendlinePolynomial    TrendlineType = "poly"      // Polynomial of the given Order
+	TrendlineMovingAverage TrendlineType = "movingAvg" // Moving average over the given Period
+)
+
+// TrendlineOptions defines a trendline fitted to the values of a series
+type TrendlineOptions struct {
+	Type            TrendlineType // Regression type (default: linear)
+	Name            string        // Name shown in the legend (default: automatic)
+	Order           int           // Polynomial order (2-6, default: 2)
+	Period          int           // Moving average period (default: 2)
+	Forward         float64       // Forecast forward by this many periods (default: 0)
+	Backward        float64       // Forecast backward by this many periods (default: 0)
+	DisplayEquation bool          // Show the trendline equation on the chart (default: false)
+	DisplayRSquared bool          // Show the R-squared value on the chart (default: false)
+	Color           string        // Hex line color (default: automatic)
+}
+
+// ErrorBarType defines how the size of error bars is computed
+type ErrorBarType string
+
+const (
+	ErrorBarFixed      ErrorBarType = "fixedVal"   // Fixed amount given by Value
+	ErrorBarPercentage ErrorBarType = "percentage" // Percentage of each value given by Value
+	ErrorBarStdDev     ErrorBarType = "stdDev"     // Value standard deviations (default: 1)
+	ErrorBarStdErr     ErrorBarType = "stdErr"     // Standard error
+	ErrorBarCustom     ErrorBarType = "cust"       // Custom amounts per point given by Plus and Minus
+)
+
+// ErrorBarDirection defines which sides of a point error bars are drawn on
+type ErrorBarDirection string
+
+const (
+	ErrorBarBoth  ErrorBarDirection = "both"  // Above and below (default)
+	ErrorBarPlus  ErrorBarDirection = "plus"  // Above only
+	ErrorBarMinus ErrorBarDirection = "minus" // Below only
+)
+
+// ErrorBarOptions defines the error bars of a series, e.g. measurement
+// tolerance bands
+type ErrorBarOptions struct {
+	Type      ErrorBarType      // How the error amount is computed (default: fixed)
+	Direction ErrorBarDirection // Sides drawn (default: both)
+	Value     float64           // Amount for fixed, percentage and standard deviation error bars
+	Plus      []float64         // Custom amounts above each point (ErrorBarCustom)
+	Minus     []float64         // Custom amounts below each point (ErrorBarCustom)
+	NoEndCap  bool              // Draw the bars without end caps (default: false)
+	Color     string            // Hex line color (default: automatic)
+}
+
+// ChartFont defines the font of chart text
+type ChartFont struct {
+	Family string  // Font family (e.g. "Arial"; default: theme font)
+	Size   float64 // Size in points (default: automatic)
+	Color  string  // Hex color (default: automatic)
+	Bold   bool    // Bold text (default: false)
+}
+
+// ChartPalette is a list of hex colors applied in turn to the series of a
+// chart
+type ChartPalette []string
+
+// Named palettes
+var (
+	PaletteOffice     = ChartPalette{"4472C4", "ED7D31", "A5A5A5", "FFC000", "5B9BD5", "70AD47"}
+	PaletteColorful   = ChartPalette{"E6194B", "3CB44B", "FFE119", "4363D8", "F58231", "911EB4", "46F0F0", "F032E6"}
+	PaletteMonochrome = ChartPalette{"1F3864", "2F5597", "4472C4", "8FAADC", "B4C7E7", "DAE3F3"}
+	PaletteGrayscale  = ChartPalette{"262626", "595959", "7F7F7F", "A6A6A6", "BFBFBF", "D9D9D9"}
+	PalettePastel     = ChartPalette{"A1C9F4", "FFB482", "8DE5A1", "FF9F9B", "D0BBFF", "DEBB9B"}
+)
+
+// ChartAreaStyle defines the fill and border of the chart or plot area
+type ChartAreaStyle struct {
+	FillColor   string  // Hex fill color (default: automatic)
+	NoFill      bool    // Transparent background
+	BorderColor string  // Hex border color (default: automatic)
+	BorderWidth float64 // Border width in points (default: automatic)
+	NoBorder    bool    // Remove the border
 }
 
 // ChartProperties defines chart-level properties
@@ -159,11 +312,82 @@ type BarChartOptions struct {
 	VaryColors bool         // Vary colors by point (default: false)
 }
 
+// ScatterStyle defines how the series of a scatter chart are drawn
+type ScatterStyle string
+
+const (
+	ScatterStyleMarker       ScatterStyle = "marker"       // Markers only (default)
+	ScatterStyleLine         ScatterStyle = "line"         // Straight lines without markers
+	ScatterStyleLineMarker   ScatterStyle = "lineMarker"   // Straight lines with markers
+	ScatterStyleSmooth       ScatterStyle = "smooth"       // Smooth lines without markers
+	ScatterStyleSmoothMarker ScatterStyle = "smoothMarker" // Smooth lines with markers
+)
+
+// ScatterChartOptions defines options specific to scatter charts
+type ScatterChartOptions struct {
+	Style ScatterStyle // Line and marker style (default: marker)
+}
+
+// BubbleChartOptions defines options specific to bubble charts
+type BubbleChartOptions struct {
+	Scale        int  // Bubble size as a percentage of the default (0-300, default: 100)
+	ShowNegative bool // Show bubbles with negative sizes (default: false)
+}
+
+// DoughnutChartOptions defines options specific to doughnut charts
+type DoughnutChartOptions struct {
+	HoleSize        int         // Hole size as a percentage of the diameter (10-90, default: 50)
+	FirstSliceAngle int         // Angle of the first slice in degrees (0-360, default: 0)
+	ExplodedPoints  map[int]int // Point index to explosion distance as a percentage of the radius (0-400)
+}
+
+// RadarStyle defines how the series of a radar chart are drawn
+type RadarStyle string
+
+const (
+	RadarStyleStandard RadarStyle = "standard" // Lines without markers
+	RadarStyleMarker   RadarStyle = "marker"   // Lines with markers (default)
+	RadarStyleFilled   RadarStyle = "filled"   // Filled areas
+)
+
+// RadarChartOptions defines options specific to radar charts
+type RadarChartOptions struct {
+	Style RadarStyle // Line, marker or filled style (default: marker)
+}
+
+// StockChartOptions defines options specific to stock charts. When nil,
+// high-low lines are shown, with up/down bars for open-high-low-close data.
+type StockChartOptions struct {
+	HighLowLines bool // Draw lines from the high to the low price
+	UpDownBars   bool // Draw bars from the open to the close price (needs 4 series)
+}
+
+// BarShape defines the shape of the columns of 3-D column charts
+type BarShape string
+
+const (
+	BarShapeBox      BarShape = "box"      // Box (default)
+	BarShapeCylinder BarShape = "cylinder" // Cylinder
+	BarShapeCone     BarShape = "cone"     // Cone
+	BarShapePyramid  BarShape = "pyramid"  // Pyramid
+)
+
+// Chart3DOptions defines the view of 3-D column and pie charts
+type Chart3DOptions struct {
+	RotationX    int      // Tilt in degrees (-90 to 90, default: 15 for column, 30 for pie)
+	RotationY    int      // Rotation in degrees (0-360, default: 20 for column, 0 for pie)
+	DepthPercent int      // Depth as a percentage of the column width (20-2000, default: 100)
+	Shape        BarShape // Column shape (default: box)
+}
+
 // ExtendedChartOptions defines comprehensive chart creation options with all customization
 type ExtendedChartOptions struct {
 	// Position and basic info
-	Position InsertPosition
-	Anchor   string
+	Position    InsertPosition
+	Target      *Target // Overrides Position and Anchor (match, table cell, Range...)
+	Anchor      string
+	Occurrence  int  // Which paragraph containing Anchor to use (1-based, default: first)
+	AnchorRegex bool // Treat Anchor as a regular expression
 
 	// Chart type
 	ChartKind ChartKind
@@ -171,14 +395,30 @@ type ExtendedChartOptions struct {
 	// Titles
 	Title        string
 	TitleOverlay bool
+	TitleFont    *ChartFont
 
 	// Data
 	Categories []string
 	Series     []SeriesOptions // Extended series with per-series options
 
+	// CategoryLevels groups the categories under outer levels, outermost
+	// first, with one label per category: Categories Q1..Q4, Q1..Q4 under
+	// the level 2023 x4, 2024 x4 draw a Year > Quarter axis.
+	CategoryLevels [][]string
+	// CategoryDates and CategoryNumbers replace Categories with dates or
+	// numbers, which the chart and its workbook store as numbers. Dates are
+	// stored as serials of the 1900 date system, as days on the clock of
+	// their location.
+	CategoryDates   []time.Time
+	CategoryNumbers []float64
+	// CategoryFormat is the number format of date and numeric categories
+	// (default: "m/d/yyyy" for dates, "General" for numbers)
+	CategoryFormat string
+
 	// Axes
-	CategoryAxis *AxisOptions
-	ValueAxis    *AxisOptions
+	CategoryAxis       *AxisOptions
+	ValueAxis          *AxisOptions
+	SecondaryValueAxis *AxisOptions // Axis of series with SecondaryAxis (default: right, no gridlines)
 
 	// Legend
 	Legend *LegendOptions
@@ -189,8 +429,19 @@ type ExtendedChartOptions struct {
 	// Chart-level properties
 	Properties *ChartProperties
 
+	// Styling
+	Palette   ChartPalette    // Colors of series without their own color, or of pie points (default: theme colors)
+	ChartArea *ChartAreaStyle // Fill and border of the whole chart
+	PlotArea  *ChartAreaStyle // Fill and border of the plot area
+
 	// Chart type specific options
-	BarChartOptions *BarChartOptions
+	BarChartOptions      *BarChartOptions
+	ScatterChartOptions  *ScatterChartOptions
+	BubbleChartOptions   *BubbleChartOptions
+	DoughnutChartOptions *DoughnutChartOptions
+	RadarChartOptions    *RadarChartOptions
+	StockChartOptions    *StockChartOptions
+	Chart3DOptions       *Chart3DOptions // 3-D column and pie charts
 
 	// Dimensions
 	Width  int
@@ -0,0 +1,230 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func newChartKindUpdater(t *testing.T, opts godocx.ExtendedChartOptions) *godocx.Updater {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	opts.Position = godocx.PositionEnd
+	if err := u.InsertChartExtended(opts); err != nil {
+		t.Fatalf("InsertChartExtended failed: %v", err)
+	}
+	return u
+}
+
+var stockSeries = []godocx.SeriesOptions{
+	{Name: "High", Values: []float64{12, 14, 13}},
+	{Name: "Low", Values: []float64{9, 10, 11}},
+	{Name: "Close", Values: []float64{11, 13, 12}, Color: "C00000"},
+}
+
+func TestInsertDoughnutChart(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindDoughnut,
+		Categories: []string{"Rent", "Food", "Travel"},
+		Series:     []godocx.SeriesOptions{{Name: "Budget", Values: []float64{50, 30, 20}}},
+		DoughnutChartOptions: &godocx.DoughnutChartOptions{
+			FirstSliceAngle: 90,
+			ExplodedPoints:  map[int]int{2: 25, 0: 10},
+		},
+	})
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:doughnutChart><c:varyColors val="1"/>`,
+		`<c:firstSliceAng val="90"/><c:holeSize val="50"/></c:doughnutChart>`,
+		`<c:dPt><c:idx val="0"/><c:bubble3D val="0"/><c:explosion val="10"/></c:dPt><c:dPt><c:idx val="2"/><c:bubble3D val="0"/><c:explosion val="25"/></c:dPt>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+	if strings.Contains(chart, "<c:catAx>") || strings.Contains(chart, "<c:valAx>") {
+		t.Error("expected no axes in a doughnut chart")
+	}
+}
+
+func TestInsertRadarChart(t *testing.T) {
+	for _, tt := range []struct {
+		style godocx.RadarStyle
+		want  string
+	}{
+		{"", `<c:radarStyle val="marker"/>`},
+		{godocx.RadarStyleStandard, `<c:symbol val="none"/>`},
+		{godocx.RadarStyleFilled, `<c:spPr><a:solidFill><a:srgbClr val="4472C4"/></a:solidFill></c:spPr>`},
+	} {
+		u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+			ChartKind:         godocx.ChartKindRadar,
+			Categories:        []string{"Speed", "Range", "Cost"},
+			Series:            []godocx.SeriesOptions{{Name: "Model A", Values: []float64{3, 4, 2}, Color: "4472C4"}},
+			RadarChartOptions: &godocx.RadarChartOptions{Style: tt.style},
+		})
+
+		chart := readChartPart(t, u, 1)
+		if !strings.Contains(chart, tt.want) {
+			t.Errorf("style %q: expected %s in chart", tt.style, tt.want)
+		}
+		if !strings.Contains(chart, "<c:catAx>") || !strings.Contains(chart, "<c:valAx>") {
+			t.Errorf("style %q: expected category and value axes", tt.style)
+		}
+	}
+}
+
+func TestInsertStockChart(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindStock,
+		Categories: []string{"Mon", "Tue", "Wed"},
+		Series:     stockSeries,
+	})
+
+	chart := readChartPart(t, u, 1)
+	if n := strings.Count(chart, `<c:spPr><a:ln w="19050"><a:noFill/></a:ln></c:spPr>`); n != 3 {
+		t.Errorf("expected 3 series without lines, got %d", n)
+	}
+	if !strings.Contains(chart, `<c:marker><c:symbol val="dash"/><c:size val="7"/>`) {
+		t.Error("expected a marker for the close price")
+	}
+	if !strings.Contains(chart, `<c:hiLowLines/>`) || strings.Contains(chart, "<c:upDownBars>") {
+		t.Error("expected high-low lines without up/down bars")
+	}
+
+	// Open-high-low-close data gets up/down bars by default
+	u = newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindStock,
+		Categories: []string{"Mon", "Tue", "Wed"},
+		Series:     append([]godocx.SeriesOptions{{Name: "Open", Values: []float64{10, 11, 13}}}, stockSeries...),
+	})
+	chart = readChartPart(t, u, 1)
+	if !strings.Contains(chart, `<c:hiLowLines/><c:upDownBars>`) || strings.Contains(chart, `val="dash"`) {
+		t.Error("expected up/down bars instead of close markers")
+	}
+}
+
+func TestInsert3DCharts(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:      godocx.ChartKindColumn3D,
+		Categories:     []string{"Q1", "Q2"},
+		Series:         []godocx.SeriesOptions{{Name: "Sales", Values: []float64{10, 20}}},
+		Chart3DOptions: &godocx.Chart3DOptions{Shape: godocx.BarShapeCylinder},
+	})
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:autoTitleDeleted val="0"/><c:view3D><c:rotX val="15"/><c:rotY val="20"/><c:depthPercent val="100"/><c:rAngAx val="1"/></c:view3D><c:plotArea>`,
+		`<c:bar3DChart><c:barDir val="col"/><c:grouping val="clustered"/>`,
+		`<c:gapWidth val="150"/><c:shape val="cylinder"/>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in 3-D column chart", want)
+		}
+	}
+
+	u = newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindPie3D,
+		Categories: []string{"A", "B"},
+		Series:     []godocx.SeriesOptions{{Name: "Share", Values: []float64{60, 40}}},
+	})
+	chart = readChartPart(t, u, 1)
+	if !strings.Contains(chart, `<c:view3D><c:rotX val="30"/><c:rotY val="0"/><c:rAngAx val="0"/></c:view3D>`) ||
+		!strings.Contains(chart, "<c:pie3DChart>") || strings.Contains(chart, "<c:catAx>") {
+		t.Error("expected a 3-D pie chart without axes")
+	}
+}
+
+func TestInsertChartKindValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	one := []godocx.SeriesOptions{{Name: "A", Values: []float64{1}}}
+	tests := []struct {
+		name string
+		opts godocx.ExtendedChartOptions
+	}{
+		{"hole size", godocx.ExtendedChartOptions{ChartKind: godocx.ChartKindDoughnut, Series: one,
+			DoughnutChartOptions: &godocx.DoughnutChartOptions{HoleSize: 95}}},
+		{"exploded point out of range", godocx.ExtendedChartOptions{ChartKind: godocx.ChartKindDoughnut, Series: one,
+			DoughnutChartOptions: &godocx.DoughnutChartOptions{ExplodedPoints: map[int]int{1: 10}}}},
+		{"radar style", godocx.ExtendedChartOptions{ChartKind: godocx.ChartKindRadar, Series: one,
+			RadarChartOptions: &godocx.RadarChartOptions{Style: "spiky"}}},
+		{"stock series count", godocx.ExtendedChartOptions{ChartKind: godocx.ChartKindStock, Series: one}},
+		{"up/down bars without open", godocx.ExtendedChartOptions{ChartKind: godocx.ChartKindStock, Series: stockSeries,
+			StockChartOptions: &godocx.StockChartOptions{UpDownBars: true}}},
+		{"3-D rotation", godocx.ExtendedChartOptions{ChartKind: godocx.ChartKindColumn3D, Series: one,
+			Chart3DOptions: &godocx.Chart3DOptions{RotationX: 120}}},
+		{"3-D with line", godocx.ExtendedChartOptions{ChartKind: godocx.ChartKindColumn3D, Series: []godocx.SeriesOptions{
+			{Name: "A", Values: []float64{1}},
+			{Name: "B", Values: []float64{1}, ChartKind: godocx.ChartKindLine},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Position = godocx.PositionEnd
+			tt.opts.Categories = []string{"X"}
+			if len(tt.opts.Series[0].Values) == 3 {
+				tt.opts.Categories = []string{"X", "Y", "Z"}
+			}
+			if err := u.InsertChartExtended(tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestUpdateChartKinds(t *testing.T) {
+	// Rebuilding the series keeps the settings of the chart element
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:            godocx.ChartKindDoughnut,
+		Categories:           []string{"A", "B"},
+		Series:               []godocx.SeriesOptions{{Name: "Share", Values: []float64{60, 40}}},
+		DoughnutChartOptions: &godocx.DoughnutChartOptions{HoleSize: 70},
+	})
+	err := u.UpdateChart(1, godocx.ChartData{
+		Categories: []string{"A", "B", "C"},
+		Series:     []godocx.SeriesData{{Name: "Share", Values: []float64{50, 30, 20}}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChart failed: %v", err)
+	}
+	chart := readChartPart(t, u, 1)
+	if !strings.Contains(chart, `<c:v>20</c:v>`) || !strings.Contains(chart, `<c:holeSize val="70"/></c:doughnutChart>`) {
+		t.Error("expected the new values and the hole size to be kept")
+	}
+
+	// Stock series keep their price formatting
+	u = newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindStock,
+		Categories: []string{"Mon", "Tue", "Wed"},
+		Series:     stockSeries,
+	})
+	err = u.UpdateChart(1, godocx.ChartData{
+		Categories: []string{"Thu", "Fri"},
+		Series: []godocx.SeriesData{
+			{Name: "High", Values: []float64{15, 16}},
+			{Name: "Low", Values: []float64{12, 13}},
+			{Name: "Close", Values: []float64{14, 15}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChart failed: %v", err)
+	}
+	chart = readChartPart(t, u, 1)
+	if strings.Count(chart, `<a:noFill/>`) != 3 || !strings.Contains(chart, `val="dash"`) || !strings.Contains(chart, `<c:hiLowLines/>`) {
+		t.Error("expected the stock formatting to be kept")
+	}
+	if !strings.Contains(chart, `<c:v>Fri</c:v>`) || !strings.Contains(chart, `<c:v>16</c:v>`) {
+		t.Error("expected the new prices")
+	}
+}
@@ -0,0 +1,503 @@
+package godocx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ChartOptionsPatch defines the changes UpdateChartOptions applies to an
+// existing chart. Nil and zero values leave the corresponding property
+// unchanged.
+type ChartOptionsPatch struct {
+	CategoryAxis       *AxisPatch // Category axis (X axis of scatter and bubble charts)
+	ValueAxis          *AxisPatch // Primary value axis
+	SecondaryValueAxis *AxisPatch // Value axis of the secondary plot of a combo chart
+
+	Legend     *LegendPatch      // Legend changes
+	DataLabels *DataLabelOptions // New data labels for every series, replacing series-level labels
+
+	// Bar and column plots
+	Grouping BarGrouping // New grouping; stacked bars fully overlap unless Overlap is set
+	GapWidth *int        // Gap between bar groups (0-500)
+	Overlap  *int        // Overlap of bars (-100 to 100)
+
+	SeriesColors map[string]string // Hex colors by series name
+}
+
+// AxisPatch defines the changes to an axis of an existing chart
+type AxisPatch struct {
+	Min          *float64 // Fixed minimum
+	Max          *float64 // Fixed maximum
+	NumberFormat string   // Number format code of the labels, e.g. "0%"
+}
+
+// LegendPatch defines the changes to the legend of an existing chart. Nil
+// and zero values leave the corresponding property unchanged.
+type LegendPatch struct {
+	Show     *bool      // Show or remove the legend; a legend is added when missing
+	Position string     // Position: "r" (right), "l" (left), "t" (top), "b" (bottom), "tr" (top right)
+	Overlay  *bool      // Legend overlays chart
+	Font     *ChartFont // Legend font
+}
+
+// chartChildOrder is the schema order of the children of <c:chart>
+var chartChildOrder = []string{
+	"title", "autoTitleDeleted", "pivotFmts", "view3D", "floor", "sideWall", "backWall",
+	"plotArea", "legend", "plotVisOnly", "dispBlanksAs", "showDLblsOverMax", "extLst",
+}
+
+// plotChildOrder is the schema order of the children of a plot such as
+// <c:barChart>, merged across chart types
+var plotChildOrder = []string{
+	"barDir", "grouping", "radarStyle", "scatterStyle", "varyColors", "ser", "dLbls",
+	"gapWidth", "gapDepth", "overlap", "serLines", "dropLines", "hiLowLines", "upDownBars",
+	"marker", "smooth", "firstSliceAng", "holeSize", "bubble3D", "bubbleScale",
+	"showNegBubbles", "sizeRepresents", "shape", "axId", "extLst",
+}
+
+// axisChildOrder is the schema order of the children of an axis, merged
+// across axis types
+var axisChildOrder = []string{
+	"axId", "scaling", "delete", "axPos", "majorGridlines", "minorGridlines", "title",
+	"numFmt", "majorTickMark", "minorTickMark", "tickLblPos", "spPr", "txPr", "crossAx",
+	"crosses", "crossesAt", "crossBetween", "auto", "lblAlgn", "lblOffset", "baseTimeUnit",
+	"majorUnit", "majorTimeUnit", "minorUnit", "minorTimeUnit", "tickLblSkip",
+	"tickMarkSkip", "noMultiLvlLbl", "dispUnits", "extLst",
+}
+
+var (
+	scalingChildOrder = []string{"logBase", "orientation", "max", "min", "extLst"}
+	legendChildOrder  = []string{"legendPos", "legendEntry", "layout", "overlay", "spPr", "txPr", "extLst"}
+	markerChildOrder  = []string{"symbol", "size", "spPr", "extLst"}
+	shapeChildOrder   = []string{"xfrm", "custGeom", "prstGeom", "noFill", "solidFill", "gradFill", "blipFill", "pattFill", "grpFill", "ln", "effectLst", "effectDag", "scene3d", "sp3d", "extLst"}
+	lineChildOrder    = []string{"noFill", "solidFill", "gradFill", "pattFill", "prstDash", "custDash", "round", "bevel", "miter", "headEnd", "tailEnd", "extLst"}
+)
+
+// otherFills are the fills of shapes and lines that a solid fill replaces
+var otherFills = []string{"a:noFill", "a:gradFill", "a:blipFill", "a:pattFill", "a:grpFill"}
+
+// UpdateChartOptions changes the axes, legend, data labels, bar layout and
+// series colors of an existing chart by index (1-based). The rest of the
+// chart XML, including the data, is kept, so charts designed in Word can be
+// restyled per report.
+func (u *Updater) UpdateChartOptions(chartIndex int, patch ChartOptionsPatch) error {
+	if u == nil {
+		return errors.New("updater is nil")
+	}
+	if chartIndex < 1 {
+		return errors.New("chart index must be >= 1")
+	}
+	if err := validateChartOptionsPatch(patch); err != nil {
+		return err
+	}
+
+	chartPath := filepath.Join(u.tempDir, "word", "charts", fmt.Sprintf("chart%d.xml", chartIndex))
+	rawXML, err := os.ReadFile(chartPath)
+	if err != nil {
+		return fmt.Errorf("read chart xml: %w", err)
+	}
+
+	updated, err := patchChartXML(rawXML, patch)
+	if err != nil {
+		return fmt.Errorf("update chart options: %w", err)
+	}
+	updated = ensureXMLDeclarationNewline(updated)
+
+	if err := os.WriteFile(chartPath, updated, 0o644); err != nil {
+		return fmt.Errorf("write chart xml: %w", err)
+	}
+	return nil
+}
+
+// validateChartOptionsPatch validates the changes of a chart options patch
+func validateChartOptionsPatch(patch ChartOptionsPatch) error {
+	for _, axis := range []struct {
+		name  string
+		patch *AxisPatch
+	}{
+		{"CategoryAxis", patch.CategoryAxis},
+		{"ValueAxis", patch.ValueAxis},
+		{"SecondaryValueAxis", patch.SecondaryValueAxis},
+	} {
+		if a := axis.patch; a != nil && a.Min != nil && a.Max != nil && *a.Min >= *a.Max {
+			return fmt.Errorf("%s: Min must be less than Max", axis.name)
+		}
+	}
+
+	if patch.Legend != nil {
+		switch patch.Legend.Position {
+		case "", "r", "l", "t", "b", "tr":
+		default:
+			return fmt.Errorf("Legend.Position %q is not supported", patch.Legend.Position)
+		}
+	}
+
+	switch patch.Grouping {
+	case "", BarGroupingClustered, BarGroupingStacked, BarGroupingPercentStacked, BarGroupingStandard:
+	default:
+		return fmt.Errorf("Grouping %q is not supported", patch.Grouping)
+	}
+	if patch.GapWidth != nil && (*patch.GapWidth < 0 || *patch.GapWidth > 500) {
+		return fmt.Errorf("GapWidth must be between 0 and 500")
+	}
+	if patch.Overlap != nil && (*patch.Overlap < -100 || *patch.Overlap > 100) {
+		return fmt.Errorf("Overlap must be between -100 and 100")
+	}
+
+	for name, color := range patch.SeriesColors {
+		if normalizeHexColor(color) == "" {
+			return fmt.Errorf("SeriesColors[%q]: invalid color %q", name, color)
+		}
+	}
+	return nil
+}
+
+// patchChartXML applies a chart options patch to the XML of a chart part
+func patchChartXML(rawXML []byte, patch ChartOptionsPatch) ([]byte, error) {
+	p := detectNamespacePrefix(string(rawXML))
+
+	chartStart := findNextTagStart(rawXML, 0, p+"chart")
+	if chartStart == -1 {
+		return nil, fmt.Errorf("malformed chart XML: no chart element")
+	}
+	chartEnd := findQualifiedElementEnd(rawXML, chartStart, p+"chart")
+	if chartEnd == -1 {
+		return nil, fmt.Errorf("malformed chart XML: no closing tag for chart")
+	}
+	chart := rawXML[chartStart:chartEnd]
+
+	plotArea := childElement(chart, p+"plotArea")
+	if plotArea == nil {
+		return nil, fmt.Errorf("malformed chart XML: no plot area")
+	}
+	found := make(map[string]bool)
+	plotArea, err := patchPlotArea(plotArea, patch, p, found)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(patch.SeriesColors)) {
+		if !found[name] {
+			return nil, fmt.Errorf("series %q not found in chart", name)
+		}
+	}
+
+	props := []propertyElement{{p + "plotArea", plotArea}}
+	var remove []string
+	if legend := patch.Legend; legend != nil {
+		existing := childElement(chart, p+"legend")
+		switch {
+		case legend.Show != nil && !*legend.Show:
+			remove = append(remove, p+"legend")
+		case existing != nil || legend.Show != nil:
+			props = append(props, propertyElement{p + "legend", patchLegend(existing, legend, p)})
+		}
+	}
+	chart = mergeChildElements(chart, chartChildOrder, props, remove)
+
+	return spliceBytes(rawXML, chart, chartStart, chartEnd), nil
+}
+
+// patchPlotArea applies a patch to the plots and axes of a plot area,
+// recording the names of the recolored series in found. The axes are
+// located through the axis IDs of the plots: the first plot refers to the
+// category and value axes, and the first plot with another value axis to
+// the secondary value axis.
+func patchPlotArea(plotArea []byte, patch ChartOptionsPatch, p string, found map[string]bool) ([]byte, error) {
+	openEnd := bytes.IndexByte(plotArea, '>') + 1
+	closeStart := bytes.LastIndex(plotArea, []byte("</"))
+	children := childElements(plotArea, openEnd, closeStart)
+
+	var catID, valID, secondaryID string
+	for _, child := range children {
+		if !strings.HasSuffix(child.name, "Chart") {
+			continue
+		}
+		plot := plotArea[child.start:child.end]
+		var ids []string
+		for _, c := range childElements(plot, bytes.IndexByte(plot, '>')+1, len(plot)) {
+			if c.name == p+"axId" {
+				ids = append(ids, xmlAttr(plot[c.start:c.end], "val"))
+			}
+		}
+		if len(ids) < 2 {
+			continue
+		}
+		if catID == "" {
+			catID, valID = ids[0], ids[1]
+		} else if secondaryID == "" && ids[1] != valID {
+			secondaryID = ids[1]
+		}
+	}
+
+	axes := make(map[string]*AxisPatch)
+	for _, axis := range []struct {
+		name  string
+		id    string
+		patch *AxisPatch
+	}{
+		{"category axis", catID, patch.CategoryAxis},
+		{"value axis", valID, patch.ValueAxis},
+		{"secondary value axis", secondaryID, patch.SecondaryValueAxis},
+	} {
+		if axis.patch == nil {
+			continue
+		}
+		if axis.id == "" {
+			return nil, fmt.Errorf("chart has no %s", axis.name)
+		}
+		axes[axis.id] = axis.patch
+	}
+
+	var buf bytes.Buffer
+	buf.Write(plotArea[:openEnd])
+	prev := openEnd
+	for _, child := range children {
+		buf.Write(plotArea[prev:child.start])
+		element := plotArea[child.start:child.end]
+		local := strings.TrimPrefix(child.name, p)
+		switch {
+		case strings.HasSuffix(local, "Chart"):
+			element = patchPlot(element, local, patch, p, found)
+		case strings.HasSuffix(local, "Ax"):
+			id := seriesChildVal(element, p+"axId")
+			if axis, ok := axes[id]; ok {
+				element = patchAxis(element, axis, p)
+				delete(axes, id)
+			}
+		}
+		buf.Write(element)
+		prev = child.end
+	}
+	buf.Write(plotArea[prev:])
+
+	if len(axes) > 0 {
+		return nil, fmt.Errorf("malformed chart XML: axis referenced by a plot not found")
+	}
+	return buf.Bytes(), nil
+}
+
+// patchPlot applies the data labels, bar layout and series colors of a
+// patch to a plot, e.g. <c:barChart>, of the given type
+func patchPlot(plot []byte, chartType string, patch ChartOptionsPatch, p string, found map[string]bool) []byte {
+	lineKind := chartType == "lineChart" || chartType == "line3DChart" || chartType == "radarChart" || chartType == "scatterChart"
+
+	// Series: colors and data labels
+	openEnd := bytes.IndexByte(plot, '>') + 1
+	var buf bytes.Buffer
+	buf.Write(plot[:openEnd])
+	prev := openEnd
+	for _, child := range childElements(plot, openEnd, len(plot)) {
+		buf.Write(plot[prev:child.start])
+		element := plot[child.start:child.end]
+		if child.name == p+"ser" {
+			var props []propertyElement
+			var remove []string
+			if patch.DataLabels != nil {
+				remove = append(remove, p+"dLbls")
+			}
+			name := seriesName(element, p)
+			if color, ok := patch.SeriesColors[name]; ok {
+				found[name] = true
+				props = seriesColorProperties(element, normalizeHexColor(color), lineKind, p)
+			}
+			if len(props) > 0 || len(remove) > 0 {
+				element = mergeChildElements(element, seriesChildOrder, props, remove)
+			}
+		}
+		buf.Write(element)
+		prev = child.end
+	}
+	buf.Write(plot[prev:])
+	plot = buf.Bytes()
+
+	var props []propertyElement
+	if patch.DataLabels != nil {
+		props = append(props, propertyElement{p + "dLbls", chartPrefixed(generateDataLabelsXML(patch.DataLabels), p)})
+	}
+	if chartType == "barChart" || chartType == "bar3DChart" {
+		if patch.Grouping != "" {
+			props = append(props, propertyElement{p + "grouping", chartPrefixed(fmt.Sprintf(`<c:grouping val="%s"/>`, patch.Grouping), p)})
+		}
+		if patch.GapWidth != nil {
+			props = append(props, propertyElement{p + "gapWidth", chartPrefixed(fmt.Sprintf(`<c:gapWidth val="%d"/>`, *patch.GapWidth), p)})
+		}
+
+		// Stacked bars must overlap fully; bars no longer stacked stop
+		// overlapping
+		overlap := patch.Overlap
+		full, none := 100, 0
+		if overlap == nil && patch.Grouping != "" {
+			stacked := func(grouping string) bool {
+				return grouping == string(BarGroupingStacked) || grouping == string(BarGroupingPercentStacked)
+			}
+			if stacked(string(patch.Grouping)) {
+				overlap = &full
+			} else if stacked(seriesChildVal(plot, p+"grouping")) {
+				overlap = &none
+			}
+		}
+		if overlap != nil && chartType == "barChart" {
+			props = append(props, propertyElement{p + "overlap", chartPrefixed(fmt.Sprintf(`<c:overlap val="%d"/>`, *overlap), p)})
+		}
+	}
+	if len(props) == 0 {
+		return plot
+	}
+	return mergeChildElements(plot, plotChildOrder, props, nil)
+}
+
+// seriesColorProperties returns the shape properties giving a series the
+// given color: the line of line, radar and scatter series and the fill of
+// other series. Only the fill changes; borders, dashes and effects stay. A
+// hidden line, as of marker-only or stock series, stays hidden. Visible
+// markers take the color too.
+func seriesColorProperties(serXML []byte, color string, lineKind bool, p string) []propertyElement {
+	fill := fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color)
+
+	var props []propertyElement
+	if spPr := childElement(serXML, p+"spPr"); !hasHiddenLine(spPr) {
+		if lineKind {
+			spPr = setLineFill(spPr, fill, `<a:ln w="28575" cap="rnd"><a:round/></a:ln>`, p)
+		} else {
+			spPr = setShapeFill(spPr, fill, p)
+		}
+		props = append(props, propertyElement{p + "spPr", spPr})
+	}
+
+	if marker := childElement(serXML, p+"marker"); marker != nil && !bytes.Contains(marker, []byte(`symbol val="none"`)) {
+		markerShape := setLineFill(setShapeFill(childElement(marker, p+"spPr"), fill, p), fill, `<a:ln></a:ln>`, p)
+		marker = mergeChildElements(openElement(marker, p+"marker"), markerChildOrder, []propertyElement{{p + "spPr", markerShape}}, nil)
+		props = append(props, propertyElement{p + "marker", marker})
+	}
+	return props
+}
+
+// setShapeFill returns shape properties, new ones when spPr is nil, with
+// the given fill in place of any other fill
+func setShapeFill(spPr []byte, fill, p string) []byte {
+	if spPr == nil {
+		spPr = chartPrefixed(`<c:spPr></c:spPr>`, p)
+	}
+	return mergeChildElements(openElement(spPr, p+"spPr"), shapeChildOrder, []propertyElement{{"a:solidFill", []byte(fill)}}, otherFills)
+}
+
+// setLineFill returns shape properties, new ones when spPr is nil, whose
+// line has the given fill in place of any other fill. newLine is the line
+// added when the shape properties have none.
+func setLineFill(spPr []byte, fill, newLine, p string) []byte {
+	if spPr == nil {
+		spPr = chartPrefixed(`<c:spPr></c:spPr>`, p)
+	}
+	line := childElement(spPr, "a:ln")
+	if line == nil {
+		line = []byte(newLine)
+	}
+	line = mergeChildElements(openElement(line, "a:ln"), lineChildOrder, []propertyElement{{"a:solidFill", []byte(fill)}}, otherFills)
+	return mergeChildElements(openElement(spPr, p+"spPr"), shapeChildOrder, []propertyElement{{"a:ln", line}}, nil)
+}
+
+// hasHiddenLine reports whether shape properties hide the line
+func hasHiddenLine(spPr []byte) bool {
+	start := bytes.Index(spPr, []byte("<a:ln"))
+	if start == -1 {
+		return false
+	}
+	end := bytes.Index(spPr[start:], []byte("</a:ln>"))
+	return end != -1 && bytes.Contains(spPr[start:start+end], []byte("<a:noFill/>"))
+}
+
+// patchAxis applies the fixed bounds and number format of a patch to an
+// axis
+func patchAxis(axis []byte, patch *AxisPatch, p string) []byte {
+	var props []propertyElement
+	if patch.Min != nil || patch.Max != nil {
+		scaling := childElement(axis, p+"scaling")
+		if scaling == nil {
+			scaling = chartPrefixed(`<c:scaling><c:orientation val="minMax"/></c:scaling>`, p)
+		}
+		var bounds []propertyElement
+		if patch.Max != nil {
+			bounds = append(bounds, propertyElement{p + "max", chartPrefixed(`<c:max val="`+formatFloat(*patch.Max)+`"/>`, p)})
+		}
+		if patch.Min != nil {
+			bounds = append(bounds, propertyElement{p + "min", chartPrefixed(`<c:min val="`+formatFloat(*patch.Min)+`"/>`, p)})
+		}
+		scaling = mergeChildElements(openElement(scaling, p+"scaling"), scalingChildOrder, bounds, nil)
+		props = append(props, propertyElement{p + "scaling", scaling})
+	}
+	if patch.NumberFormat != "" {
+		numFmt := fmt.Sprintf(`<c:numFmt formatCode="%s" sourceLinked="0"/>`, xmlEscape(patch.NumberFormat))
+		props = append(props, propertyElement{p + "numFmt", chartPrefixed(numFmt, p)})
+	}
+	if len(props) == 0 {
+		return axis
+	}
+	return mergeChildElements(axis, axisChildOrder, props, nil)
+}
+
+// patchLegend returns the legend with the position, overlay and font of
+// legend applied, or a new legend when the chart has none
+func patchLegend(existing []byte, legend *LegendPatch, p string) []byte {
+	if existing == nil {
+		opts := LegendOptions{Show: true, Position: legend.Position, Font: legend.Font}
+		if opts.Position == "" {
+			opts.Position = "r"
+		}
+		if legend.Overlay != nil {
+			opts.Overlay = *legend.Overlay
+		}
+		return chartPrefixed(generateLegendXML(&opts), p)
+	}
+
+	var props []propertyElement
+	if legend.Position != "" {
+		props = append(props, propertyElement{p + "legendPos", chartPrefixed(fmt.Sprintf(`<c:legendPos val="%s"/>`, legend.Position), p)})
+	}
+	if legend.Overlay != nil {
+		props = append(props, propertyElement{p + "overlay", chartPrefixed(fmt.Sprintf(`<c:overlay val="%d"/>`, boolToInt(*legend.Overlay)), p)})
+	}
+	if legend.Font != nil {
+		props = append(props, propertyElement{p + "txPr", chartPrefixed(generateTextPropertiesXML(legend.Font), p)})
+	}
+	if len(props) == 0 {
+		return existing
+	}
+	return mergeChildElements(openElement(existing, p+"legend"), legendChildOrder, props, nil)
+}
+
+// childElement returns the first child with the given name of element, or
+// nil
+func childElement(element []byte, name string) []byte {
+	openEnd := bytes.IndexByte(element, '>') + 1
+	for _, child := range childElements(element, openEnd, len(element)) {
+		if child.name == name {
+			return element[child.start:child.end]
+		}
+	}
+	return nil
+}
+
+// openElement returns a self-closing element as an empty element with a
+// close tag, so that children can be merged into it
+func openElement(element []byte, name string) []byte {
+	if !bytes.HasSuffix(element, []byte("/>")) {
+		return element
+	}
+	return []byte(string(element[:len(element)-2]) + "></" + name + ">")
+}
+
+// chartPrefixed returns chart XML generated with the c: prefix in the
+// namespace prefix of the chart being edited
+func chartPrefixed(xml, p string) []byte {
+	if p != "c:" {
+		xml = strings.ReplaceAll(xml, "<c:", "<"+p)
+		xml = strings.ReplaceAll(xml, "</c:", "</"+p)
+	}
+	return []byte(xml)
+}
@@ -0,0 +1,173 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func TestUpdateChartOptions(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		Categories: []string{"Q1", "Q2"},
+		Series: []godocx.SeriesOptions{
+			{Name: "Sales", Values: []float64{0.4, 0.6}},
+			{Name: "Costs", Values: []float64{0.3, 0.2}, Color: "4472C4"},
+		},
+	})
+
+	minimum, maximum, gap := 0.0, 1.0, 60
+	err := u.UpdateChartOptions(1, godocx.ChartOptionsPatch{
+		ValueAxis:    &godocx.AxisPatch{Min: &minimum, Max: &maximum, NumberFormat: "0%"},
+		Legend:       &godocx.LegendPatch{Show: ptrBool(true), Position: "b"},
+		DataLabels:   &godocx.DataLabelOptions{ShowValue: true},
+		Grouping:     godocx.BarGroupingStacked,
+		GapWidth:     &gap,
+		SeriesColors: map[string]string{"Costs": "#ff0000"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChartOptions failed: %v", err)
+	}
+
+	chart := readChartPart(t, u, 1)
+	val := axisSection(chart, "c:valAx")
+	for _, want := range []string{
+		`<c:scaling><c:orientation val="minMax"/><c:max val="1"/><c:min val="0"/></c:scaling>`,
+		`<c:numFmt formatCode="0%" sourceLinked="0"/>`,
+	} {
+		if !strings.Contains(val, want) {
+			t.Errorf("expected %s in value axis", want)
+		}
+	}
+	if strings.Count(val, "<c:numFmt") != 1 {
+		t.Error("expected the number format to be replaced")
+	}
+
+	for _, want := range []string{
+		`<c:legendPos val="b"/>`,
+		`<c:grouping val="stacked"/>`,
+		`<c:showVal val="1"/>`,
+		`<c:gapWidth val="60"/><c:overlap val="100"/>`,
+		`<a:srgbClr val="FF0000"/>`,
+		`<c:v>0.6</c:v>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+	if strings.Contains(chart, "4472C4") {
+		t.Error("expected the series color to be replaced")
+	}
+
+	// Back to clustered bars, without a legend
+	err = u.UpdateChartOptions(1, godocx.ChartOptionsPatch{
+		Grouping: godocx.BarGroupingClustered,
+		Legend:   &godocx.LegendPatch{Show: ptrBool(false)},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChartOptions failed: %v", err)
+	}
+	chart = readChartPart(t, u, 1)
+	if !strings.Contains(chart, `<c:gapWidth val="60"/><c:overlap val="0"/>`) || strings.Contains(chart, "<c:legend>") {
+		t.Error("expected unstacked bars without a legend")
+	}
+}
+
+func TestUpdateChartOptionsStyledChart(t *testing.T) {
+	u := newStyledChartUpdater(t)
+
+	err := u.UpdateChartOptions(1, godocx.ChartOptionsPatch{
+		Legend:       &godocx.LegendPatch{Show: ptrBool(true)},
+		DataLabels:   &godocx.DataLabelOptions{ShowCategoryName: true},
+		SeriesColors: map[string]string{"Old B": "00B050"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChartOptions failed: %v", err)
+	}
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		// Series-level labels give way to the new plot-level labels
+		`</c:ser><c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="1"/>`,
+		// The line keeps its width; the marker gets a fill and an outline
+		`<c:spPr><a:ln w="19050"><a:solidFill><a:srgbClr val="00B050"/></a:solidFill></a:ln></c:spPr>`,
+		`<c:marker><c:symbol val="triangle"/><c:spPr><a:solidFill><a:srgbClr val="00B050"/></a:solidFill><a:ln><a:solidFill><a:srgbClr val="00B050"/></a:solidFill></a:ln></c:spPr></c:marker>`,
+		`<a:prstDash val="dash"/>`,
+		`</c:plotArea><c:legend><c:legendPos val="r"/>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+	if strings.Count(chart, "<c:dLbls>") != 1 {
+		t.Error("expected the series data labels to be removed")
+	}
+}
+
+func TestUpdateChartOptionsKeepsFormatting(t *testing.T) {
+	u := newStyledChartUpdater(t)
+
+	// A dashed line keeps its dash when recolored
+	err := u.UpdateChartOptions(1, godocx.ChartOptionsPatch{
+		SeriesColors: map[string]string{"Old A": "7030A0"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChartOptions failed: %v", err)
+	}
+	chart := readChartPart(t, u, 1)
+	want := `<c:spPr><a:ln w="38100"><a:solidFill><a:srgbClr val="7030A0"/></a:solidFill><a:prstDash val="dash"/></a:ln></c:spPr>`
+	if !strings.Contains(chart, want) {
+		t.Errorf("expected %s in chart", want)
+	}
+
+	// A position alone moves the legend without hiding or adding it
+	err = u.UpdateChartOptions(1, godocx.ChartOptionsPatch{Legend: &godocx.LegendPatch{Position: "b"}})
+	if err != nil {
+		t.Fatalf("UpdateChartOptions failed: %v", err)
+	}
+	if strings.Contains(readChartPart(t, u, 1), "<c:legend>") {
+		t.Error("expected no legend to be added")
+	}
+	err = u.UpdateChartOptions(1, godocx.ChartOptionsPatch{Legend: &godocx.LegendPatch{Show: ptrBool(true)}})
+	if err != nil {
+		t.Fatalf("UpdateChartOptions failed: %v", err)
+	}
+	err = u.UpdateChartOptions(1, godocx.ChartOptionsPatch{Legend: &godocx.LegendPatch{Position: "b"}})
+	if err != nil {
+		t.Fatalf("UpdateChartOptions failed: %v", err)
+	}
+	if chart := readChartPart(t, u, 1); !strings.Contains(chart, `<c:legend><c:legendPos val="b"/>`) {
+		t.Error("expected the legend to move to the bottom")
+	}
+}
+
+func TestUpdateChartOptionsErrors(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindPie,
+		Categories: []string{"A", "B"},
+		Series:     []godocx.SeriesOptions{{Name: "Share", Values: []float64{60, 40}}},
+	})
+
+	one, two, wide := 1.0, 2.0, 600
+	tests := []struct {
+		name  string
+		index int
+		patch godocx.ChartOptionsPatch
+	}{
+		{"chart index", 0, godocx.ChartOptionsPatch{}},
+		{"missing chart", 2, godocx.ChartOptionsPatch{}},
+		{"axis bounds", 1, godocx.ChartOptionsPatch{ValueAxis: &godocx.AxisPatch{Min: &two, Max: &one}}},
+		{"no axes", 1, godocx.ChartOptionsPatch{ValueAxis: &godocx.AxisPatch{Max: &two}}},
+		{"legend position", 1, godocx.ChartOptionsPatch{Legend: &godocx.LegendPatch{Position: "x"}}},
+		{"gap width", 1, godocx.ChartOptionsPatch{GapWidth: &wide}},
+		{"color", 1, godocx.ChartOptionsPatch{SeriesColors: map[string]string{"Share": "red"}}},
+		{"unknown series", 1, godocx.ChartOptionsPatch{SeriesColors: map[string]string{"Other": "FF0000"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := u.UpdateChartOptions(tt.index, tt.patch); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
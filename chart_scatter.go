@@ -0,0 +1,277 @@
+package godocx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// isXYChartKind reports whether a chart type plots numeric X values against
+// Y values (scatter and bubble charts) instead of values by category
+func isXYChartKind(kind ChartKind) bool {
+	return kind == ChartKindScatter || kind == ChartKindBubble
+}
+
+// validateXYSeries validates the X values and bubble sizes of a scatter or
+// bubble series
+func validateXYSeries(i int, kind ChartKind, values, xValues, bubbleSizes []float64) error {
+	if len(values) == 0 {
+		return fmt.Errorf("series[%d] values cannot be empty", i)
+	}
+	if len(xValues) != len(values) {
+		return fmt.Errorf("series[%d] X values length (%d) must match values length (%d)",
+			i, len(xValues), len(values))
+	}
+	if kind == ChartKindBubble && len(bubbleSizes) != len(values) {
+		return fmt.Errorf("series[%d] bubble sizes length (%d) must match values length (%d)",
+			i, len(bubbleSizes), len(values))
+	}
+	return nil
+}
+
+// validateXYChartData validates the data of a scatter or bubble chart
+// update: each series needs X values, and bubble sizes for bubble charts
+func validateXYChartData(data ChartData, kind ChartKind) error {
+	if len(data.Series) == 0 {
+		return errors.New("series cannot be empty")
+	}
+	for i, s := range data.Series {
+		if strings.TrimSpace(s.Name) == "" {
+			return fmt.Errorf("series[%d] name cannot be empty", i)
+		}
+		if err := validateXYSeries(i, kind, s.Values, s.XValues, s.BubbleSizes); err != nil {
+			return err
+		}
+		if err := validateSeriesValues(i, s.Values, s.XValues, s.BubbleSizes); err != nil {
+			return err
+		}
+	}
+	return validateDisplayBlanksAs(data.DisplayBlanksAs)
+}
+
+// xyChartKind returns ChartKindScatter or ChartKindBubble when the plots of
+// a chart are scatter or bubble plots, or "" for category charts. Charts
+// combining both are not supported by UpdateChart.
+func xyChartKind(content string) (ChartKind, error) {
+	groups, err := findPlotGroups(content, detectNamespacePrefix(content))
+	if err != nil {
+		return "", err
+	}
+
+	var kind ChartKind
+	categoryPlots := false
+	for _, group := range groups {
+		switch groupKind := ChartKind(group.chartType); {
+		case !isXYChartKind(groupKind):
+			categoryPlots = true
+		case kind != "" && kind != groupKind:
+			return "", fmt.Errorf("charts combining scatter and bubble plots cannot be updated")
+		default:
+			kind = groupKind
+		}
+	}
+	if kind != "" && categoryPlots {
+		return "", fmt.Errorf("charts combining %s plots with category plots cannot be updated", kind)
+	}
+	return kind, nil
+}
+
+// seriesColumns returns the 1-based worksheet columns of the X (or category),
+// Y and bubble size values of a series. Category charts share the given
+// number of category columns from column A; scatter series each have an X
+// and a Y column and bubble series an additional size column. size is 0
+// when the series has none.
+func seriesColumns(kind ChartKind, index, categoryColumns int) (x, y, size int) {
+	switch kind {
+	case ChartKindScatter:
+		x = index*2 + 1
+		return x, x + 1, 0
+	case ChartKindBubble:
+		x = index*3 + 1
+		return x, x + 1, x + 2
+	default:
+		return 1, categoryColumns + index + 1, 0
+	}
+}
+
+// sheetColumnRange returns the Sheet1 formula of the data rows of a column
+func sheetColumnRange(col, rows int) string {
+	letter := columnLetter(col)
+	return fmt.Sprintf("Sheet1!$%s$2:$%s$%d", letter, letter, rows+1)
+}
+
+// extendedFromChartOptions converts ChartOptions to ExtendedChartOptions for
+// the chart types drawn by the extended chart generator
+func extendedFromChartOptions(opts ChartOptions) ExtendedChartOptions {
+	series := make([]SeriesOptions, len(opts.Series))
+	for i, s := range opts.Series {
+		series[i] = SeriesOptions{
+			Name:        s.Name,
+			Values:      s.Values,
+			Color:       s.Color,
+			XValues:     s.XValues,
+			BubbleSizes: s.BubbleSizes,
+		}
+	}
+
+	return ExtendedChartOptions{
+		Position:        opts.Position,
+		Target:          opts.Target,
+		Anchor:          opts.Anchor,
+		Occurrence:      opts.Occurrence,
+		AnchorRegex:     opts.AnchorRegex,
+		ChartKind:       opts.ChartKind,
+		Title:           opts.Title,
+		Categories:      opts.Categories,
+		CategoryLevels:  opts.CategoryLevels,
+		CategoryDates:   opts.CategoryDates,
+		CategoryNumbers: opts.CategoryNumbers,
+		CategoryFormat:  opts.CategoryFormat,
+		Series:          series,
+		CategoryAxis:    &AxisOptions{Title: opts.CategoryAxisTitle},
+		ValueAxis:       &AxisOptions{Title: opts.ValueAxisTitle},
+		Legend:          &LegendOptions{Show: opts.ShowLegend, Position: opts.LegendPosition},
+		Properties:      &ChartProperties{DisplayBlanksAs: opts.DisplayBlanksAs},
+		Width:           opts.Width,
+		Height:          opts.Height,
+		Caption:         opts.Caption,
+	}
+}
+
+// generateExtendedScatterChartXML generates scatter chart XML with extended options
+func generateExtendedScatterChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:scatterChart>`)
+	buf.WriteString(fmt.Sprintf(`<c:scatterStyle val="%s"/>`, opts.ScatterChartOptions.Style))
+	buf.WriteString(`<c:varyColors val="0"/>`)
+
+	// Series
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
+	}
+
+	// Data labels
+	if opts.DataLabels != nil {
+		buf.WriteString(generateDataLabelsXML(opts.DataLabels))
+	} else {
+		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="0"/><c:showBubbleSize val="0"/></c:dLbls>`)
+	}
+
+	buf.WriteString(group.axisIDsXML())
+	buf.WriteString(`</c:scatterChart>`)
+
+	return buf.String()
+}
+
+// generateExtendedBubbleChartXML generates bubble chart XML with extended options
+func generateExtendedBubbleChartXML(opts ExtendedChartOptions, group chartPlotGroup) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:bubbleChart>`)
+	buf.WriteString(`<c:varyColors val="0"/>`)
+
+	// Series
+	for _, i := range group.series {
+		buf.WriteString(generateSeriesXML(i, opts.Series[i], group.kind, opts))
+	}
+
+	// Data labels
+	if opts.DataLabels != nil {
+		buf.WriteString(generateDataLabelsXML(opts.DataLabels))
+	} else {
+		buf.WriteString(`<c:dLbls><c:showLegendKey val="0"/><c:showVal val="0"/><c:showCatName val="0"/><c:showSerName val="0"/><c:showPercent val="0"/><c:showBubbleSize val="0"/></c:dLbls>`)
+	}
+
+	// Bubble size scale and negative bubbles
+	buf.WriteString(fmt.Sprintf(`<c:bubbleScale val="%d"/>`, opts.BubbleChartOptions.Scale))
+	buf.WriteString(fmt.Sprintf(`<c:showNegBubbles val="%d"/>`, boolToInt(opts.BubbleChartOptions.ShowNegative)))
+
+	buf.WriteString(group.axisIDsXML())
+	buf.WriteString(`</c:bubbleChart>`)
+
+	return buf.String()
+}
+
+// scatterSeriesShapeXML returns the shape properties and marker of a
+// scatter series drawn in the given style
+func scatterSeriesShapeXML(color string, style ScatterStyle) string {
+	var buf bytes.Buffer
+
+	fill := ""
+	if color != "" {
+		fill = fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color)
+	}
+
+	// Line: hidden for marker-only series
+	if style == ScatterStyleMarker {
+		buf.WriteString(`<c:spPr><a:ln w="19050"><a:noFill/></a:ln></c:spPr>`)
+	} else if fill != "" {
+		buf.WriteString(`<c:spPr><a:ln w="19050">` + fill + `</a:ln></c:spPr>`)
+	}
+
+	// Marker
+	switch style {
+	case ScatterStyleLine, ScatterStyleSmooth:
+		buf.WriteString(`<c:marker><c:symbol val="none"/></c:marker>`)
+	default:
+		buf.WriteString(`<c:marker><c:symbol val="circle"/><c:size val="5"/>`)
+		if fill != "" {
+			buf.WriteString(`<c:spPr>` + fill + `<a:ln>` + fill + `</a:ln></c:spPr>`)
+		}
+		buf.WriteString(`</c:marker>`)
+	}
+
+	return buf.String()
+}
+
+// generateXYSheetXML creates the worksheet of a scatter or bubble chart:
+// each series has an X column, a Y column headed with the series name and,
+// for bubble charts, a size column
+func generateXYSheetXML(opts ChartOptions) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheetData>`)
+
+	// Header row with column names
+	buf.WriteString(`<row r="1">`)
+	rows := 0
+	for i, series := range opts.Series {
+		x, y, size := seriesColumns(opts.ChartKind, i, 1)
+		buf.WriteString(fmt.Sprintf(`<c r="%s1" t="str"><v>X</v></c>`, columnLetter(x)))
+		buf.WriteString(fmt.Sprintf(`<c r="%s1" t="str"><v>%s</v></c>`, columnLetter(y), xmlEscape(series.Name)))
+		if size != 0 {
+			buf.WriteString(fmt.Sprintf(`<c r="%s1" t="str"><v>Size</v></c>`, columnLetter(size)))
+		}
+		rows = max(rows, len(series.Values))
+	}
+	buf.WriteString(`</row>`)
+
+	// Data rows, up to the longest series
+	for r := 0; r < rows; r++ {
+		rowNum := r + 2
+		buf.WriteString(fmt.Sprintf(`<row r="%d">`, rowNum))
+		for i, series := range opts.Series {
+			if r >= len(series.Values) {
+				continue
+			}
+			x, y, size := seriesColumns(opts.ChartKind, i, 1)
+			buf.WriteString(fmt.Sprintf(`<c r="%s%d"><v>%g</v></c>`, columnLetter(x), rowNum, series.XValues[r]))
+			if !IsMissing(series.Values[r]) {
+				buf.WriteString(fmt.Sprintf(`<c r="%s%d"><v>%g</v></c>`, columnLetter(y), rowNum, series.Values[r]))
+			}
+			if size != 0 {
+				buf.WriteString(fmt.Sprintf(`<c r="%s%d"><v>%g</v></c>`, columnLetter(size), rowNum, series.BubbleSizes[r]))
+			}
+		}
+		buf.WriteString(`</row>`)
+	}
+
+	buf.WriteString(`</sheetData>
+</worksheet>`)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,304 @@
+package godocx_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+// readChartSheet returns the worksheet of the embedded workbook of a chart
+func readChartSheet(t *testing.T, u *godocx.Updater, workbook string) string {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join(u.TempDir(), "word", "embeddings", workbook))
+	if err != nil {
+		t.Fatalf("read workbook: %v", err)
+	}
+	return readWorkbookEntry(t, raw, "xl/worksheets/sheet1.xml")
+}
+
+func TestInsertScatterChart(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertChartExtended(godocx.ExtendedChartOptions{
+		Position:  godocx.PositionEnd,
+		ChartKind: godocx.ChartKindScatter,
+		Title:     "Height vs Weight",
+		Series: []godocx.SeriesOptions{
+			{Name: "Men", XValues: []float64{170, 180, 190}, Values: []float64{70, 80, 95}, Color: "1F77B4"},
+			{Name: "Women", XValues: []float64{160, 165}, Values: []float64{55, 60}},
+		},
+		CategoryAxis:        &godocx.AxisOptions{Title: "Height (cm)"},
+		ScatterChartOptions: &godocx.ScatterChartOptions{Style: godocx.ScatterStyleSmoothMarker},
+	})
+	if err != nil {
+		t.Fatalf("InsertChartExtended failed: %v", err)
+	}
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:scatterStyle val="smoothMarker"/>`,
+		`<c:xVal><c:numRef><c:f>Sheet1!$A$2:$A$4</c:f>`,
+		`<c:yVal><c:numRef><c:f>Sheet1!$B$2:$B$4</c:f>`,
+		`<c:tx><c:strRef><c:f>Sheet1!$B$1</c:f>`,
+		`<c:xVal><c:numRef><c:f>Sheet1!$C$2:$C$3</c:f>`,
+		`<c:yVal><c:numRef><c:f>Sheet1!$D$2:$D$3</c:f>`,
+		`<c:smooth val="1"/>`,
+		`<c:marker><c:symbol val="circle"/><c:size val="5"/>`,
+		`<a:srgbClr val="1F77B4"/>`,
+		`<c:crossBetween val="midCat"/>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+
+	// Both axes are value axes; the X axis is at the bottom
+	if strings.Count(chart, "<c:valAx>") != 2 || strings.Contains(chart, "<c:catAx>") {
+		t.Fatal("expected two value axes and no category axis")
+	}
+	xAxis := chart[strings.Index(chart, "<c:valAx>"):strings.Index(chart, "</c:valAx>")]
+	if !strings.Contains(xAxis, `<c:axPos val="b"/>`) || !strings.Contains(xAxis, "Height (cm)") {
+		t.Error("expected the X axis at the bottom with its title")
+	}
+
+	// Series elements are in schema order
+	series := chart[strings.Index(chart, "<c:ser>"):strings.Index(chart, "</c:ser>")]
+	order := []string{"<c:tx>", "<c:spPr>", "<c:marker>", "<c:xVal>", "<c:yVal>", "<c:smooth"}
+	for i := 1; i < len(order); i++ {
+		if strings.Index(series, order[i-1]) > strings.Index(series, order[i]) {
+			t.Errorf("expected %s before %s", order[i-1], order[i])
+		}
+	}
+
+	sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+	for _, want := range []string{
+		`<c r="B1" t="str"><v>Men</v></c>`,
+		`<c r="D1" t="str"><v>Women</v></c>`,
+		`<c r="A4"><v>190</v></c>`,
+		`<c r="B4"><v>95</v></c>`,
+		`<c r="C3"><v>165</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("expected %s in worksheet", want)
+		}
+	}
+	if strings.Contains(sheet, `<c r="C4">`) {
+		t.Error("expected no cells past the end of the shorter series")
+	}
+}
+
+func TestInsertBubbleChart(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	// InsertChart hands bubble charts to the extended generator
+	err = u.InsertChart(godocx.ChartOptions{
+		Position:  godocx.PositionEnd,
+		ChartKind: godocx.ChartKindBubble,
+		Series: []godocx.SeriesData{
+			{Name: "Markets", XValues: []float64{1, 2}, Values: []float64{10, 20}, BubbleSizes: []float64{5, 15}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertChart failed: %v", err)
+	}
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:bubbleChart>`,
+		`<c:bubbleSize><c:numRef><c:f>Sheet1!$C$2:$C$3</c:f>`,
+		`<c:bubble3D val="0"/>`,
+		`<c:bubbleScale val="100"/><c:showNegBubbles val="0"/>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+
+	sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+	if !strings.Contains(sheet, `<c r="C1" t="str"><v>Size</v></c>`) || !strings.Contains(sheet, `<c r="C3"><v>15</v></c>`) {
+		t.Error("expected bubble sizes in column C of the worksheet")
+	}
+}
+
+func TestInsertScatterChartValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	tests := []struct {
+		name string
+		opts godocx.ExtendedChartOptions
+	}{
+		{"missing X values", godocx.ExtendedChartOptions{
+			ChartKind: godocx.ChartKindScatter,
+			Series:    []godocx.SeriesOptions{{Name: "A", Values: []float64{1, 2}, XValues: []float64{1}}},
+		}},
+		{"missing bubble sizes", godocx.ExtendedChartOptions{
+			ChartKind: godocx.ChartKindBubble,
+			Series:    []godocx.SeriesOptions{{Name: "A", Values: []float64{1}, XValues: []float64{1}}},
+		}},
+		{"scatter with line", godocx.ExtendedChartOptions{
+			ChartKind:  godocx.ChartKindScatter,
+			Categories: []string{"X"},
+			Series: []godocx.SeriesOptions{
+				{Name: "A", Values: []float64{1}, XValues: []float64{1}},
+				{Name: "B", Values: []float64{1}, XValues: []float64{1}, ChartKind: godocx.ChartKindLine},
+			},
+		}},
+		{"invalid style", godocx.ExtendedChartOptions{
+			ChartKind:           godocx.ChartKindScatter,
+			Series:              []godocx.SeriesOptions{{Name: "A", Values: []float64{1}, XValues: []float64{1}}},
+			ScatterChartOptions: &godocx.ScatterChartOptions{Style: "dotted"},
+		}},
+		{"invalid bubble scale", godocx.ExtendedChartOptions{
+			ChartKind:          godocx.ChartKindBubble,
+			Series:             []godocx.SeriesOptions{{Name: "A", Values: []float64{1}, XValues: []float64{1}, BubbleSizes: []float64{1}}},
+			BubbleChartOptions: &godocx.BubbleChartOptions{Scale: 400},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Position = godocx.PositionEnd
+			if err := u.InsertChartExtended(tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestUpdateScatterChart(t *testing.T) {
+	insert := func(t *testing.T) *godocx.Updater {
+		u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		t.Cleanup(func() { _ = u.Cleanup() })
+
+		err = u.InsertChartExtended(godocx.ExtendedChartOptions{
+			Position:  godocx.PositionEnd,
+			ChartKind: godocx.ChartKindScatter,
+			Series: []godocx.SeriesOptions{
+				{Name: "Men", XValues: []float64{170, 180}, Values: []float64{70, 80}, Color: "1F77B4"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("InsertChartExtended failed: %v", err)
+		}
+		return u
+	}
+	data := godocx.ChartData{
+		Series: []godocx.SeriesData{
+			{Name: "Men", XValues: []float64{170, 180, 190}, Values: []float64{70, 80, 95}},
+			{Name: "Women", XValues: []float64{160, 165}, Values: []float64{55, 60}},
+		},
+	}
+
+	t.Run("preserve formatting", func(t *testing.T) {
+		u := insert(t)
+		data := data
+		data.PreserveFormatting = true
+		if err := u.UpdateChart(1, data); err != nil {
+			t.Fatalf("UpdateChart failed: %v", err)
+		}
+
+		chart := readChartPart(t, u, 1)
+		for _, want := range []string{
+			`<c:xVal><c:numRef><c:f>Sheet1!$A$2:$A$4</c:f>`,
+			`<c:yVal><c:numRef><c:f>Sheet1!$B$2:$B$4</c:f>`,
+			`<c:tx><c:strRef><c:f>Sheet1!$D$1</c:f>`,
+			`<c:xVal><c:numRef><c:f>Sheet1!$C$2:$C$3</c:f>`,
+			`<c:pt idx="2"><c:v>190</c:v></c:pt>`,
+			`<a:srgbClr val="1F77B4"/>`,
+		} {
+			if !strings.Contains(chart, want) {
+				t.Errorf("expected %s in chart", want)
+			}
+		}
+		if strings.Contains(chart, "<c:strRef><c:f>Sheet1!$A$2") {
+			t.Error("expected numeric X values, not a category reference")
+		}
+
+		sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+		for _, want := range []string{`<c r="A4"><v>190</v></c>`, `<c r="B4"><v>95</v></c>`, `<c r="C3"><v>165</v></c>`, `<c r="D2"><v>55</v></c>`} {
+			if !strings.Contains(sheet, want) {
+				t.Errorf("expected %s in worksheet", want)
+			}
+		}
+	})
+
+	t.Run("rebuild", func(t *testing.T) {
+		u := insert(t)
+		if err := u.UpdateChart(1, data); err != nil {
+			t.Fatalf("UpdateChart failed: %v", err)
+		}
+
+		chart := readChartPart(t, u, 1)
+		if strings.Count(chart, "<c:xVal><c:numRef>") != 2 || strings.Count(chart, "<c:yVal><c:numRef>") != 2 {
+			t.Error("expected X and Y values for both series")
+		}
+		if strings.Contains(chart, "<c:cat>") {
+			t.Error("expected no categories in a scatter chart")
+		}
+	})
+
+	t.Run("bubble", func(t *testing.T) {
+		u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		t.Cleanup(func() { _ = u.Cleanup() })
+
+		err = u.InsertChart(godocx.ChartOptions{
+			Position:  godocx.PositionEnd,
+			ChartKind: godocx.ChartKindBubble,
+			Series:    []godocx.SeriesData{{Name: "Markets", XValues: []float64{1}, Values: []float64{10}, BubbleSizes: []float64{5}}},
+		})
+		if err != nil {
+			t.Fatalf("InsertChart failed: %v", err)
+		}
+		err = u.UpdateChart(1, godocx.ChartData{
+			PreserveFormatting: true,
+			Series:             []godocx.SeriesData{{Name: "Markets", XValues: []float64{1, 2}, Values: []float64{10, 20}, BubbleSizes: []float64{5, 15}}},
+		})
+		if err != nil {
+			t.Fatalf("UpdateChart failed: %v", err)
+		}
+
+		chart := readChartPart(t, u, 1)
+		if !strings.Contains(chart, `<c:bubbleSize><c:numRef><c:f>Sheet1!$C$2:$C$3</c:f>`) {
+			t.Error("expected bubble sizes from column C")
+		}
+		sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+		if !strings.Contains(sheet, `<c r="C1" t="inlineStr"><is><t>Size</t></is></c>`) || !strings.Contains(sheet, `<c r="C3"><v>15</v></c>`) {
+			t.Errorf("expected bubble sizes in column C of the worksheet: %s", sheet)
+		}
+		if err := u.UpdateChart(1, godocx.ChartData{Series: []godocx.SeriesData{{Name: "Markets", XValues: []float64{1}, Values: []float64{10}}}}); err == nil {
+			t.Error("expected error for a bubble series without sizes")
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		u := insert(t)
+		err := u.UpdateChart(1, godocx.ChartData{
+			Categories: []string{"A", "B"},
+			Series:     []godocx.SeriesData{{Name: "Men", Values: []float64{1, 2}}},
+		})
+		if err == nil {
+			t.Error("expected error for a series without X values")
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// fontRunPropertiesXML returns a DrawingML run properties element (e.g.
+// a:defRPr) for a chart font, with any extra attributes
+func fontRunPropertiesXML(tag, attrs string, font *ChartFont) string {
+	var attrList []string
+	if attrs != "" {
+		attrList = append(attrList, attrs)
+	}
+	var children bytes.Buffer
+	if font != nil {
+		if font.Size > 0 {
+			attrList = append(attrList, fmt.Sprintf(`sz="%d"`, int(font.Size*100+0.5)))
+		}
+		if font.Bold {
+			attrList = append(attrList, `b="1"`)
+		}
+		if color := normalizeHexColor(font.Color); color != "" {
+			children.WriteString(fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color))
+		}
+		if font.Family != "" {
+			family := xmlEscape(font.Family)
+			children.WriteString(`<a:latin typeface="` + family + `"/><a:cs typeface="` + family + `"/>`)
+		}
+	}
+
+	open := "<" + tag
+	if len(attrList) > 0 {
+		open += " " + strings.Join(attrList, " ")
+	}
+	if children.Len() == 0 {
+		return open + "/>"
+	}
+	return open + ">" + children.String() + "</" + tag + ">"
+}
+
+// generateTextPropertiesXML generates the text properties (c:txPr) of a
+// chart element in the given font, or nothing for the default font
+func generateTextPropertiesXML(font *ChartFont) string {
+	if font == nil {
+		return ""
+	}
+	return `<c:txPr><a:bodyPr/><a:lstStyle/><a:p><a:pPr>` + fontRunPropertiesXML("a:defRPr", "", font) +
+		`</a:pPr><a:endParaRPr lang="en-US"/></a:p></c:txPr>`
+}
+
+// generateAreaStyleXML generates the shape properties of the chart or plot
+// area, or nothing for the default style
+func generateAreaStyleXML(style *ChartAreaStyle) string {
+	if style == nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`<c:spPr>`)
+	if style.NoFill {
+		buf.WriteString(`<a:noFill/>`)
+	} else if color := normalizeHexColor(style.FillColor); color != "" {
+		buf.WriteString(fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color))
+	}
+
+	// Border
+	switch {
+	case style.NoBorder:
+		buf.WriteString(`<a:ln><a:noFill/></a:ln>`)
+	case style.BorderColor != "" || style.BorderWidth > 0:
+		buf.WriteString(`<a:ln`)
+		if style.BorderWidth > 0 {
+			buf.WriteString(fmt.Sprintf(` w="%d"`, int(style.BorderWidth*12700)))
+		}
+		buf.WriteString(`>`)
+		if color := normalizeHexColor(style.BorderColor); color != "" {
+			buf.WriteString(fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color))
+		}
+		buf.WriteString(`</a:ln>`)
+	}
+	buf.WriteString(`</c:spPr>`)
+
+	return buf.String()
+}
+
+// generateDataPointsXML generates the data point overrides of a series:
+// point colors and, for doughnut charts, exploded points, in point order
+func generateDataPointsXML(kind ChartKind, colors map[int]string, explosions map[int]int) string {
+	points := slices.Sorted(maps.Keys(colors))
+	for point := range explosions {
+		if _, ok := colors[point]; !ok {
+			points = append(points, point)
+		}
+	}
+	slices.Sort(points)
+
+	// Points of line series color the line segment and marker
+	lineKind := kind == ChartKindLine || kind == ChartKindRadar || kind == ChartKindScatter
+
+	var buf bytes.Buffer
+	for _, point := range points {
+		var fill string
+		if color := normalizeHexColor(colors[point]); color != "" {
+			fill = fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color)
+		}
+
+		buf.WriteString(fmt.Sprintf(`<c:dPt><c:idx val="%d"/>`, point))
+		if kind == ChartKindColumn || kind == ChartKindColumn3D {
+			buf.WriteString(`<c:invertIfNegative val="0"/>`)
+		}
+		if lineKind && fill != "" {
+			buf.WriteString(`<c:marker><c:spPr>` + fill + `</c:spPr></c:marker>`)
+		}
+		buf.WriteString(`<c:bubble3D val="0"/>`)
+		if explosion, ok := explosions[point]; ok {
+			buf.WriteString(fmt.Sprintf(`<c:explosion val="%d"/>`, explosion))
+		}
+		if fill != "" {
+			if lineKind {
+				fill = `<a:ln w="28575" cap="rnd">` + fill + `</a:ln>`
+			}
+			buf.WriteString(`<c:spPr>` + fill + `</c:spPr>`)
+		}
+		buf.WriteString(`</c:dPt>`)
+	}
+	return buf.String()
+}
+
+// applyChartPalette returns the series with palette colors given to series
+// without their own color, or to the points of pie and doughnut series
+// without their own point color. The caller's series are not modified.
+func applyChartPalette(series []SeriesOptions, opts ExtendedChartOptions) []SeriesOptions {
+	series = slices.Clone(series)
+	palette := opts.Palette
+	for i := range series {
+		s := &series[i]
+		if isPieChartKind(seriesChartKind(*s, opts)) {
+			colors := maps.Clone(s.PointColors)
+			if colors == nil {
+				colors = make(map[int]string)
+			}
+			for point := range s.Values {
+				if _, ok := colors[point]; !ok {
+					colors[point] = palette[point%len(palette)]
+				}
+			}
+			s.PointColors = colors
+		} else if s.Color == "" {
+			s.Color = palette[i%len(palette)]
+		}
+	}
+	return series
+}
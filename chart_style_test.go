@@ -0,0 +1,137 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func TestInsertChartPointColors(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		Categories: []string{"Jan", "Feb", "Mar"},
+		Series: []godocx.SeriesOptions{
+			{Name: "Sales", Values: []float64{10, 12, 15}, Color: "A5A5A5", PointColors: map[int]string{2: "#C00000"}},
+			{Name: "Trend", Values: []float64{9, 11, 14}, ChartKind: godocx.ChartKindLine, PointColors: map[int]string{1: "00B050"}},
+		},
+	})
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:dPt><c:idx val="2"/><c:invertIfNegative val="0"/><c:bubble3D val="0"/><c:spPr><a:solidFill><a:srgbClr val="C00000"/></a:solidFill></c:spPr></c:dPt>`,
+		`<c:dPt><c:idx val="1"/><c:marker><c:spPr><a:solidFill><a:srgbClr val="00B050"/></a:solidFill></c:spPr></c:marker><c:bubble3D val="0"/>` +
+			`<c:spPr><a:ln w="28575" cap="rnd"><a:solidFill><a:srgbClr val="00B050"/></a:solidFill></a:ln></c:spPr></c:dPt>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+
+	// Data points come before the categories
+	series := strings.Split(chart, "<c:ser>")
+	if strings.Index(series[1], "<c:dPt>") > strings.Index(series[1], "<c:cat>") {
+		t.Error("expected the data points before the categories")
+	}
+}
+
+func TestInsertChartPalette(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		Categories: []string{"A", "B"},
+		Series: []godocx.SeriesOptions{
+			{Name: "S1", Values: []float64{1, 2}},
+			{Name: "S2", Values: []float64{3, 4}, Color: "123456"},
+			{Name: "S3", Values: []float64{5, 6}},
+		},
+		Palette: godocx.PaletteGrayscale,
+	})
+
+	series := strings.Split(readChartPart(t, u, 1), "<c:ser>")
+	for i, want := range []string{"262626", "123456", "7F7F7F"} {
+		if !strings.Contains(series[i+1], `<a:srgbClr val="`+want+`"/>`) {
+			t.Errorf("series %d: expected color %s", i, want)
+		}
+	}
+
+	// Pie charts color each point, keeping the caller's point colors
+	pieSeries := []godocx.SeriesOptions{{Name: "Share", Values: []float64{50, 30, 20}, PointColors: map[int]string{1: "FF0000"}}}
+	u = newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindPie,
+		Categories: []string{"A", "B", "C"},
+		Series:     pieSeries,
+		Palette:    godocx.PalettePastel,
+	})
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:idx val="0"/><c:bubble3D val="0"/><c:spPr><a:solidFill><a:srgbClr val="A1C9F4"/>`,
+		`<c:idx val="1"/><c:bubble3D val="0"/><c:spPr><a:solidFill><a:srgbClr val="FF0000"/>`,
+		`<c:idx val="2"/><c:bubble3D val="0"/><c:spPr><a:solidFill><a:srgbClr val="8DE5A1"/>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in pie chart", want)
+		}
+	}
+	if len(pieSeries[0].PointColors) != 1 {
+		t.Error("expected the caller's point colors to be unchanged")
+	}
+}
+
+func TestInsertChartFonts(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		Title:        "Revenue",
+		TitleFont:    &godocx.ChartFont{Family: "Arial", Size: 14, Bold: true, Color: "1F3864"},
+		Categories:   []string{"A", "B"},
+		Series:       []godocx.SeriesOptions{{Name: "S", Values: []float64{1, 2}}},
+		CategoryAxis: &godocx.AxisOptions{Font: &godocx.ChartFont{Size: 9}},
+		Legend:       &godocx.LegendOptions{Show: true, Position: "b", Font: &godocx.ChartFont{Family: "Calibri"}},
+		DataLabels:   &godocx.DataLabelOptions{ShowValue: true, Font: &godocx.ChartFont{Size: 8, Color: "FFFFFF"}},
+	})
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<a:defRPr sz="1400" b="1"><a:solidFill><a:srgbClr val="1F3864"/></a:solidFill><a:latin typeface="Arial"/><a:cs typeface="Arial"/></a:defRPr>`,
+		`<a:rPr lang="en-US" sz="1400" b="1">`,
+		`<c:legendPos val="b"/><c:layout/><c:overlay val="0"/><c:txPr><a:bodyPr/><a:lstStyle/><a:p><a:pPr><a:defRPr><a:latin typeface="Calibri"/>`,
+		`<a:defRPr sz="800"><a:solidFill><a:srgbClr val="FFFFFF"/></a:solidFill></a:defRPr>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+	if cat := axisSection(chart, "c:catAx"); !strings.Contains(cat, `<c:txPr><a:bodyPr/><a:lstStyle/><a:p><a:pPr><a:defRPr sz="900"/>`) {
+		t.Error("expected the category axis font")
+	}
+}
+
+func TestInsertChartAreaStyles(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		Categories: []string{"A", "B"},
+		Series:     []godocx.SeriesOptions{{Name: "S", Values: []float64{1, 2}}},
+		ChartArea:  &godocx.ChartAreaStyle{FillColor: "F2F2F2", BorderColor: "7F7F7F", BorderWidth: 1.5},
+		PlotArea:   &godocx.ChartAreaStyle{NoFill: true, NoBorder: true},
+	})
+
+	chart := readChartPart(t, u, 1)
+	if !strings.Contains(chart, `<c:spPr><a:noFill/><a:ln><a:noFill/></a:ln></c:spPr></c:plotArea>`) {
+		t.Error("expected the plot area style")
+	}
+	if !strings.Contains(chart, `</c:chart><c:spPr><a:solidFill><a:srgbClr val="F2F2F2"/></a:solidFill><a:ln w="19050"><a:solidFill><a:srgbClr val="7F7F7F"/></a:solidFill></a:ln></c:spPr>`) {
+		t.Error("expected the chart area style")
+	}
+}
+
+func TestInsertChartPointColorValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertChartExtended(godocx.ExtendedChartOptions{
+		Position:   godocx.PositionEnd,
+		Categories: []string{"A", "B"},
+		Series:     []godocx.SeriesOptions{{Name: "S", Values: []float64{1, 2}, PointColors: map[int]string{2: "FF0000"}}},
+	})
+	if err == nil {
+		t.Error("expected error for a point color out of range")
+	}
+}
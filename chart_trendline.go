@@ -0,0 +1,192 @@
+package godocx
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"math"
+)
+
+// TargetLineSeries returns a line series drawn at the same value for each
+// of the given number of categories. Added to a column chart, it draws a
+// horizontal target or threshold line.
+func TargetLineSeries(name string, value float64, categories int, color string) SeriesOptions {
+	values := make([]float64, categories)
+	for i := range values {
+		values[i] = value
+	}
+	return SeriesOptions{
+		Name:      name,
+		Values:    values,
+		Color:     color,
+		ChartKind: ChartKindLine,
+	}
+}
+
+// validateSeriesAnalysis validates the trendline and error bars of a series
+func validateSeriesAnalysis(i int, kind ChartKind, series SeriesOptions) error {
+	if series.Trendline == nil && series.ErrorBars == nil {
+		return nil
+	}
+	if isPieChartKind(kind) || kind == ChartKindRadar {
+		return fmt.Errorf("series[%d]: %s series cannot have trendlines or error bars", i, kind)
+	}
+
+	if t := series.Trendline; t != nil {
+		switch t.Type {
+		case "", TrendlineLinear, TrendlineExponential, TrendlineLogarithmic, TrendlinePower:
+		case TrendlinePolynomial:
+			if t.Order != 0 && (t.Order < 2 || t.Order > 6) {
+				return fmt.Errorf("series[%d] trendline: polynomial order must be between 2 and 6", i)
+			}
+		case TrendlineMovingAverage:
+			if t.Period != 0 && (t.Period < 2 || t.Period >= len(series.Values)) {
+				return fmt.Errorf("series[%d] trendline: moving average period must be at least 2 and less than the number of values", i)
+			}
+			if t.Forward != 0 || t.Backward != 0 {
+				return fmt.Errorf("series[%d] trendline: moving averages cannot forecast", i)
+			}
+		default:
+			return fmt.Errorf("series[%d] trendline: type %q is not supported", i, t.Type)
+		}
+		if t.Forward < 0 || t.Backward < 0 {
+			return fmt.Errorf("series[%d] trendline: forecast periods cannot be negative", i)
+		}
+	}
+
+	if e := series.ErrorBars; e != nil {
+		switch e.Direction {
+		case "", ErrorBarBoth, ErrorBarPlus, ErrorBarMinus:
+		default:
+			return fmt.Errorf("series[%d] error bars: direction %q is not supported", i, e.Direction)
+		}
+		switch e.Type {
+		case "", ErrorBarFixed, ErrorBarPercentage, ErrorBarStdDev, ErrorBarStdErr:
+			if math.IsNaN(e.Value) || math.IsInf(e.Value, 0) {
+				return fmt.Errorf("series[%d] error bars: value must be a finite number", i)
+			}
+			if e.Value < 0 {
+				return fmt.Errorf("series[%d] error bars: value cannot be negative", i)
+			}
+		case ErrorBarCustom:
+			if e.Direction != ErrorBarMinus && len(e.Plus) != len(series.Values) {
+				return fmt.Errorf("series[%d] error bars: plus length (%d) must match values length (%d)", i, len(e.Plus), len(series.Values))
+			}
+			if e.Direction != ErrorBarPlus && len(e.Minus) != len(series.Values) {
+				return fmt.Errorf("series[%d] error bars: minus length (%d) must match values length (%d)", i, len(e.Minus), len(series.Values))
+			}
+			for _, amounts := range []struct {
+				name   string
+				values []float64
+			}{{"plus", e.Plus}, {"minus", e.Minus}} {
+				for j, v := range amounts.values {
+					if math.IsNaN(v) || math.IsInf(v, 0) {
+						return fmt.Errorf("series[%d] error bars: %s amount %d must be a finite number", i, amounts.name, j)
+					}
+				}
+			}
+		default:
+			return fmt.Errorf("series[%d] error bars: type %q is not supported", i, e.Type)
+		}
+	}
+	return nil
+}
+
+// generateTrendlineXML generates the trendline XML of a series
+func generateTrendlineXML(t *TrendlineOptions) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:trendline>`)
+	if t.Name != "" {
+		buf.WriteString(`<c:name>` + xmlEscape(t.Name) + `</c:name>`)
+	}
+	if color := normalizeHexColor(t.Color); color != "" {
+		buf.WriteString(fmt.Sprintf(`<c:spPr><a:ln w="19050" cap="rnd"><a:solidFill><a:srgbClr val="%s"/></a:solidFill><a:prstDash val="sysDot"/></a:ln></c:spPr>`, color))
+	}
+
+	trendType := t.Type
+	if trendType == "" {
+		trendType = TrendlineLinear
+	}
+	buf.WriteString(fmt.Sprintf(`<c:trendlineType val="%s"/>`, trendType))
+	switch trendType {
+	case TrendlinePolynomial:
+		buf.WriteString(fmt.Sprintf(`<c:order val="%d"/>`, max(t.Order, 2)))
+	case TrendlineMovingAverage:
+		buf.WriteString(fmt.Sprintf(`<c:period val="%d"/>`, max(t.Period, 2)))
+	}
+
+	// Forecast
+	if t.Forward > 0 {
+		buf.WriteString(`<c:forward val="` + formatFloat(t.Forward) + `"/>`)
+	}
+	if t.Backward > 0 {
+		buf.WriteString(`<c:backward val="` + formatFloat(t.Backward) + `"/>`)
+	}
+
+	buf.WriteString(fmt.Sprintf(`<c:dispRSqr val="%d"/>`, boolToInt(t.DisplayRSquared)))
+	buf.WriteString(fmt.Sprintf(`<c:dispEq val="%d"/>`, boolToInt(t.DisplayEquation)))
+	if t.DisplayEquation || t.DisplayRSquared {
+		buf.WriteString(`<c:trendlineLbl><c:numFmt formatCode="General" sourceLinked="0"/></c:trendlineLbl>`)
+	}
+	buf.WriteString(`</c:trendline>`)
+
+	return buf.String()
+}
+
+// generateErrorBarsXML generates the error bars XML of a series. Scatter
+// and bubble series need the direction of the bars; they are drawn along Y.
+func generateErrorBarsXML(e *ErrorBarOptions, xy bool) string {
+	var buf bytes.Buffer
+
+	buf.WriteString(`<c:errBars>`)
+	if xy {
+		buf.WriteString(`<c:errDir val="y"/>`)
+	}
+
+	direction := e.Direction
+	if direction == "" {
+		direction = ErrorBarBoth
+	}
+	valType := e.Type
+	if valType == "" {
+		valType = ErrorBarFixed
+	}
+	buf.WriteString(fmt.Sprintf(`<c:errBarType val="%s"/>`, direction))
+	buf.WriteString(fmt.Sprintf(`<c:errValType val="%s"/>`, valType))
+	buf.WriteString(fmt.Sprintf(`<c:noEndCap val="%d"/>`, boolToInt(e.NoEndCap)))
+
+	switch valType {
+	case ErrorBarCustom:
+		if direction != ErrorBarMinus {
+			buf.WriteString(`<c:plus>` + numLitXML(e.Plus) + `</c:plus>`)
+		}
+		if direction != ErrorBarPlus {
+			buf.WriteString(`<c:minus>` + numLitXML(e.Minus) + `</c:minus>`)
+		}
+	case ErrorBarStdErr:
+	case ErrorBarStdDev:
+		buf.WriteString(`<c:val val="` + formatFloat(cmp.Or(e.Value, 1)) + `"/>`)
+	default:
+		buf.WriteString(`<c:val val="` + formatFloat(e.Value) + `"/>`)
+	}
+
+	if color := normalizeHexColor(e.Color); color != "" {
+		buf.WriteString(fmt.Sprintf(`<c:spPr><a:ln w="12700"><a:solidFill><a:srgbClr val="%s"/></a:solidFill></a:ln></c:spPr>`, color))
+	}
+	buf.WriteString(`</c:errBars>`)
+
+	return buf.String()
+}
+
+// numLitXML returns a literal number list
+func numLitXML(values []float64) string {
+	var buf bytes.Buffer
+	buf.WriteString(`<c:numLit><c:formatCode>General</c:formatCode>`)
+	buf.WriteString(fmt.Sprintf(`<c:ptCount val="%d"/>`, len(values)))
+	for i, v := range values {
+		buf.WriteString(fmt.Sprintf(`<c:pt idx="%d"><c:v>%s</c:v></c:pt>`, i, formatFloat(v)))
+	}
+	buf.WriteString(`</c:numLit>`)
+	return buf.String()
+}
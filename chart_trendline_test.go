@@ -0,0 +1,132 @@
+package godocx_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func TestInsertChartTrendlineAndErrorBars(t *testing.T) {
+	categories := []string{"Jan", "Feb", "Mar", "Apr"}
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindLine,
+		Categories: categories,
+		Series: []godocx.SeriesOptions{
+			{
+				Name:   "Sales",
+				Values: []float64{10, 12, 15, 19},
+				Trendline: &godocx.TrendlineOptions{
+					Type:            godocx.TrendlinePolynomial,
+					Order:           3,
+					Forward:         2,
+					DisplayEquation: true,
+					DisplayRSquared: true,
+				},
+			},
+			{
+				Name:   "Thickness",
+				Values: []float64{5, 5.1, 4.9, 5},
+				ErrorBars: &godocx.ErrorBarOptions{
+					Type:  godocx.ErrorBarCustom,
+					Plus:  []float64{0.2, 0.2, 0.3, 0.2},
+					Minus: []float64{0.1, 0.1, 0.1, 0.2},
+				},
+			},
+			godocx.TargetLineSeries("Target", 15, len(categories), "FF0000"),
+		},
+	})
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:trendline><c:trendlineType val="poly"/><c:order val="3"/><c:forward val="2"/><c:dispRSqr val="1"/><c:dispEq val="1"/>`,
+		`<c:errBars><c:errBarType val="both"/><c:errValType val="cust"/><c:noEndCap val="0"/>`,
+		`<c:plus><c:numLit><c:formatCode>General</c:formatCode><c:ptCount val="4"/><c:pt idx="0"><c:v>0.2</c:v></c:pt>`,
+		`<c:minus><c:numLit>`,
+		`<c:v>Target</c:v>`,
+		`<a:ln w="28575" cap="rnd"><a:solidFill><a:srgbClr val="FF0000"/></a:solidFill><a:round/></a:ln>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+
+	// The trendline and error bars come before the categories
+	series := strings.Split(chart, "<c:ser>")
+	if strings.Index(series[1], "<c:trendline>") > strings.Index(series[1], "<c:cat>") ||
+		strings.Index(series[2], "<c:errBars>") > strings.Index(series[2], "<c:cat>") {
+		t.Error("expected the trendline and error bars before the categories")
+	}
+	if strings.Count(series[3], "<c:v>15</c:v>") != 4 {
+		t.Error("expected the target line at 15 for every category")
+	}
+}
+
+func TestInsertChartErrorBarTypes(t *testing.T) {
+	tests := []struct {
+		bars godocx.ErrorBarOptions
+		want string
+	}{
+		{godocx.ErrorBarOptions{Value: 0.5}, `<c:errValType val="fixedVal"/><c:noEndCap val="0"/><c:val val="0.5"/>`},
+		{godocx.ErrorBarOptions{Type: godocx.ErrorBarPercentage, Value: 5, Direction: godocx.ErrorBarPlus}, `<c:errBarType val="plus"/><c:errValType val="percentage"/><c:noEndCap val="0"/><c:val val="5"/>`},
+		{godocx.ErrorBarOptions{Type: godocx.ErrorBarStdDev, NoEndCap: true}, `<c:errValType val="stdDev"/><c:noEndCap val="1"/><c:val val="1"/>`},
+	}
+	for _, tt := range tests {
+		u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+			Categories: []string{"A", "B"},
+			Series:     []godocx.SeriesOptions{{Name: "S", Values: []float64{1, 2}, ErrorBars: &tt.bars}},
+		})
+		if chart := readChartPart(t, u, 1); !strings.Contains(chart, tt.want) {
+			t.Errorf("expected %s in chart", tt.want)
+		}
+	}
+
+	// Scatter error bars are drawn along Y
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind: godocx.ChartKindScatter,
+		Series: []godocx.SeriesOptions{{Name: "S", XValues: []float64{1, 2}, Values: []float64{1, 2},
+			ErrorBars: &godocx.ErrorBarOptions{Value: 1}}},
+	})
+	if chart := readChartPart(t, u, 1); !strings.Contains(chart, `<c:errBars><c:errDir val="y"/>`) {
+		t.Error("expected Y error bars in the scatter chart")
+	}
+}
+
+func TestInsertChartTrendlineValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	tests := []struct {
+		name   string
+		kind   godocx.ChartKind
+		series godocx.SeriesOptions
+	}{
+		{"pie trendline", godocx.ChartKindPie, godocx.SeriesOptions{Trendline: &godocx.TrendlineOptions{}}},
+		{"polynomial order", godocx.ChartKindLine, godocx.SeriesOptions{Trendline: &godocx.TrendlineOptions{Type: godocx.TrendlinePolynomial, Order: 7}}},
+		{"moving average forecast", godocx.ChartKindLine, godocx.SeriesOptions{Trendline: &godocx.TrendlineOptions{Type: godocx.TrendlineMovingAverage, Forward: 1}}},
+		{"custom length", godocx.ChartKindColumn, godocx.SeriesOptions{ErrorBars: &godocx.ErrorBarOptions{Type: godocx.ErrorBarCustom, Plus: []float64{1}, Minus: []float64{1, 2}}}},
+		{"negative value", godocx.ChartKindColumn, godocx.SeriesOptions{ErrorBars: &godocx.ErrorBarOptions{Value: -1}}},
+		{"NaN value", godocx.ChartKindColumn, godocx.SeriesOptions{ErrorBars: &godocx.ErrorBarOptions{Value: math.NaN()}}},
+		{"infinite plus", godocx.ChartKindColumn, godocx.SeriesOptions{ErrorBars: &godocx.ErrorBarOptions{Type: godocx.ErrorBarCustom, Plus: []float64{1, math.Inf(1)}, Minus: []float64{1, 2}}}},
+		{"NaN minus", godocx.ChartKindColumn, godocx.SeriesOptions{ErrorBars: &godocx.ErrorBarOptions{Type: godocx.ErrorBarCustom, Direction: godocx.ErrorBarMinus, Minus: []float64{math.NaN(), 2}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.series.Name = "S"
+			tt.series.Values = []float64{1, 2}
+			err := u.InsertChartExtended(godocx.ExtendedChartOptions{
+				Position:   godocx.PositionEnd,
+				ChartKind:  tt.kind,
+				Categories: []string{"A", "B"},
+				Series:     []godocx.SeriesOptions{tt.series},
+			})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
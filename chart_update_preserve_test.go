@@ -0,0 +1,164 @@
+package godocx_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+// styledChartFixtureXML is a line chart whose series carry designer
+// formatting: fills, markers, point overrides, data labels and a trendline
+const styledChartFixtureXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <c:chart>
+    <c:plotArea>
+      <c:lineChart>
+        <c:grouping val="standard"/>
+        <c:ser>
+          <c:idx val="0"/><c:order val="0"/>
+          <c:tx><c:strRef><c:f>Data!$B$1</c:f><c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>Old A</c:v></c:pt></c:strCache></c:strRef></c:tx>
+          <c:spPr><a:ln w="38100"><a:solidFill><a:srgbClr val="1F77B4"/></a:solidFill><a:prstDash val="dash"/></a:ln></c:spPr>
+          <c:marker><c:symbol val="diamond"/><c:size val="9"/></c:marker>
+          <c:dPt><c:idx val="1"/><c:spPr><a:solidFill><a:srgbClr val="FF0000"/></a:solidFill></c:spPr></c:dPt>
+          <c:dLbls><c:showVal val="1"/></c:dLbls>
+          <c:trendline><c:trendlineType val="linear"/></c:trendline>
+          <c:cat><c:strRef><c:f>Data!$A$2:$A$3</c:f><c:strCache><c:ptCount val="2"/><c:pt idx="0"><c:v>X</c:v></c:pt><c:pt idx="1"><c:v>Y</c:v></c:pt></c:strCache></c:strRef></c:cat>
+          <c:val><c:numRef><c:f>Data!$B$2:$B$3</c:f><c:numCache><c:formatCode>0.0%</c:formatCode><c:ptCount val="2"/><c:pt idx="0"><c:v>1</c:v></c:pt><c:pt idx="1"><c:v>2</c:v></c:pt></c:numCache></c:numRef></c:val>
+          <c:smooth val="1"/>
+        </c:ser>
+        <c:ser>
+          <c:idx val="1"/><c:order val="1"/>
+          <c:tx><c:strRef><c:f>Data!$C$1</c:f><c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>Old B</c:v></c:pt></c:strCache></c:strRef></c:tx>
+          <c:spPr><a:ln w="19050"><a:solidFill><a:srgbClr val="2CA02C"/></a:solidFill></a:ln></c:spPr>
+          <c:marker><c:symbol val="triangle"/></c:marker>
+          <c:cat><c:strRef><c:f>Data!$A$2:$A$3</c:f><c:strCache><c:ptCount val="2"/><c:pt idx="0"><c:v>X</c:v></c:pt><c:pt idx="1"><c:v>Y</c:v></c:pt></c:strCache></c:strRef></c:cat>
+          <c:val><c:numRef><c:f>Data!$C$2:$C$3</c:f><c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="2"/><c:pt idx="0"><c:v>3</c:v></c:pt><c:pt idx="1"><c:v>4</c:v></c:pt></c:numCache></c:numRef></c:val>
+          <c:smooth val="0"/>
+          <c:extLst><c:ext uri="{C3380CC4-5D6E-409C-BE32-E72D297353CC}"><c16:uniqueId xmlns:c16="http://schemas.microsoft.com/office/drawing/2014/chart" val="{00000001-0000-0000-0000-000000000000}"/></c:ext></c:extLst>
+        </c:ser>
+        <c:marker val="1"/>
+        <c:axId val="1"/><c:axId val="2"/>
+      </c:lineChart>
+    </c:plotArea>
+  </c:chart>
+  <c:externalData r:id="rId1"/>
+</c:chartSpace>`
+
+func newStyledChartUpdater(t *testing.T) *godocx.Updater {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, map[string]string{
+		"word/charts/chart1.xml":                          styledChartFixtureXML,
+		"word/charts/_rels/chart1.xml.rels":               chartRelsFixtureXML,
+		"word/embeddings/Microsoft_Excel_Worksheet1.xlsx": string(buildFixtureWorkbook(t)),
+	}))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+	return u
+}
+
+func readChartPart(t *testing.T, u *godocx.Updater, chartIndex int) string {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join(u.TempDir(), "word", "charts", "chart"+strconv.Itoa(chartIndex)+".xml"))
+	if err != nil {
+		t.Fatalf("read chart xml: %v", err)
+	}
+	return string(raw)
+}
+
+func TestUpdateChartPreserveFormatting(t *testing.T) {
+	u := newStyledChartUpdater(t)
+
+	err := u.UpdateChart(1, godocx.ChartData{
+		Categories: []string{"Jan", "Feb", "Mar"},
+		Series: []godocx.SeriesData{
+			{Name: "North", Values: []float64{0.1, 0.2, 0.3}},
+			{Name: "South", Values: []float64{4, 5, 6}},
+			{Name: "West", Values: []float64{7, 8, 9}},
+		},
+		PreserveFormatting: true,
+	})
+	if err != nil {
+		t.Fatalf("UpdateChart failed: %v", err)
+	}
+
+	chart := readChartPart(t, u, 1)
+	series := strings.Split(chart, "<c:ser>")[1:]
+	if len(series) != 3 {
+		t.Fatalf("expected 3 series, got %d", len(series))
+	}
+
+	// The formatting of the first series is kept around the new data, and
+	// the value number format is kept
+	for _, want := range []string{
+		`<a:prstDash val="dash"/>`,
+		`<c:marker><c:symbol val="diamond"/><c:size val="9"/></c:marker>`,
+		`<c:dPt><c:idx val="1"/>`,
+		`<c:dLbls><c:showVal val="1"/></c:dLbls>`,
+		`<c:trendline><c:trendlineType val="linear"/></c:trendline>`,
+		`<c:f>Data!$B$1</c:f><c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>North</c:v>`,
+		`<c:f>Data!$A$2:$A$4</c:f><c:strCache><c:ptCount val="3"/>`,
+		`<c:f>Data!$B$2:$B$4</c:f><c:numCache><c:formatCode>0.0%</c:formatCode><c:ptCount val="3"/>`,
+		`<c:smooth val="1"/>`,
+	} {
+		if !strings.Contains(series[0], want) {
+			t.Errorf("expected %s in first series", want)
+		}
+	}
+	if strings.Contains(chart, "Old A") || strings.Contains(chart, "Old B") {
+		t.Error("expected old series names to be replaced")
+	}
+
+	// The added series copies the last series with a new index and without
+	// its unique ID
+	for _, want := range []string{
+		`<c:idx val="2"/><c:order val="2"/>`,
+		`<a:srgbClr val="2CA02C"/>`,
+		`<c:symbol val="triangle"/>`,
+		`<c:v>West</c:v>`,
+		`<c:f>Data!$D$2:$D$4</c:f>`,
+	} {
+		if !strings.Contains(series[2], want) {
+			t.Errorf("expected %s in added series", want)
+		}
+	}
+	if strings.Count(chart, "c16:uniqueId") != 1 {
+		t.Error("expected the unique ID not to be copied")
+	}
+
+	// Elements after the series are kept
+	if !strings.Contains(chart, `<c:marker val="1"/>`) || strings.Count(chart, "<c:axId") != 2 {
+		t.Error("expected chart elements after the series to be kept")
+	}
+}
+
+func TestUpdateChartPreserveFormattingDropsSurplus(t *testing.T) {
+	u := newStyledChartUpdater(t)
+
+	err := u.UpdateChart(1, godocx.ChartData{
+		Categories:         []string{"Jan"},
+		Series:             []godocx.SeriesData{{Name: "Only", Values: []float64{42}}},
+		PreserveFormatting: true,
+	})
+	if err != nil {
+		t.Fatalf("UpdateChart failed: %v", err)
+	}
+
+	chart := readChartPart(t, u, 1)
+	if n := strings.Count(chart, "<c:ser>"); n != 1 {
+		t.Fatalf("expected 1 series, got %d", n)
+	}
+	if !strings.Contains(chart, `<c:symbol val="diamond"/>`) || strings.Contains(chart, "2CA02C") {
+		t.Error("expected the first series to be kept and the second removed")
+	}
+	if !strings.Contains(chart, `<c:v>42</c:v>`) {
+		t.Error("expected the updated value in the chart")
+	}
+}
@@ -71,13 +71,24 @@ func (u *Updater) UpdateChart(chartIndex int, data ChartData) error {
 	if chartIndex < 1 {
 		return errors.New("chart index must be >= 1")
 	}
-	if err := validateChartData(data); err != nil {
-		return err
-	}
 
 	chartPath := filepath.Join(u.tempDir, "word", "charts", fmt.Sprintf("chart%d.xml", chartIndex))
-	if _, err := os.Stat(chartPath); err != nil {
-		return fmt.Errorf("chart file does not exist: %w", err)
+	rawChart, readErr := os.ReadFile(chartPath)
+
+	// The data is validated first, as a category chart when the chart is
+	// missing
+	var kind ChartKind
+	if readErr == nil {
+		var err error
+		if kind, err = xyChartKind(string(rawChart)); err != nil {
+			return err
+		}
+	}
+	if err := validateChartData(data, kind); err != nil {
+		return err
+	}
+	if readErr != nil {
+		return fmt.Errorf("chart file does not exist: %w", readErr)
 	}
 
 	if err := updateChartXML(chartPath, data); err != nil {
@@ -88,7 +99,7 @@ func (u *Updater) UpdateChart(chartIndex int, data ChartData) error {
 	if err != nil {
 		return fmt.Errorf("resolve embedded workbook: %w", err)
 	}
-	if err := updateEmbeddedWorkbook(xlsxPath, data); err != nil {
+	if err := updateEmbeddedWorkbook(xlsxPath, data, kind); err != nil {
 		return fmt.Errorf("update embedded workbook: %w", err)
 	}
 
@@ -112,10 +123,21 @@ func (u *Updater) Save(outputPath string) error {
 	return nil
 }
 
-func validateChartData(data ChartData) error {
-	if len(data.Categories) == 0 {
+// validateChartData validates the data of a chart update. Scatter and
+// bubble charts (kind ChartKindScatter or ChartKindBubble) take X values and
+// bubble sizes instead of categories; kind is empty for other charts.
+func validateChartData(data ChartData, kind ChartKind) error {
+	if isXYChartKind(kind) {
+		return validateXYChartData(data, kind)
+	}
+
+	categories := data.categoryData()
+	if categories.count() == 0 {
 		return errors.New("categories cannot be empty")
 	}
+	if err := categories.validate(); err != nil {
+		return err
+	}
 	if len(data.Series) == 0 {
 		return errors.New("series cannot be empty")
 	}
@@ -123,11 +145,14 @@ func validateChartData(data ChartData) error {
 		if strings.TrimSpace(s.Name) == "" {
 			return fmt.Errorf("series[%d] name cannot be empty", i)
 		}
-		if len(s.Values) != len(data.Categories) {
-			return fmt.Errorf("series[%d] values length (%d) must match categories length (%d)", i, len(s.Values), len(data.Categories))
+		if len(s.Values) != categories.count() {
+			return fmt.Errorf("series[%d] values length (%d) must match categories length (%d)", i, len(s.Values), categories.count())
+		}
+		if err := validateSeriesValues(i, s.Values, nil, nil); err != nil {
+			return err
 		}
 	}
-	return nil
+	return validateDisplayBlanksAs(data.DisplayBlanksAs)
 }
 
 func (u *Updater) findWorkbookPathForChart(chartIndex int) (string, error) {
@@ -203,9 +228,10 @@ type relationships struct {
 }
 
 type relationship struct {
-	ID     string `xml:"Id,attr"`
-	Type   string `xml:"Type,attr"`
-	Target string `xml:"Target,attr"`
+	ID         string `xml:"Id,attr"`
+	Type       string `xml:"Type,attr"`
+	Target     string `xml:"Target,attr"`
+	TargetMode string `xml:"TargetMode,attr"`
 }
 
 func findRelationshipTarget(relsPath, relationshipID string) (string, error) {
@@ -0,0 +1,56 @@
+package godocx
+
+import (
+	"fmt"
+	"math"
+)
+
+// Missing marks a missing chart value, such as a reading lost to a sensor
+// outage:
+//
+//	Values: []float64{12.5, godocx.Missing, 13.1}
+//
+// A missing point is left out of the chart cache and its worksheet cell is
+// left empty. The chart draws it as set by DisplayBlanksAs: as a gap (the
+// default), as zero, or by spanning the line across it. Missing is a NaN
+// and never equals itself; use IsMissing to test for it.
+var Missing = math.Float64frombits(missingBits)
+
+// missingBits is the quiet NaN payload of Missing, which tells it apart from
+// NaNs produced by arithmetic
+const missingBits = 0x7FF8_0000_0000_0BAD
+
+// IsMissing reports whether v is the Missing value
+func IsMissing(v float64) bool {
+	return math.Float64bits(v) == missingBits
+}
+
+// validateSeriesValues checks that the values of series i are finite or
+// Missing, and that its X values and bubble sizes are finite
+func validateSeriesValues(i int, values, xValues, bubbleSizes []float64) error {
+	for j, v := range values {
+		if !IsMissing(v) && (math.IsNaN(v) || math.IsInf(v, 0)) {
+			return fmt.Errorf("series[%d] value %d must be a finite number or Missing", i, j)
+		}
+	}
+	for j, v := range xValues {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("series[%d] X value %d must be a finite number", i, j)
+		}
+	}
+	for j, v := range bubbleSizes {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("series[%d] bubble size %d must be a finite number", i, j)
+		}
+	}
+	return nil
+}
+
+// validateDisplayBlanksAs validates how a chart draws missing values
+func validateDisplayBlanksAs(displayBlanksAs string) error {
+	switch displayBlanksAs {
+	case "", "gap", "zero", "span":
+		return nil
+	}
+	return fmt.Errorf("DisplayBlanksAs %q is not supported (use gap, zero or span)", displayBlanksAs)
+}
@@ -0,0 +1,152 @@
+package godocx_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func TestIsMissing(t *testing.T) {
+	if !godocx.IsMissing(godocx.Missing) {
+		t.Error("expected Missing to be missing")
+	}
+	for _, v := range []float64{0, math.NaN(), math.Inf(1)} {
+		if godocx.IsMissing(v) {
+			t.Errorf("expected %v not to be missing", v)
+		}
+	}
+}
+
+func TestInsertChartMissingValues(t *testing.T) {
+	u := newChartKindUpdater(t, godocx.ExtendedChartOptions{
+		ChartKind:  godocx.ChartKindLine,
+		Categories: []string{"08:00", "09:00", "10:00"},
+		Series:     []godocx.SeriesOptions{{Name: "Sensor", Values: []float64{21.5, godocx.Missing, 22.1}}},
+		Properties: &godocx.ChartProperties{DisplayBlanksAs: "span"},
+	})
+
+	chart := readChartPart(t, u, 1)
+	for _, want := range []string{
+		`<c:ptCount val="3"/><c:pt idx="0"><c:v>21.5</c:v></c:pt><c:pt idx="2"><c:v>22.1</c:v></c:pt></c:numCache>`,
+		`<c:dispBlanksAs val="span"/>`,
+	} {
+		if !strings.Contains(chart, want) {
+			t.Errorf("expected %s in chart", want)
+		}
+	}
+
+	sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+	if strings.Contains(sheet, `r="B3"`) || !strings.Contains(sheet, `r="B4"`) {
+		t.Error("expected an empty cell for the missing value")
+	}
+
+	// Column charts from InsertChart
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+	err = u.InsertChart(godocx.ChartOptions{
+		Position:        godocx.PositionEnd,
+		Categories:      []string{"A", "B"},
+		Series:          []godocx.SeriesData{{Name: "S", Values: []float64{godocx.Missing, 2}}},
+		DisplayBlanksAs: "zero",
+	})
+	if err != nil {
+		t.Fatalf("InsertChart failed: %v", err)
+	}
+	chart = readChartPart(t, u, 1)
+	if strings.Contains(chart, "NaN") || !strings.Contains(chart, `<c:dispBlanksAs val="zero"/>`) {
+		t.Error("expected the missing value to be left out and drawn as zero")
+	}
+	if sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx"); strings.Contains(sheet, `r="B2"`) || strings.Contains(sheet, "NaN") {
+		t.Error("expected an empty cell for the missing value")
+	}
+}
+
+func TestUpdateChartMissingValues(t *testing.T) {
+	for _, preserve := range []bool{false, true} {
+		u := newStyledChartUpdater(t)
+		err := u.UpdateChart(1, godocx.ChartData{
+			Categories: []string{"Mon", "Tue", "Wed"},
+			Series: []godocx.SeriesData{
+				{Name: "Old A", Values: []float64{1, godocx.Missing, 3}},
+				{Name: "Old B", Values: []float64{godocx.Missing, 5, 6}},
+			},
+			PreserveFormatting: preserve,
+			DisplayBlanksAs:    "gap",
+		})
+		if err != nil {
+			t.Fatalf("UpdateChart failed: %v", err)
+		}
+
+		chart := readChartPart(t, u, 1)
+		for _, want := range []string{
+			`<c:ptCount val="3"/><c:pt idx="0"><c:v>1</c:v></c:pt><c:pt idx="2"><c:v>3</c:v></c:pt>`,
+			`<c:ptCount val="3"/><c:pt idx="1"><c:v>5</c:v></c:pt>`,
+		} {
+			if !strings.Contains(chart, want) {
+				t.Errorf("preserve %v: expected %s in chart", preserve, want)
+			}
+		}
+		if !strings.Contains(chart, `</c:plotArea><c:dispBlanksAs val="gap"/></c:chart>`) {
+			t.Errorf("preserve %v: expected the blanks to be drawn as gaps", preserve)
+		}
+
+		sheet := readChartSheet(t, u, "Microsoft_Excel_Worksheet1.xlsx")
+		if strings.Contains(sheet, `r="B3"`) || strings.Contains(sheet, `r="C2"`) || !strings.Contains(sheet, `r="C3"`) {
+			t.Errorf("preserve %v: expected empty cells for the missing values", preserve)
+		}
+	}
+}
+
+func TestChartValueValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	for _, series := range []godocx.SeriesOptions{
+		{Name: "NaN", Values: []float64{1, math.NaN()}},
+		{Name: "Inf", Values: []float64{1, math.Inf(-1)}},
+	} {
+		err := u.InsertChartExtended(godocx.ExtendedChartOptions{
+			Position:   godocx.PositionEnd,
+			Categories: []string{"A", "B"},
+			Series:     []godocx.SeriesOptions{series},
+		})
+		if err == nil {
+			t.Errorf("%s: expected error", series.Name)
+		}
+	}
+
+	err = u.InsertChartExtended(godocx.ExtendedChartOptions{
+		Position:  godocx.PositionEnd,
+		ChartKind: godocx.ChartKindScatter,
+		Series:    []godocx.SeriesOptions{{Name: "S", XValues: []float64{godocx.Missing}, Values: []float64{1}}},
+	})
+	if err == nil {
+		t.Error("expected error for a missing X value")
+	}
+
+	err = u.InsertChartExtended(godocx.ExtendedChartOptions{
+		Position:   godocx.PositionEnd,
+		Categories: []string{"A"},
+		Series:     []godocx.SeriesOptions{{Name: "S", Values: []float64{1}}},
+		Properties: &godocx.ChartProperties{DisplayBlanksAs: "interpolate"},
+	})
+	if err == nil {
+		t.Error("expected error for an unsupported DisplayBlanksAs")
+	}
+
+	err = u.UpdateChart(1, godocx.ChartData{
+		Categories: []string{"A"},
+		Series:     []godocx.SeriesData{{Name: "S", Values: []float64{math.NaN()}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "finite") {
+		t.Errorf("expected a finite number error from UpdateChart, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -192,6 +193,14 @@ func updateChartXMLContent(rawXML []byte, data ChartData) ([]byte, error) {
 		return nil, err
 	}
 
+	if data.DisplayBlanksAs != "" {
+		content = setDisplayBlanksAs(content, data.DisplayBlanksAs, nsPrefix)
+	}
+
+	if categories := data.categoryData(); categories.numeric() {
+		content = setCategoryAxisFormat(content, categories.numberFormat(), nsPrefix)
+	}
+
 	return []byte(content), nil
 }
 
@@ -203,6 +212,53 @@ func detectNamespacePrefix(content string) string {
 	return ""
 }
 
+// setDisplayBlanksAs sets how the chart draws missing values
+func setDisplayBlanksAs(content, displayBlanksAs, nsPrefix string) string {
+	data := []byte(content)
+	start := findNextTagStart(data, 0, nsPrefix+"chart")
+	if start == -1 {
+		return content
+	}
+	end := findQualifiedElementEnd(data, start, nsPrefix+"chart")
+	if end == -1 {
+		return content
+	}
+
+	dispBlanksAs := fmt.Sprintf(`<%sdispBlanksAs val="%s"/>`, nsPrefix, displayBlanksAs)
+	chart := mergeChildElements(data[start:end], chartChildOrder, []propertyElement{{nsPrefix + "dispBlanksAs", []byte(dispBlanksAs)}}, nil)
+	return content[:start] + string(chart) + content[end:]
+}
+
+// setCategoryAxisFormat links the number format of the category and date
+// axes to date or numeric categories, so that their labels show as dates or
+// numbers rather than serials. An axis with a format of its own keeps it.
+func setCategoryAxisFormat(content, format, nsPrefix string) string {
+	numFmt := fmt.Sprintf(`<%snumFmt formatCode="%s" sourceLinked="1"/>`, nsPrefix, xmlEscape(format))
+
+	for _, name := range []string{nsPrefix + "catAx", nsPrefix + "dateAx"} {
+		for pos := 0; ; {
+			data := []byte(content)
+			start := findNextTagStart(data, pos, name)
+			if start == -1 {
+				break
+			}
+			end := findQualifiedElementEnd(data, start, name)
+			if end == -1 {
+				break
+			}
+			pos = end
+
+			if old := childElement(data[start:end], nsPrefix+"numFmt"); old != nil && xmlAttr(old, "formatCode") != "General" {
+				continue
+			}
+			axis := mergeChildElements(data[start:end], axisChildOrder, []propertyElement{{nsPrefix + "numFmt", []byte(numFmt)}}, nil)
+			content = content[:start] + string(axis) + content[end:]
+			pos = start + len(axis)
+		}
+	}
+	return content
+}
+
 // updateChartTitle updates the chart title in the XML.
 func updateChartTitle(content, title, nsPrefix string) string {
 	// Simple approach: find title section and update text
@@ -291,45 +347,168 @@ func updateFirstAxisTitle(content, title, nsPrefix, axisType string) string {
 	return beforeTitle + title + afterTitle
 }
 
-// updateChartSeries updates the series data in the chart XML.
+// updateChartSeries updates the series data in the chart XML. Each plot of
+// the plot area (e.g. the bars and the lines of a combo chart) receives the
+// data series routed to it by routeSeries.
 func updateChartSeries(content string, data ChartData, nsPrefix string) (string, error) {
-	// Find the chart type (barChart, lineChart, scatterChart, pieChart)
-	chartTypes := []string{"barChart", "lineChart", "scatterChart", "pieChart", "areaChart"}
-
-	var chartType string
-	var chartStart, chartEnd int
-
-	for _, ct := range chartTypes {
-		chartStart = strings.Index(content, "<"+nsPrefix+ct+">")
-		if chartStart != -1 {
-			chartType = ct
-			chartEnd = strings.Index(content[chartStart:], "</"+nsPrefix+ct+">")
-			if chartEnd == -1 {
-				return "", fmt.Errorf("malformed chart XML: no closing tag for %s", ct)
+	groups, err := findPlotGroups(content, nsPrefix)
+	if err != nil {
+		return "", err
+	}
+	if len(groups) == 0 {
+		return "", fmt.Errorf("unsupported or missing chart type")
+	}
+
+	assigned := routeSeries(groups, data)
+	if assigned == nil {
+		return "", fmt.Errorf("no series found in chart")
+	}
+
+	nextIdx := 0
+	for _, group := range groups {
+		nextIdx = max(nextIdx, group.nextIdx)
+	}
+
+	// Update from the last plot so the offsets of earlier plots stay valid
+	for g := len(groups) - 1; g >= 0; g-- {
+		group := groups[g]
+		if len(group.series) == 0 {
+			continue
+		}
+		updatedSeries, err := updateSeriesSection(content[group.start:group.end], data, assigned[g], nsPrefix, group.chartType, &nextIdx)
+		if err != nil {
+			return "", err
+		}
+		content = content[:group.start] + updatedSeries + content[group.end:]
+	}
+
+	return content, nil
+}
+
+// plotGroup is a plot of the plot area, such as <c:barChart>, located in
+// the chart XML. The span ends before the close tag.
+type plotGroup struct {
+	start, end int
+	chartType  string   // Local name, e.g. "barChart"
+	series     []string // Names of the existing series
+	nextIdx    int      // First series index above those in use
+}
+
+// findPlotGroups returns the plots of the plot area in document order
+func findPlotGroups(content, nsPrefix string) ([]plotGroup, error) {
+	data := []byte(content)
+	areaStart := findNextTagStart(data, 0, nsPrefix+"plotArea")
+	if areaStart == -1 {
+		return nil, nil
+	}
+	areaEnd := findQualifiedElementEnd(data, areaStart, nsPrefix+"plotArea")
+	if areaEnd == -1 {
+		return nil, fmt.Errorf("malformed chart XML: no closing tag for plotArea")
+	}
+	areaOpenEnd := areaStart + bytes.IndexByte(data[areaStart:], '>') + 1
+
+	var groups []plotGroup
+	for _, child := range childElements(data, areaOpenEnd, areaEnd) {
+		chartType := strings.TrimPrefix(child.name, nsPrefix)
+		if !strings.HasSuffix(chartType, "Chart") || data[child.end-2] == '/' {
+			continue
+		}
+
+		group := plotGroup{start: child.start, end: child.end - len("</"+child.name+">"), chartType: chartType}
+		openEnd := child.start + bytes.IndexByte(data[child.start:], '>') + 1
+		for _, ser := range childElements(data, openEnd, group.end) {
+			if ser.name != nsPrefix+"ser" {
+				continue
+			}
+			serXML := data[ser.start:ser.end]
+			group.series = append(group.series, seriesName(serXML, nsPrefix))
+			if idx, err := strconv.Atoi(seriesChildVal(serXML, nsPrefix+"idx")); err == nil {
+				group.nextIdx = max(group.nextIdx, idx+1)
 			}
-			chartEnd += chartStart
-			break
 		}
+		groups = append(groups, group)
 	}
+	return groups, nil
+}
 
-	if chartType == "" {
-		return "", fmt.Errorf("unsupported or missing chart type")
+// routeSeries assigns the data series to plots, returning the indexes of the
+// data series of each plot. A data series goes to the plot of the existing
+// series with the same name, or else of the next existing series not yet
+// taken; series beyond the existing ones go to the plot of the last series.
+// It returns nil when the chart has no series.
+func routeSeries(groups []plotGroup, data ChartData) [][]int {
+	type existingSeries struct {
+		group int
+		name  string
+		taken bool
+	}
+	var existing []existingSeries
+	for g, group := range groups {
+		for _, name := range group.series {
+			existing = append(existing, existingSeries{group: g, name: name})
+		}
+	}
+	if len(existing) == 0 {
+		return nil
 	}
 
-	chartSection := content[chartStart:chartEnd]
+	target := make([]int, len(data.Series))
+	for i, s := range data.Series {
+		target[i] = -1
+		for j := range existing {
+			if !existing[j].taken && existing[j].name == s.Name {
+				existing[j].taken = true
+				target[i] = existing[j].group
+				break
+			}
+		}
+	}
 
-	// Update or remove series
-	updatedSeries, err := updateSeriesSection(chartSection, data, nsPrefix, chartType)
-	if err != nil {
-		return "", err
+	assigned := make([][]int, len(groups))
+	next := 0
+	for i := range data.Series {
+		for target[i] == -1 {
+			if next == len(existing) {
+				target[i] = existing[len(existing)-1].group
+			} else if !existing[next].taken {
+				existing[next].taken = true
+				target[i] = existing[next].group
+			} else {
+				next++
+			}
+		}
+		assigned[target[i]] = append(assigned[target[i]], i)
 	}
+	return assigned
+}
 
-	// Reconstruct content
-	return content[:chartStart] + updatedSeries + content[chartEnd:], nil
+// seriesName returns the name of a series from its text, or "" when it has
+// none
+func seriesName(serXML []byte, nsPrefix string) string {
+	openEnd := bytes.IndexByte(serXML, '>') + 1
+	for _, child := range childElements(serXML, openEnd, len(serXML)) {
+		if child.name != nsPrefix+"tx" {
+			continue
+		}
+		tx := serXML[child.start:child.end]
+		start := bytes.Index(tx, []byte("<"+nsPrefix+"v>"))
+		if start == -1 {
+			return ""
+		}
+		start += len("<" + nsPrefix + "v>")
+		end := bytes.Index(tx[start:], []byte("</"))
+		if end == -1 {
+			return ""
+		}
+		return xmlUnescape(string(tx[start : start+end]))
+	}
+	return ""
 }
 
-// updateSeriesSection updates the series within a chart section.
-func updateSeriesSection(chartSection string, data ChartData, nsPrefix, chartType string) (string, error) {
+// updateSeriesSection updates the series within a chart section with the
+// data series at the given indexes. nextIdx is the next free series index
+// of the chart, used for added series when formatting is preserved.
+func updateSeriesSection(chartSection string, data ChartData, indexes []int, nsPrefix, chartType string, nextIdx *int) (string, error) {
 	// Find all series elements
 	serTags := findAllSeriesTags(chartSection, nsPrefix)
 
@@ -337,22 +516,293 @@ func updateSeriesSection(chartSection string, data ChartData, nsPrefix, chartTyp
 		return "", fmt.Errorf("no series found in chart")
 	}
 
-	// Build new series section
+	// Stock series are always updated in place: without their formatting
+	// (no lines, price markers) the prices would be drawn as line series
+	if data.PreserveFormatting || chartType == "stockChart" {
+		return updateSeriesInPlace(chartSection, data, indexes, nsPrefix, chartType, nextIdx), nil
+	}
+
+	// Build new series section in place of the existing series, keeping the
+	// other children of the chart element (grouping, axId, etc.) where they are
+	section := []byte(chartSection)
+	var first, last elementSpan
+	for _, child := range childElements(section, bytes.IndexByte(section, '>')+1, len(section)) {
+		if child.name == nsPrefix+"ser" {
+			if first.end == 0 {
+				first = child
+			}
+			last = child
+		}
+	}
+
 	var buf bytes.Buffer
-	buf.WriteString("<" + nsPrefix + chartType + ">")
+	buf.WriteString(chartSection[:first.start])
 
 	// Write series from data
-	for i, series := range data.Series {
-		serXML := buildSeriesXML(series, data.Categories, i, nsPrefix, chartType)
+	for _, i := range indexes {
+		serXML := buildSeriesXML(data.Series[i], data.categoryData(), i, nsPrefix, chartType)
 		buf.WriteString(serXML)
 	}
 
-	// Copy any non-series elements (like axId, etc.) from the original
-	buf.WriteString(copyNonSeriesElements(chartSection, nsPrefix))
+	buf.WriteString(chartSection[last.end:])
 
 	return buf.String(), nil
 }
 
+// seriesChildOrder is the schema order of the children of a series,
+// merged across chart types
+var seriesChildOrder = []string{
+	"idx", "order", "tx", "spPr", "invertIfNegative", "pictureOptions", "marker", "explosion",
+	"dPt", "dLbls", "trendline", "errBars", "cat", "val", "xVal", "yVal", "smooth", "shape",
+	"bubbleSize", "bubble3D", "extLst",
+}
+
+// updateSeriesInPlace updates the series of a chart section without
+// rebuilding them: the name, categories and values of each series are
+// rewritten and every other child is kept. A data series takes the place of
+// the existing series with the same name, or else of the next unused one;
+// added series copy the last series and unused series are removed.
+func updateSeriesInPlace(chartSection string, data ChartData, indexes []int, nsPrefix, chartType string, nextIdx *int) string {
+	section := []byte(chartSection)
+	openEnd := bytes.IndexByte(section, '>') + 1
+
+	var series []elementSpan
+	var names []string
+	for _, child := range childElements(section, openEnd, len(section)) {
+		if child.name == nsPrefix+"ser" {
+			series = append(series, child)
+			names = append(names, seriesName(section[child.start:child.end], nsPrefix))
+		}
+	}
+
+	// Match by name first, then fill in order
+	template := make([]int, len(indexes))
+	used := make([]bool, len(series))
+	for k, i := range indexes {
+		template[k] = -1
+		for j, name := range names {
+			if !used[j] && name == data.Series[i].Name {
+				template[k], used[j] = j, true
+				break
+			}
+		}
+	}
+	next := 0
+	for k := range template {
+		for template[k] == -1 && next < len(series) {
+			if !used[next] {
+				template[k], used[next] = next, true
+			}
+			next++
+		}
+	}
+
+	sheet := chartSheetName(chartSection, nsPrefix)
+	last := series[len(series)-1]
+
+	var buf bytes.Buffer
+	buf.Write(section[:series[0].start])
+	for k, i := range indexes {
+		var serXML []byte
+		if j := template[k]; j != -1 {
+			serXML = section[series[j].start:series[j].end]
+		} else {
+			serXML = cloneSeriesXML(section[last.start:last.end], *nextIdx, nsPrefix)
+			*nextIdx++
+		}
+		buf.Write(rewriteSeriesData(serXML, data.Series[i], data.categoryData(), i, sheet, nsPrefix, chartType))
+	}
+	buf.Write(section[last.end:])
+	return buf.String()
+}
+
+// cloneSeriesXML copies a series for a new series with the given index. The
+// extension list is dropped since it holds the unique ID of the series.
+func cloneSeriesXML(serXML []byte, idx int, nsPrefix string) []byte {
+	return mergeChildElements(serXML, seriesChildOrder, []propertyElement{
+		{nsPrefix + "idx", []byte(fmt.Sprintf(`<%sidx val="%d"/>`, nsPrefix, idx))},
+		{nsPrefix + "order", []byte(fmt.Sprintf(`<%sorder val="%d"/>`, nsPrefix, idx))},
+	}, []string{nsPrefix + "extLst"})
+}
+
+// rewriteSeriesData replaces the name, category and value data of a series
+// with the given series, referencing the worksheet layout written by
+// updateEmbeddedWorkbook: names in row 1, categories from column A and the
+// values of series i in the (i+1)th column after them. Scatter and bubble
+// series reference their X, Y and size columns instead.
+func rewriteSeriesData(serXML []byte, series SeriesData, categories chartCategories, index int, sheet, nsPrefix, chartType string) []byte {
+	p := nsPrefix
+	col := columnLetter(categories.columns() + index + 1)
+	lastRow := categories.count() + 1
+
+	catTag, valTag := "cat", "val"
+	kind := ChartKind(chartType)
+	if isXYChartKind(kind) {
+		catTag, valTag = "xVal", "yVal"
+		_, y, _ := seriesColumns(kind, index, 0)
+		col, lastRow = columnLetter(y), len(series.Values)+1
+	}
+
+	existing := make(map[string][]byte)
+	openEnd := bytes.IndexByte(serXML, '>') + 1
+	for _, child := range childElements(serXML, openEnd, len(serXML)) {
+		existing[child.name] = serXML[child.start:child.end]
+	}
+
+	// A literal name stays literal
+	tx := "<" + p + "tx>" + buildStrRefXML(p, fmt.Sprintf("%s!$%s$1", sheet, col), []string{series.Name}) + "</" + p + "tx>"
+	if old, ok := existing[p+"tx"]; ok && !bytes.Contains(old, []byte("<"+p+"strRef")) {
+		tx = "<" + p + "tx><" + p + "v>" + xmlEscape(series.Name) + "</" + p + "v></" + p + "tx>"
+	}
+
+	// Keep the number formats of the value caches
+	formatCode := func(tag string) string {
+		old := existing[p+tag]
+		if start := bytes.Index(old, []byte("<"+p+"formatCode>")); start != -1 {
+			start += len("<" + p + "formatCode>")
+			if end := bytes.Index(old[start:], []byte("</")); end != -1 {
+				return string(old[start : start+end])
+			}
+		}
+		return "General"
+	}
+
+	val := "<" + p + valTag + ">" + buildNumRefXML(p, fmt.Sprintf("%s!$%s$2:$%s$%d", sheet, col, col, lastRow), formatCode(valTag), series.Values) + "</" + p + valTag + ">"
+	if isXYChartKind(kind) {
+		x, _, size := seriesColumns(kind, index, 0)
+		props := []propertyElement{
+			{p + "tx", []byte(tx)},
+			{p + "xVal", []byte("<" + p + "xVal>" + buildNumRefXML(p, sheetRange(sheet, x, lastRow), formatCode("xVal"), series.XValues) + "</" + p + "xVal>")},
+			{p + "yVal", []byte(val)},
+		}
+		if size != 0 {
+			props = append(props, propertyElement{p + "bubbleSize", []byte("<" + p + "bubbleSize>" + buildNumRefXML(p, sheetRange(sheet, size, lastRow), formatCode("bubbleSize"), series.BubbleSizes) + "</" + p + "bubbleSize>")})
+		}
+		return mergeChildElements(serXML, seriesChildOrder, props, nil)
+	}
+
+	cat := "<" + p + catTag + ">" + categories.refXML(p, categories.formula(sheet)) + "</" + p + catTag + ">"
+
+	return mergeChildElements(serXML, seriesChildOrder, []propertyElement{
+		{p + "tx", []byte(tx)},
+		{p + catTag, []byte(cat)},
+		{p + valTag, []byte(val)},
+	}, nil)
+}
+
+// sheetRange returns the formula of rows 2 to lastRow of a worksheet column
+func sheetRange(sheet string, col, lastRow int) string {
+	letter := columnLetter(col)
+	return fmt.Sprintf("%s!$%s$2:$%s$%d", sheet, letter, letter, lastRow)
+}
+
+// buildStrRefXML builds a string reference with its cache. The formula must
+// already be escaped; an empty formula is left out.
+func buildStrRefXML(p, formula string, values []string) string {
+	var buf bytes.Buffer
+	buf.WriteString("<" + p + "strRef>")
+	if formula != "" {
+		buf.WriteString("<" + p + "f>" + formula + "</" + p + "f>")
+	}
+	buf.WriteString("<" + p + "strCache><" + p + "ptCount val=\"" + strconv.Itoa(len(values)) + "\"/>")
+	for i, v := range values {
+		buf.WriteString("<" + p + "pt idx=\"" + strconv.Itoa(i) + "\"><" + p + "v>" + xmlEscape(v) + "</" + p + "v></" + p + "pt>")
+	}
+	buf.WriteString("</" + p + "strCache></" + p + "strRef>")
+	return buf.String()
+}
+
+// buildNumRefXML builds a number reference with its cache. The formula and
+// format code must already be escaped; an empty formula is left out.
+// Missing values have no cache point.
+func buildNumRefXML(p, formula, formatCode string, values []float64) string {
+	var buf bytes.Buffer
+	buf.WriteString("<" + p + "numRef>")
+	if formula != "" {
+		buf.WriteString("<" + p + "f>" + formula + "</" + p + "f>")
+	}
+	buf.WriteString("<" + p + "numCache><" + p + "formatCode>" + formatCode + "</" + p + "formatCode>")
+	buf.WriteString("<" + p + "ptCount val=\"" + strconv.Itoa(len(values)) + "\"/>")
+	for i, v := range values {
+		if IsMissing(v) {
+			continue
+		}
+		buf.WriteString("<" + p + "pt idx=\"" + strconv.Itoa(i) + "\"><" + p + "v>" + formatFloat(v) + "</" + p + "v></" + p + "pt>")
+	}
+	buf.WriteString("</" + p + "numCache></" + p + "numRef>")
+	return buf.String()
+}
+
+// chartSheetName returns the (escaped) sheet part of the first formula of a
+// chart, e.g. Sheet1 or 'Sales Data', defaulting to Sheet1
+func chartSheetName(content, nsPrefix string) string {
+	start := strings.Index(content, "<"+nsPrefix+"f>")
+	if start == -1 {
+		return "Sheet1"
+	}
+	start += len("<" + nsPrefix + "f>")
+	end := strings.Index(content[start:], "</")
+	if end == -1 {
+		return "Sheet1"
+	}
+	formula := content[start : start+end]
+	if bang := strings.LastIndex(formula, "!"); bang > 0 {
+		return formula[:bang]
+	}
+	return "Sheet1"
+}
+
+// seriesChildVal returns the val attribute of the first child with the
+// given name of a series, e.g. c:idx
+func seriesChildVal(serXML []byte, name string) string {
+	openEnd := bytes.IndexByte(serXML, '>') + 1
+	for _, child := range childElements(serXML, openEnd, len(serXML)) {
+		if child.name == name {
+			return xmlAttr(serXML[child.start:child.end], "val")
+		}
+	}
+	return ""
+}
+
+// mergeChildElements replaces, adds or removes the children of element,
+// keeping the schema order given by local names. Every existing child named
+// in props or remove is dropped, so repeated elements such as c:dPt are
+// replaced as a group.
+func mergeChildElements(element []byte, order []string, props []propertyElement, remove []string) []byte {
+	openEnd := bytes.IndexByte(element, '>') + 1
+	closeStart := bytes.LastIndex(element, []byte("</"))
+
+	var merged []propertyElement
+	for _, child := range childElements(element, openEnd, closeStart) {
+		replaced := slices.ContainsFunc(props, func(p propertyElement) bool { return p.name == child.name })
+		if !replaced && !slices.Contains(remove, child.name) {
+			merged = append(merged, propertyElement{child.name, element[child.start:child.end]})
+		}
+	}
+	merged = append(merged, props...)
+
+	position := func(name string) int {
+		if _, local, ok := strings.Cut(name, ":"); ok {
+			name = local
+		}
+		if idx := slices.Index(order, name); idx != -1 {
+			return idx
+		}
+		return len(order)
+	}
+	slices.SortStableFunc(merged, func(a, b propertyElement) int {
+		return position(a.name) - position(b.name)
+	})
+
+	var buf bytes.Buffer
+	buf.Write(element[:openEnd])
+	for _, child := range merged {
+		buf.Write(child.xml)
+	}
+	buf.Write(element[closeStart:])
+	return buf.Bytes()
+}
+
 // findAllSeriesTags finds positions of all series tags.
 func findAllSeriesTags(content, nsPrefix string) []int {
 	var positions []int
@@ -372,7 +822,7 @@ func findAllSeriesTags(content, nsPrefix string) []int {
 }
 
 // buildSeriesXML constructs XML for a single series.
-func buildSeriesXML(series SeriesData, categories []string, idx int, nsPrefix, chartType string) string {
+func buildSeriesXML(series SeriesData, categories chartCategories, idx int, nsPrefix, chartType string) string {
 	var buf bytes.Buffer
 
 	buf.WriteString("<" + nsPrefix + "ser>")
@@ -384,25 +834,18 @@ func buildSeriesXML(series SeriesData, categories []string, idx int, nsPrefix, c
 	buf.WriteString("<" + nsPrefix + "v>" + xmlEscape(series.Name) + "</" + nsPrefix + "v>")
 	buf.WriteString("</" + nsPrefix + "tx>")
 
-	// Categories (for most chart types except scatter)
-	if chartType != "scatterChart" {
+	// Categories, or the X values of scatter and bubble charts
+	if chartType != "scatterChart" && chartType != "bubbleChart" {
 		buf.WriteString("<" + nsPrefix + "cat>")
-		buf.WriteString("<" + nsPrefix + "strRef>")
-		buf.WriteString("<" + nsPrefix + "strCache>")
-		buf.WriteString("<" + nsPrefix + "ptCount val=\"" + strconv.Itoa(len(categories)) + "\"/>")
-		for i, cat := range categories {
-			buf.WriteString("<" + nsPrefix + "pt idx=\"" + strconv.Itoa(i) + "\">")
-			buf.WriteString("<" + nsPrefix + "v>" + xmlEscape(cat) + "</" + nsPrefix + "v>")
-			buf.WriteString("</" + nsPrefix + "pt>")
-		}
-		buf.WriteString("</" + nsPrefix + "strCache>")
-		buf.WriteString("</" + nsPrefix + "strRef>")
+		buf.WriteString(categories.refXML(nsPrefix, ""))
 		buf.WriteString("</" + nsPrefix + "cat>")
+	} else {
+		buf.WriteString("<" + nsPrefix + "xVal>" + buildNumRefXML(nsPrefix, "", "General", series.XValues) + "</" + nsPrefix + "xVal>")
 	}
 
 	// Values
 	valTag := "val"
-	if chartType == "scatterChart" {
+	if chartType == "scatterChart" || chartType == "bubbleChart" {
 		valTag = "yVal"
 	}
 
@@ -411,6 +854,9 @@ func buildSeriesXML(series SeriesData, categories []string, idx int, nsPrefix, c
 	buf.WriteString("<" + nsPrefix + "numCache>")
 	buf.WriteString("<" + nsPrefix + "ptCount val=\"" + strconv.Itoa(len(series.Values)) + "\"/>")
 	for i, val := range series.Values {
+		if IsMissing(val) {
+			continue
+		}
 		buf.WriteString("<" + nsPrefix + "pt idx=\"" + strconv.Itoa(i) + "\">")
 		buf.WriteString("<" + nsPrefix + "v>" + formatFloat(val) + "</" + nsPrefix + "v>")
 		buf.WriteString("</" + nsPrefix + "pt>")
@@ -419,45 +865,11 @@ func buildSeriesXML(series SeriesData, categories []string, idx int, nsPrefix, c
 	buf.WriteString("</" + nsPrefix + "numRef>")
 	buf.WriteString("</" + nsPrefix + valTag + ">")
 
-	buf.WriteString("</" + nsPrefix + "ser>")
-
-	return buf.String()
-}
-
-// copyNonSeriesElements copies elements like axId from the original chart.
-func copyNonSeriesElements(chartSection, nsPrefix string) string {
-	var buf bytes.Buffer
-
-	// Find and copy axId elements
-	axIdTags := []string{"axId", "dLbls", "gapWidth", "overlap", "varyColors"}
-
-	for _, tag := range axIdTags {
-		startTag := "<" + nsPrefix + tag
-		offset := 0
-
-		for {
-			pos := strings.Index(chartSection[offset:], startTag)
-			if pos == -1 {
-				break
-			}
-
-			pos += offset
-			endPos := strings.Index(chartSection[pos:], "/>")
-			if endPos != -1 {
-				buf.WriteString(chartSection[pos : pos+endPos+2])
-				offset = pos + endPos + 2
-			} else {
-				endTagStr := "</" + nsPrefix + tag + ">"
-				endPos = strings.Index(chartSection[pos:], endTagStr)
-				if endPos != -1 {
-					buf.WriteString(chartSection[pos : pos+endPos+len(endTagStr)])
-					offset = pos + endPos + len(endTagStr)
-				} else {
-					break
-				}
-			}
-		}
+	if chartType == "bubbleChart" {
+		buf.WriteString("<" + nsPrefix + "bubbleSize>" + buildNumRefXML(nsPrefix, "", "General", series.BubbleSizes) + "</" + nsPrefix + "bubbleSize>")
 	}
 
+	buf.WriteString("</" + nsPrefix + "ser>")
+
 	return buf.String()
 }
@@ -44,6 +44,10 @@ var (
 	// docPrIDPattern matches docPr id attributes in document.xml
 	docPrIDPattern = regexp.MustCompile(`docPr id="(\d+)"`)
 
+	// uniqueIDAttrPattern matches the paragraph and drawing IDs that must be
+	// unique in a document (w14:paraId, w14:textId, wp14:anchorId)
+	uniqueIDAttrPattern = regexp.MustCompile(`\s(?:w14:paraId|w14:textId|wp14:anchorId)="[^"]*"`)
+
 	// bookmarkIDPattern matches bookmark id attributes (w:bookmarkStart and w:bookmarkEnd)
 	bookmarkIDPattern = regexp.MustCompile(`w:id="(\d+)"`)
 
@@ -62,6 +66,38 @@ var (
 
 	// textContentPattern extracts text from a Word text run
 	textContentPattern = regexp.MustCompile(`<w:t(?:\s[^>]*)?>(.*)</w:t>`)
+
+	// pStyleValPattern extracts the style ID of a paragraph
+	pStyleValPattern = regexp.MustCompile(`<w:pStyle w:val="([^"]+)"`)
+
+	// outlineLvlPattern extracts an explicit paragraph outline level
+	outlineLvlPattern = regexp.MustCompile(`<w:outlineLvl w:val="(\d+)"`)
+
+	// headingStylePattern extracts the level of built-in heading style IDs
+	headingStylePattern = regexp.MustCompile(`(?i)^heading\s*(\d)$`)
+
+	// relationshipRefPattern matches relationship references in part XML
+	// (e.g., r:id="rId5", r:embed="rId7", VML o:relid="rId8")
+	relationshipRefPattern = regexp.MustCompile(`\b(?:r:(?:id|embed|link|pict|dm|lo|qs|cs)|o:relid)="([^"]+)"`)
+
+	// tableCaptionPattern matches the SEQ field of a table caption
+	tableCaptionPattern = regexp.MustCompile(`(?:<w:instrText[^>]*>|w:instr=")\s*SEQ\s+Table\b`)
+
+	// bookmarkMarkerPattern matches bookmark start and end markers
+	bookmarkMarkerPattern = regexp.MustCompile(`<w:bookmark(Start|End)\b[^>]*>`)
+
+	// hyperlinkAnchorPattern matches the bookmark target of an internal hyperlink
+	hyperlinkAnchorPattern = regexp.MustCompile(`\bw:anchor="([^"]*)"`)
+
+	// fieldInstructionPattern matches field instruction text, in complex
+	// fields (<w:instrText>) and simple fields (w:instr attribute)
+	fieldInstructionPattern = regexp.MustCompile(`<w:instrText\b[^>]*>[^<]*|\bw:instr="[^"]*`)
+
+	// bookmarkFieldPattern matches the bookmark name of a REF or PAGEREF field
+	bookmarkFieldPattern = regexp.MustCompile(`\b((?:PAGE)?REF\s+)([^\s"<\\]+)`)
+
+	// chartReferencePattern matches the chart reference of a chart drawing
+	chartReferencePattern = regexp.MustCompile(`<c:chart\b[^>]*>`)
 )
 
 // OpenXML namespace URIs
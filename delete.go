@@ -0,0 +1,433 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DeleteParagraphs deletes every paragraph of the document body whose text
+// matches pattern and returns the number of paragraphs deleted. Paragraphs
+// in table cells are included when opts.InTables is set; a cell that loses
+// all of its paragraphs keeps an empty one. When neither InParagraphs nor
+// InTables is set, both are searched. opts.MaxResults limits the number of
+// paragraphs deleted.
+func (u *Updater) DeleteParagraphs(pattern string, opts FindOptions) (int, error) {
+	if u == nil {
+		return 0, fmt.Errorf("updater is nil")
+	}
+	if pattern == "" {
+		return 0, NewValidationError("pattern", "search pattern cannot be empty")
+	}
+
+	searchPattern, err := compileFindPattern(pattern, opts)
+	if err != nil {
+		return 0, err
+	}
+	if !opts.InParagraphs && !opts.InTables {
+		opts.InParagraphs = true
+		opts.InTables = true
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return 0, err
+	}
+
+	var spans []elementSpan
+	for _, para := range scanStoryParagraphs(docXML, StoryDocument) {
+		if opts.MaxResults > 0 && len(spans) >= opts.MaxResults {
+			break
+		}
+		if para.loc.InTable() && !opts.InTables || !para.loc.InTable() && !opts.InParagraphs {
+			continue
+		}
+		text := strings.Join(paragraphRunTexts(docXML[para.start:para.end]), "")
+		if searchPattern.MatchString(text) {
+			spans = append(spans, elementSpan{start: para.start, end: para.end, name: "w:p"})
+		}
+	}
+
+	if len(spans) == 0 {
+		return 0, nil
+	}
+
+	if err := u.writeDocumentXML(deleteElements(docXML, spans)); err != nil {
+		return 0, err
+	}
+	return len(spans), nil
+}
+
+// DeleteParagraphAt deletes the paragraph containing a match returned by
+// FindText. Unlike DeleteParagraphs it removes exactly the paragraph of the
+// match, even when the same text occurs several times.
+func (u *Updater) DeleteParagraphAt(match TextMatch) error {
+	if u == nil {
+		return fmt.Errorf("updater is nil")
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return err
+	}
+
+	paraStart, paraEnd, err := findParagraphByMatch(docXML, &match)
+	if err != nil {
+		return err
+	}
+
+	return u.writeDocumentXML(deleteElements(docXML, []elementSpan{{start: paraStart, end: paraEnd, name: "w:p"}}))
+}
+
+// DeleteParagraphsIn deletes the paragraphs covered by a range and returns
+// the number of paragraphs deleted: the paragraph containing a text range,
+// or the paragraphs and tables of a block range (counting the paragraphs of
+// the tables). The range becomes an empty block range where the content was.
+func (u *Updater) DeleteParagraphsIn(r *Range) (int, error) {
+	if u == nil {
+		return 0, fmt.Errorf("updater is nil")
+	}
+
+	docXML, start, end, err := u.blockSource(r)
+	if err != nil {
+		return 0, err
+	}
+
+	spans := appendBlockElements(nil, docXML, start, end)
+	count := len(scanStoryParagraphs(docXML[start:end], StoryDocument))
+
+	updated := deleteElements(docXML, spans)
+	r.block = true
+	r.headCut, r.tailCut = 0, 0
+	if err := r.store(updated, start, start); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteBetween deletes the body content between the paragraph containing
+// startAnchor and the next paragraph containing endAnchor. When inclusive
+// is true the anchor paragraphs are deleted as well. An anchor found inside
+// a table selects the whole table, so tables are only removed completely.
+//
+// Section breaks within the deleted content are removed with it; the
+// section properties of the last deleted break are kept when content of
+// that section remains before the deletion.
+func (u *Updater) DeleteBetween(startAnchor, endAnchor string, inclusive bool) error {
+	if u == nil {
+		return fmt.Errorf("updater is nil")
+	}
+	if startAnchor == "" {
+		return NewValidationError("startAnchor", "start anchor cannot be empty")
+	}
+	if endAnchor == "" {
+		return NewValidationError("endAnchor", "end anchor cannot be empty")
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return err
+	}
+
+	blocks, err := bodyBlocks(docXML)
+	if err != nil {
+		return err
+	}
+
+	first := findBlockByAnchor(docXML, blocks, startAnchor, 0)
+	if first == -1 {
+		return NewTextNotFoundError(startAnchor)
+	}
+	last := findBlockByAnchor(docXML, blocks, endAnchor, first+1)
+	if last == -1 {
+		return NewTextNotFoundError(endAnchor)
+	}
+
+	if !inclusive {
+		first++
+		last--
+	}
+	if first > last {
+		return nil
+	}
+
+	return u.writeDocumentXML(deleteElements(docXML, blocks[first:last+1]))
+}
+
+// DeleteBookmarkContent deletes the content enclosed by a bookmark. The
+// bookmark itself is kept, so it can be used as an anchor for new content.
+// Paragraphs holding the bookmark markers keep their content outside the
+// bookmark; whole paragraphs and tables between them are removed. When only
+// one marker lies in a table, the table is cleared only within the cell of
+// that marker, from the marker on (or up to it).
+func (u *Updater) DeleteBookmarkContent(name string) error {
+	if u == nil {
+		return fmt.Errorf("updater is nil")
+	}
+	if name == "" {
+		return NewValidationError("name", "bookmark name cannot be empty")
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return err
+	}
+
+	startIdx, endIdx, err := findBookmarkMarkers(docXML, name)
+	if err != nil {
+		return err
+	}
+	startTagEnd := startIdx + bytes.IndexByte(docXML[startIdx:], '>') + 1
+	if endIdx < startTagEnd {
+		return nil
+	}
+
+	var startPara, endPara *paragraphSpan
+	paragraphs := scanStoryParagraphs(docXML, StoryDocument)
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if startIdx >= para.start && startIdx < para.end {
+			startPara = para
+		}
+		if endIdx >= para.start && endIdx < para.end {
+			endPara = para
+		}
+	}
+	tables := scanStoryTables(docXML)
+
+	// Runs after the start marker and before the end marker
+	var spans []elementSpan
+	markerParagraphs := []*paragraphSpan{startPara}
+	if endPara != startPara {
+		markerParagraphs = append(markerParagraphs, endPara)
+	}
+	for _, para := range markerParagraphs {
+		if para == nil {
+			continue
+		}
+		for _, run := range paragraphRuns(docXML[para.start:para.end]) {
+			runStart, runEnd := para.start+run.start, para.start+run.end
+			if runStart >= startTagEnd && runEnd <= endIdx {
+				spans = append(spans, elementSpan{start: runStart, end: runEnd, name: "w:r"})
+			}
+		}
+	}
+
+	// Paragraphs and tables between the marker paragraphs. A marker
+	// paragraph in a table cell bounds the deletion within its cell; outside
+	// the cell, the deletion continues after (or before) the whole table.
+	if startPara == nil || startPara != endPara {
+		from, to := startTagEnd, endIdx
+		if startPara != nil {
+			from = startPara.end
+			if cell, tbl, ok := markerCell(tables, startPara.loc); ok && (endIdx < cell.start || endIdx >= cell.end) {
+				spans = appendBlockElements(spans, docXML, from, cell.end-len("</w:tc>"))
+				from = max(tbl.end, from)
+			}
+		}
+		if endPara != nil {
+			to = endPara.start
+			if cell, tbl, ok := markerCell(tables, endPara.loc); ok && (startIdx < cell.start || startIdx >= cell.end) {
+				cellContent := cell.start + bytes.IndexByte(docXML[cell.start:], '>') + 1
+				spans = appendBlockElements(spans, docXML, cellContent, to)
+				to = min(tbl.start, to)
+			}
+		}
+		if from < to {
+			spans = appendBlockElements(spans, docXML, from, to)
+		}
+	}
+
+	if len(spans) == 0 {
+		return nil
+	}
+	return u.writeDocumentXML(deleteElements(docXML, spans))
+}
+
+// markerCell returns the top-level table cell holding a paragraph
+func markerCell(tables []tableSpan, loc TextLocation) (cellSpan, tableSpan, bool) {
+	if !loc.InTable() || loc.Table >= len(tables) {
+		return cellSpan{}, tableSpan{}, false
+	}
+	tbl := tables[loc.Table]
+	if loc.Row >= len(tbl.rows) || loc.Cell >= len(tbl.rows[loc.Row].cells) {
+		return cellSpan{}, tableSpan{}, false
+	}
+	return tbl.rows[loc.Row].cells[loc.Cell], tbl, true
+}
+
+// appendBlockElements appends the paragraphs, tables and content controls
+// among the children of data[from:to]
+func appendBlockElements(spans []elementSpan, data []byte, from, to int) []elementSpan {
+	for _, child := range childElements(data, from, to) {
+		if isBlockElement(child.name) {
+			spans = append(spans, child)
+		}
+	}
+	return spans
+}
+
+// isBlockElement reports whether an element is body content that can be
+// deleted as a whole
+func isBlockElement(name string) bool {
+	return name == "w:p" || name == "w:tbl" || name == "w:sdt"
+}
+
+// bodyBlocks returns the paragraphs, tables and content controls that are
+// direct children of the document body
+func bodyBlocks(docXML []byte) ([]elementSpan, error) {
+	bodyStart, err := findBodyContentStart(docXML)
+	if err != nil {
+		return nil, err
+	}
+	bodyEnd := bytes.Index(docXML, []byte("</w:body>"))
+	if bodyEnd == -1 {
+		return nil, fmt.Errorf("could not find </w:body> tag")
+	}
+
+	var blocks []elementSpan
+	for _, child := range childElements(docXML, bodyStart, bodyEnd) {
+		if isBlockElement(child.name) {
+			blocks = append(blocks, child)
+		}
+	}
+	return blocks, nil
+}
+
+// findBlockByAnchor returns the index of the first block from index from
+// with a paragraph containing the anchor text, or -1
+func findBlockByAnchor(docXML []byte, blocks []elementSpan, anchor string, from int) int {
+	matches, _ := positionAnchor{text: anchor}.matcher()
+	for i := from; i < len(blocks); i++ {
+		block := docXML[blocks[i].start:blocks[i].end]
+		for _, para := range scanStoryParagraphs(block, StoryDocument) {
+			if matches(extractParagraphPlainText(block[para.start:para.end])) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// deleteElements removes the given elements from document.xml. Spans must
+// not overlap. A deleted paragraph that carries a section break leaves its
+// section properties behind in an empty paragraph, unless no content of that
+// section remains. A table cell left without paragraphs gets an empty one,
+// and the body always keeps at least one paragraph.
+func deleteElements(docXML []byte, spans []elementSpan) []byte {
+	// Delete from the end so earlier offsets stay valid
+	sorted := slices.Clone(spans)
+	slices.SortFunc(sorted, func(a, b elementSpan) int {
+		return b.start - a.start
+	})
+
+	updated := docXML
+	for _, span := range sorted {
+		var replacement []byte
+		if span.name == "w:p" {
+			if pPr := paragraphProperties(updated[span.start:span.end]); bytes.Contains(pPr, []byte("<w:sectPr")) {
+				replacement = slices.Concat([]byte("<w:p>"), pPr, []byte("</w:p>"))
+			}
+		}
+		updated = spliceBytes(updated, replacement, span.start, span.end)
+		if span.name == "w:r" {
+			updated, _, _ = removeEmptyHyperlinks(updated, span.start, span.start)
+		}
+		updated = ensureCellParagraph(updated, span.start)
+		updated = removeEmptySectionBreak(updated, span.start)
+	}
+
+	if blocks, err := bodyBlocks(updated); err == nil && len(blocks) == 0 {
+		if insertPos, err := findBodyEndInsertPos(updated); err == nil {
+			updated = spliceBytes(updated, []byte("<w:p/>"), insertPos, insertPos)
+		}
+	}
+
+	return updated
+}
+
+// removeEmptySectionBreak removes the section break paragraph at offset when
+// it has no content of its own and its section no longer has any content,
+// which would otherwise leave an empty page
+func removeEmptySectionBreak(docXML []byte, offset int) []byte {
+	if !bytes.HasPrefix(docXML[offset:], []byte("<w:p>")) && !bytes.HasPrefix(docXML[offset:], []byte("<w:p ")) {
+		return docXML
+	}
+	end := findElementEnd(docXML, offset, "p")
+	if end == -1 {
+		return docXML
+	}
+	para := docXML[offset:end]
+	if !bytes.Contains(paragraphProperties(para), []byte("<w:sectPr")) || len(paragraphRuns(para)) > 0 {
+		return docXML
+	}
+
+	blocks, err := bodyBlocks(docXML)
+	if err != nil {
+		return docXML
+	}
+	for i, block := range blocks {
+		if block.start != offset {
+			continue
+		}
+		if i > 0 {
+			prev := docXML[blocks[i-1].start:blocks[i-1].end]
+			if blocks[i-1].name != "w:p" || !bytes.Contains(paragraphProperties(prev), []byte("<w:sectPr")) {
+				return docXML
+			}
+		}
+		return spliceBytes(docXML, nil, offset, end)
+	}
+	return docXML
+}
+
+// removeEmptyHyperlinks unwraps the hyperlinks overlapping docXML[from:to]
+// that no longer contain a run, keeping other content such as bookmark
+// markers. It returns the updated document and the new offsets of from and
+// to.
+func removeEmptyHyperlinks(docXML []byte, from, to int) ([]byte, int, int) {
+	pos := from
+	if open := bytes.LastIndex(docXML[:from], []byte("<w:hyperlink")); open != -1 {
+		if end := findElementEnd(docXML, open, "hyperlink"); end > from {
+			pos = open
+		}
+	}
+
+	var empty []elementSpan
+	for {
+		start := findNextWordTagStart(docXML, pos, "hyperlink")
+		if start == -1 || start > to {
+			break
+		}
+		end := findElementEnd(docXML, start, "hyperlink")
+		if end == -1 {
+			break
+		}
+		if findNextWordTagStart(docXML[:end], start, "r") == -1 {
+			empty = append(empty, elementSpan{start: start, end: end, name: "w:hyperlink"})
+		}
+		pos = end
+	}
+
+	// Removed tags before an offset move it back
+	shift := func(offset, tagStart, tagEnd int) int {
+		if offset <= tagStart {
+			return offset
+		}
+		return offset - (min(offset, tagEnd) - tagStart)
+	}
+
+	for i := len(empty) - 1; i >= 0; i-- {
+		link := empty[i]
+		openEnd := link.start + bytes.IndexByte(docXML[link.start:], '>') + 1
+		closeStart := link.end
+		if docXML[openEnd-2] != '/' {
+			closeStart = link.end - len("</w:hyperlink>")
+		}
+		from = shift(shift(from, closeStart, link.end), link.start, openEnd)
+		to = shift(shift(to, closeStart, link.end), link.start, openEnd)
+		docXML = slices.Concat(docXML[:link.start], docXML[openEnd:closeStart], docXML[link.end:])
+	}
+	return docXML, from, to
+}
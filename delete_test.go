@@ -0,0 +1,445 @@
+package godocx_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+const deleteFixtureBody = `<w:p><w:r><w:t>Intro</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>[Instructions: fill in the intro]</w:t></w:r></w:p>` +
+	`<w:tbl><w:tr><w:tc><w:tcPr><w:tcW w:w="2000" w:type="dxa"/></w:tcPr><w:p><w:r><w:t>[Instructions: cell]</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Keep</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:p><w:pPr><w:sectPr><w:type w:val="nextPage"/></w:sectPr></w:pPr><w:r><w:t>Section one end</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>START</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>Sample paragraph</w:t></w:r></w:p>` +
+	`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Sample table</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:p><w:pPr><w:sectPr><w:type w:val="continuous"/></w:sectPr></w:pPr></w:p>` +
+	`<w:p><w:r><w:t>END</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t xml:space="preserve">Before </w:t></w:r><w:bookmarkStart w:id="5" w:name="Sample"/><w:r><w:t>inline sample</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>middle</w:t></w:r></w:p>` +
+	`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Mid table</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:p><w:r><w:t>more</w:t></w:r><w:bookmarkEnd w:id="5"/><w:r><w:t xml:space="preserve"> after</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>Closing</w:t></w:r></w:p>`
+
+func openDeleteFixture(t *testing.T) *godocx.Updater {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, deleteFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+	return u
+}
+
+func TestDeleteParagraphs(t *testing.T) {
+	u := openDeleteFixture(t)
+
+	count, err := u.DeleteParagraphs(`^\[Instructions:`, godocx.FindOptions{UseRegex: true})
+	if err != nil {
+		t.Fatalf("DeleteParagraphs failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 paragraphs deleted, got %d", count)
+	}
+
+	// The section break survives deletion of its paragraph text
+	if _, err := u.DeleteParagraphs("Section one end", godocx.DefaultFindOptions()); err != nil {
+		t.Fatalf("DeleteParagraphs failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if strings.Contains(doc, "Instructions") || strings.Contains(doc, "Section one end") {
+		t.Error("expected matching paragraphs to be deleted")
+	}
+	if !strings.Contains(doc, `</w:tcPr><w:p/></w:tc>`) {
+		t.Error("expected emptied cell to keep an empty paragraph")
+	}
+	if !strings.Contains(doc, `<w:p><w:pPr><w:sectPr><w:type w:val="nextPage"/></w:sectPr></w:pPr></w:p>`) {
+		t.Error("expected section properties to be kept in an empty paragraph")
+	}
+	assertOrder(t, doc, "Intro", "Keep", `w:val="nextPage"`, "START")
+
+	count, err = u.DeleteParagraphs("no such text", godocx.DefaultFindOptions())
+	if err != nil || count != 0 {
+		t.Errorf("expected no deletions, got %d (%v)", count, err)
+	}
+}
+
+func TestDeleteBetween(t *testing.T) {
+	u := openDeleteFixture(t)
+
+	if err := u.DeleteBetween("START", "END", false); err != nil {
+		t.Fatalf("DeleteBetween failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if strings.Contains(doc, "Sample paragraph") || strings.Contains(doc, "Sample table") {
+		t.Error("expected content between anchors to be deleted")
+	}
+	// START still belongs to the continuous section
+	assertOrder(t, doc, "START", `<w:sectPr><w:type w:val="continuous"/></w:sectPr>`, "END")
+
+	if err := u.DeleteBetween("START", "Missing", false); err == nil {
+		t.Error("expected error for missing end anchor")
+	}
+}
+
+func TestDeleteBetweenInclusive(t *testing.T) {
+	u := openDeleteFixture(t)
+
+	if err := u.DeleteBetween("Intro", "START", true); err != nil {
+		t.Fatalf("DeleteBetween failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `<w:body><w:p><w:r><w:t>Sample paragraph</w:t>`) {
+		t.Error("expected the body to start with the first paragraph after the deletion")
+	}
+	// The first section has no content left, so its break is removed
+	if strings.Contains(doc, "nextPage") {
+		t.Error("expected the empty section break to be removed")
+	}
+	if strings.Contains(doc, "Keep") {
+		t.Error("expected the table between the anchors to be deleted")
+	}
+}
+
+func TestDeleteBookmarkContent(t *testing.T) {
+	u := openDeleteFixture(t)
+
+	if err := u.DeleteBookmarkContent("Sample"); err != nil {
+		t.Fatalf("DeleteBookmarkContent failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	for _, removed := range []string{"inline sample", "middle", "Mid table", "more"} {
+		if strings.Contains(doc, removed) {
+			t.Errorf("expected %q to be deleted", removed)
+		}
+	}
+	assertOrder(t, doc, "Before ", `<w:bookmarkStart w:id="5" w:name="Sample"/></w:p>`, `<w:p><w:bookmarkEnd w:id="5"/>`, " after", "Closing")
+
+	if err := u.DeleteBookmarkContent("Missing"); err == nil {
+		t.Error("expected error for missing bookmark")
+	}
+}
+
+func TestDeleteParagraphAtAndIn(t *testing.T) {
+	body := `<w:p><w:r><w:t>Note: keep</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Note: drop</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Range text</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Block one</w:t></w:r></w:p>` +
+		`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Block cell</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`<w:p><w:r><w:t>Block two</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Closing</w:t></w:r></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	matches, err := u.FindText("Note:", godocx.DefaultFindOptions())
+	if err != nil || len(matches) != 2 {
+		t.Fatalf("FindText: %v, %d matches", err, len(matches))
+	}
+	if err := u.DeleteParagraphAt(matches[1]); err != nil {
+		t.Fatalf("DeleteParagraphAt failed: %v", err)
+	}
+
+	// A text range deletes its whole paragraph
+	r, err := u.FindRange("text", godocx.FindOptions{})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if count, err := u.DeleteParagraphsIn(r); err != nil || count != 1 {
+		t.Fatalf("DeleteParagraphsIn: %v, %d deleted", err, count)
+	}
+
+	// A block range deletes its paragraphs and tables
+	block, err := u.ParagraphRange(1, 2)
+	if err != nil {
+		t.Fatalf("ParagraphRange failed: %v", err)
+	}
+	if count, err := u.DeleteParagraphsIn(block); err != nil || count != 3 {
+		t.Fatalf("DeleteParagraphsIn: %v, %d deleted", err, count)
+	}
+	if err := block.InsertBefore("Replacement"); err != nil {
+		t.Fatalf("InsertBefore failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	for _, removed := range []string{"Note: drop", "Range text", "Block one", "Block cell", "Block two", "<w:tbl>"} {
+		if strings.Contains(doc, removed) {
+			t.Errorf("expected %q to be deleted", removed)
+		}
+	}
+	assertOrder(t, doc, "Note: keep", "Replacement", "Closing")
+}
+
+func TestDeleteBookmarkContentFromTableCell(t *testing.T) {
+	body := `<w:tbl><w:tr><w:tc><w:p><w:r><w:t xml:space="preserve">Cell before </w:t></w:r><w:bookmarkStart w:id="7" w:name="Span"/><w:r><w:t>cell inside</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>cell next</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Other cell</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`<w:p><w:r><w:t>Body inside</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>end inside</w:t></w:r><w:bookmarkEnd w:id="7"/><w:r><w:t>Tail</w:t></w:r></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	if err := u.DeleteBookmarkContent("Span"); err != nil {
+		t.Fatalf("DeleteBookmarkContent failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	for _, removed := range []string{"cell inside", "cell next", "Body inside", "end inside"} {
+		if strings.Contains(doc, removed) {
+			t.Errorf("expected %q to be deleted", removed)
+		}
+	}
+	assertOrder(t, doc, "Cell before ", `w:name="Span"/></w:p></w:tc>`, "Other cell", "</w:tbl>", `<w:p><w:bookmarkEnd w:id="7"/>`, "Tail")
+}
+
+func TestDeleteRemovesEmptyHyperlinks(t *testing.T) {
+	body := `<w:p><w:r><w:t xml:space="preserve">Link: </w:t></w:r><w:bookmarkStart w:id="8" w:name="Linked"/><w:hyperlink r:id="rId9"><w:r><w:t>site</w:t></w:r></w:hyperlink><w:bookmarkEnd w:id="8"/></w:p>` +
+		`<w:p><w:hyperlink w:anchor="Linked"><w:r><w:t>jump</w:t></w:r></w:hyperlink><w:r><w:t xml:space="preserve"> here</w:t></w:r></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	if err := u.DeleteBookmarkContent("Linked"); err != nil {
+		t.Fatalf("DeleteBookmarkContent failed: %v", err)
+	}
+	r, err := u.FindRange("jump", godocx.FindOptions{})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if err := r.Delete(); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := r.InsertAfter("Go"); err != nil {
+		t.Fatalf("InsertAfter failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if strings.Contains(doc, "<w:hyperlink") {
+		t.Errorf("expected emptied hyperlinks to be removed: %s", doc)
+	}
+	assertOrder(t, doc, "Link: ", `<w:bookmarkStart w:id="8" w:name="Linked"/><w:bookmarkEnd w:id="8"/></w:p>`, "<w:p>", "Go", " here")
+}
+
+func TestRangeReplaceRemovesEmptyHyperlinks(t *testing.T) {
+	body := `<w:p><w:r><w:t xml:space="preserve">Link: </w:t></w:r><w:hyperlink r:id="rId9"><w:r><w:t>site</w:t></w:r></w:hyperlink></w:p>` +
+		`<w:p><w:r><w:t xml:space="preserve">See </w:t></w:r><w:hyperlink w:anchor="Top"><w:r><w:t>top</w:t></w:r></w:hyperlink></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	// The new run takes the place of the first run, outside the hyperlink
+	r, err := u.FindRange("Link: site", godocx.FindOptions{})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if err := r.Replace("No link"); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if text, _ := r.Text(); text != "No link" {
+		t.Errorf("expected replaced range text, got %q", text)
+	}
+
+	// Empty text leaves no run behind
+	r, err = u.FindRange("top", godocx.FindOptions{})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if err := r.Replace(""); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if strings.Contains(doc, "<w:hyperlink") {
+		t.Errorf("expected emptied hyperlinks to be removed: %s", doc)
+	}
+	if !strings.Contains(doc, `<w:p><w:r><w:t>No link</w:t></w:r></w:p>`) {
+		t.Errorf("expected the replacement in place of the link: %s", doc)
+	}
+	if !strings.Contains(doc, `<w:p><w:r><w:t xml:space="preserve">See </w:t></w:r></w:p>`) {
+		t.Errorf("expected no empty run: %s", doc)
+	}
+}
+
+func TestDeleteChartImageAndTable(t *testing.T) {
+	body := `<w:p><w:r><w:t>Anchor</w:t></w:r></w:p>` +
+		`<w:tbl><w:tr><w:tc><w:p/></w:tc></w:tr></w:tbl>` +
+		`<w:p><w:r><w:t>Closing</w:t></w:r></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	for _, title := range []string{"First chart", "Second chart"} {
+		if err := u.InsertChart(godocx.ChartOptions{
+			Position:   godocx.PositionEnd,
+			Title:      title,
+			Categories: []string{"Q1", "Q2"},
+			Series:     []godocx.SeriesData{{Name: "Revenue", Values: []float64{1, 2}}},
+		}); err != nil {
+			t.Fatalf("InsertChart failed: %v", err)
+		}
+	}
+
+	imagePath := filepath.Join(t.TempDir(), "logo.png")
+	createTestImage(t, imagePath, 20, 10)
+	if err := u.InsertImage(godocx.ImageOptions{Path: imagePath, AltText: "Logo", Position: godocx.PositionAfterText, Anchor: "Anchor"}); err != nil {
+		t.Fatalf("InsertImage failed: %v", err)
+	}
+	if err := u.InsertImage(godocx.ImageOptions{Path: imagePath, Target: godocx.TargetInsideTableCell(1, 1, 1)}); err != nil {
+		t.Fatalf("InsertImage in table failed: %v", err)
+	}
+
+	if err := u.DeleteChart(1); err != nil {
+		t.Fatalf("DeleteChart failed: %v", err)
+	}
+	if err := u.DeleteImage("Logo"); err != nil {
+		t.Fatalf("DeleteImage failed: %v", err)
+	}
+	if err := u.DeleteTable(1); err != nil {
+		t.Fatalf("DeleteTable failed: %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "out.docx")
+	if err := u.Save(outPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	entries := strings.Join(listZipEntries(t, outPath), "\n")
+	for _, removed := range []string{
+		"word/charts/chart1.xml",
+		"word/charts/_rels/chart1.xml.rels",
+		"word/embeddings/Microsoft_Excel_Worksheet1.xlsx",
+		"word/media/image1.png",
+		"word/media/image2.png",
+	} {
+		if strings.Contains(entries, removed) {
+			t.Errorf("expected %s to be removed from the package", removed)
+		}
+	}
+	if !strings.Contains(entries, "word/charts/chart2.xml") || !strings.Contains(entries, "word/embeddings/Microsoft_Excel_Worksheet2.xlsx") {
+		t.Error("expected the second chart to be kept")
+	}
+
+	contentTypes := readZipEntry(t, outPath, "[Content_Types].xml")
+	if strings.Contains(contentTypes, "chart1.xml") || !strings.Contains(contentTypes, "chart2.xml") {
+		t.Errorf("unexpected content type overrides: %s", contentTypes)
+	}
+	rels := readZipEntry(t, outPath, "word/_rels/document.xml.rels")
+	if strings.Contains(rels, "chart1.xml") || strings.Contains(rels, "media/") || !strings.Contains(rels, "chart2.xml") {
+		t.Errorf("unexpected document relationships: %s", rels)
+	}
+
+	doc := readZipEntry(t, outPath, "word/document.xml")
+	if strings.Contains(doc, "<w:tbl>") || strings.Contains(doc, "<pic:pic") || strings.Count(doc, "<c:chart ") != 1 {
+		t.Error("expected only the second chart drawing to remain")
+	}
+	assertOrder(t, doc, "Anchor", "Closing", `name="Chart 2"`)
+
+	if err := u.DeleteChart(1); err == nil {
+		t.Error("expected error for deleted chart")
+	}
+	if err := u.DeleteImage("missing.png"); err == nil {
+		t.Error("expected error for missing image")
+	}
+	if err := u.DeleteTable(1); err == nil {
+		t.Error("expected error for missing table")
+	}
+}
+
+func TestDeleteTableRemovesCaption(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p><w:r><w:t>Intro</w:t></w:r></w:p>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	for i, position := range []godocx.CaptionPosition{godocx.CaptionBefore, godocx.CaptionAfter} {
+		if err := u.InsertTable(godocx.TableOptions{
+			Position: godocx.PositionEnd,
+			Columns:  []godocx.ColumnDefinition{{Title: "Item"}},
+			Rows:     [][]string{{fmt.Sprintf("Row %d", i+1)}},
+			Caption:  &godocx.CaptionOptions{Type: godocx.CaptionTable, Position: position, AutoNumber: true, Description: fmt.Sprintf("Caption %d", i+1)},
+		}); err != nil {
+			t.Fatalf("InsertTable failed: %v", err)
+		}
+	}
+
+	if err := u.DeleteTable(1); err != nil {
+		t.Fatalf("DeleteTable failed: %v", err)
+	}
+	doc := saveAndReadDocument(t, u)
+	if strings.Contains(doc, "Caption 1") || strings.Contains(doc, "Row 1") {
+		t.Error("expected the first table and its caption to be deleted")
+	}
+	assertOrder(t, doc, "Intro", "Row 2", "Caption 2")
+
+	if err := u.DeleteTable(1); err != nil {
+		t.Fatalf("DeleteTable failed: %v", err)
+	}
+	if doc := saveAndReadDocument(t, u); strings.Contains(doc, "SEQ Table") {
+		t.Error("expected the caption after the table to be deleted")
+	}
+}
+
+func TestDeleteVMLImage(t *testing.T) {
+	body := `<w:p><w:r><w:t>Legacy</w:t></w:r><w:r><w:pict><v:shape xmlns:v="urn:schemas-microsoft-com:vml" id="_x0000_i1025" alt="Old logo"><v:imagedata r:id="rId7" o:title=""/></v:shape></w:pict></w:r></w:p>` +
+		`<w:p><w:r><w:pict><v:shape xmlns:v="urn:schemas-microsoft-com:vml" id="_x0000_i1026"><v:imagedata xmlns:o="urn:schemas-microsoft-com:office:office" o:relid="rId8"/></v:shape></w:pict></w:r></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	rels := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId7" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/legacy1.png"/>` +
+		`<Relationship Id="rId8" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/legacy2.png"/></Relationships>`
+	if err := os.WriteFile(filepath.Join(u.TempDir(), "word", "_rels", "document.xml.rels"), []byte(rels), 0o644); err != nil {
+		t.Fatalf("write rels: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(u.TempDir(), "word", "media"), 0o755); err != nil {
+		t.Fatalf("create media folder: %v", err)
+	}
+	for _, name := range []string{"legacy1.png", "legacy2.png"} {
+		createTestImage(t, filepath.Join(u.TempDir(), "word", "media", name), 4, 4)
+	}
+
+	if err := u.DeleteImage("Old logo"); err != nil {
+		t.Fatalf("DeleteImage failed: %v", err)
+	}
+	if err := u.DeleteImage("legacy2.png"); err != nil {
+		t.Fatalf("DeleteImage failed: %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "out.docx")
+	if err := u.Save(outPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if entries := strings.Join(listZipEntries(t, outPath), "\n"); strings.Contains(entries, "legacy") {
+		t.Errorf("expected the VML images to be removed from the package: %s", entries)
+	}
+	if rels := readZipEntry(t, outPath, "word/_rels/document.xml.rels"); strings.Contains(rels, "rId7") || strings.Contains(rels, "rId8") {
+		t.Errorf("expected the VML image relationships to be removed: %s", rels)
+	}
+	doc := readZipEntry(t, outPath, "word/document.xml")
+	if strings.Contains(doc, "<w:pict>") || !strings.Contains(doc, "Legacy") {
+		t.Error("expected only the VML pictures to be removed")
+	}
+}
@@ -60,6 +60,9 @@ const (
 
 	// Header/Footer errors
 	ErrCodeHeaderFooter ErrorCode = "HEADER_FOOTER"
+
+	// Range errors
+	ErrCodeStaleRange ErrorCode = "STALE_RANGE"
 )
 
 // DocxError provides structured error information
@@ -230,3 +233,12 @@ func NewHeaderFooterError(reason string, err error) error {
 		Err:     err,
 	}
 }
+
+// NewStaleRangeError creates an error for a range whose content can no
+// longer be located in the document
+func NewStaleRangeError(reason string) error {
+	return &DocxError{
+		Code:    ErrCodeStaleRange,
+		Message: reason,
+	}
+}
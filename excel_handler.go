@@ -9,47 +9,27 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-func updateEmbeddedWorkbook(xlsxPath string, data ChartData) error {
+// updateEmbeddedWorkbook writes chart data to the first worksheet of an
+// embedded workbook, in the layout of a category chart or, for
+// ChartKindScatter and ChartKindBubble, of a scatter or bubble chart
+func updateEmbeddedWorkbook(xlsxPath string, data ChartData, kind ChartKind) error {
 	xlsxRaw, err := os.ReadFile(xlsxPath)
 	if err != nil {
 		return fmt.Errorf("read embedded workbook: %w", err)
 	}
 
-	zr, err := zip.NewReader(bytes.NewReader(xlsxRaw), int64(len(xlsxRaw)))
+	entries, names, err := readWorkbookEntries(xlsxRaw)
 	if err != nil {
-		return fmt.Errorf("open workbook zip: %w", err)
-	}
-
-	entries := make(map[string][]byte, len(zr.File))
-	names := make([]string, 0, len(zr.File))
-
-	for _, f := range zr.File {
-		rc, err := f.Open()
-		if err != nil {
-			return fmt.Errorf("open workbook entry %s: %w", f.Name, err)
-		}
-
-		content, err := io.ReadAll(rc)
-		if err != nil {
-			rc.Close()
-			return fmt.Errorf("read workbook entry %s: %w", f.Name, err)
-		}
-
-		if err := rc.Close(); err != nil {
-			return fmt.Errorf("close workbook entry %s: %w", f.Name, err)
-		}
-
-		entries[f.Name] = content
-		names = append(names, f.Name)
+		return err
 	}
 
-	sort.Strings(names)
-	worksheetPath, err := resolveWorksheetPath(entries, names)
+	worksheetPath, err := resolveWorksheetPath(entries, names, "")
 	if err != nil {
 		return err
 	}
@@ -69,7 +49,21 @@ func updateEmbeddedWorkbook(xlsxPath string, data ChartData) error {
 		stringIndexes = indexes
 	}
 
-	updatedWorksheet, err := updateWorksheetXML(entries[worksheetPath], data, useSharedStrings, stringIndexes)
+	// Date and numeric categories take a cell style with their number format.
+	// Without a styles part the cells are written unstyled.
+	categoryStyle := 0
+	if format := data.categoryData().cellFormat(); format != "" {
+		if stylesRaw, ok := entries["xl/styles.xml"]; ok {
+			updatedStyles, style, err := addCellFormatStyle(stylesRaw, format)
+			if err != nil {
+				return err
+			}
+			entries["xl/styles.xml"] = updatedStyles
+			categoryStyle = style
+		}
+	}
+
+	updatedWorksheet, err := updateWorksheetXML(entries[worksheetPath], data, kind, useSharedStrings, stringIndexes, categoryStyle)
 	if err != nil {
 		return err
 	}
@@ -100,6 +94,41 @@ func updateEmbeddedWorkbook(xlsxPath string, data ChartData) error {
 	return nil
 }
 
+// readWorkbookEntries reads the entries of an xlsx package into memory,
+// returning the contents by name and the sorted entry names
+func readWorkbookEntries(xlsxRaw []byte) (map[string][]byte, []string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(xlsxRaw), int64(len(xlsxRaw)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("open workbook zip: %w", err)
+	}
+
+	entries := make(map[string][]byte, len(zr.File))
+	names := make([]string, 0, len(zr.File))
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, nil, fmt.Errorf("open workbook entry %s: %w", f.Name, err)
+		}
+
+		content, err := io.ReadAll(rc)
+		if err != nil {
+			rc.Close()
+			return nil, nil, fmt.Errorf("read workbook entry %s: %w", f.Name, err)
+		}
+
+		if err := rc.Close(); err != nil {
+			return nil, nil, fmt.Errorf("close workbook entry %s: %w", f.Name, err)
+		}
+
+		entries[f.Name] = content
+		names = append(names, f.Name)
+	}
+
+	sort.Strings(names)
+	return entries, names, nil
+}
+
 func firstWorksheetPath(names []string) string {
 	for _, name := range names {
 		if strings.HasPrefix(name, "xl/worksheets/sheet") && strings.HasSuffix(name, ".xml") {
@@ -109,12 +138,17 @@ func firstWorksheetPath(names []string) string {
 	return ""
 }
 
-func resolveWorksheetPath(entries map[string][]byte, names []string) (string, error) {
+// resolveWorksheetPath returns the path of the named worksheet, or of the
+// first worksheet when sheet is empty
+func resolveWorksheetPath(entries map[string][]byte, names []string, sheet string) (string, error) {
 	const workbookPath = "xl/workbook.xml"
 	const relsPath = "xl/_rels/workbook.xml.rels"
 	workbookRaw, okWorkbook := entries[workbookPath]
 	relsRaw, okRels := entries[relsPath]
 	if !okWorkbook || !okRels {
+		if sheet != "" {
+			return "", fmt.Errorf("worksheet %q not found", sheet)
+		}
 		return firstWorksheetPath(names), nil
 	}
 
@@ -122,10 +156,21 @@ func resolveWorksheetPath(entries map[string][]byte, names []string) (string, er
 	if err := xml.Unmarshal(workbookRaw, &wb); err != nil {
 		return "", fmt.Errorf("parse workbook.xml: %w", err)
 	}
-	if len(wb.Sheets) == 0 {
+	if len(wb.Sheets) == 0 && sheet == "" {
 		return firstWorksheetPath(names), nil
 	}
 
+	selected := -1
+	for i, s := range wb.Sheets {
+		if sheet == "" || s.Name == sheet {
+			selected = i
+			break
+		}
+	}
+	if selected < 0 {
+		return "", fmt.Errorf("worksheet %q not found", sheet)
+	}
+
 	var rels relationships
 	if err := xml.Unmarshal(relsRaw, &rels); err != nil {
 		return "", fmt.Errorf("parse workbook.xml.rels: %w", err)
@@ -133,19 +178,18 @@ func resolveWorksheetPath(entries map[string][]byte, names []string) (string, er
 
 	target := ""
 	for _, rel := range rels.Relationships {
-		if rel.ID == wb.Sheets[0].RelID {
+		if rel.ID == wb.Sheets[selected].RelID {
 			target = rel.Target
 			break
 		}
 	}
-	if target == "" {
-		return firstWorksheetPath(names), nil
-	}
-
 	full := filepath.ToSlash(filepath.Clean(filepath.Join("xl", target)))
-	if _, ok := entries[full]; ok {
+	if _, ok := entries[full]; ok && target != "" {
 		return full, nil
 	}
+	if sheet != "" {
+		return "", fmt.Errorf("worksheet %q has no part", sheet)
+	}
 	return firstWorksheetPath(names), nil
 }
 
@@ -154,12 +198,16 @@ type workbookXML struct {
 }
 
 type workbookSheet struct {
+	Name  string `xml:"name,attr"`
 	RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
 }
 
-func updateWorksheetXML(existing []byte, data ChartData, useSharedStrings bool, stringIndexes map[string]int) ([]byte, error) {
+func updateWorksheetXML(existing []byte, data ChartData, kind ChartKind, useSharedStrings bool, stringIndexes map[string]int, categoryStyle int) ([]byte, error) {
 	updated := string(existing)
-	newSheetData := buildSheetDataXML(data, useSharedStrings, stringIndexes)
+	newSheetData := buildSheetDataXML(data, useSharedStrings, stringIndexes, categoryStyle)
+	if isXYChartKind(kind) {
+		newSheetData = buildXYSheetDataXML(data, kind, useSharedStrings, stringIndexes)
+	}
 
 	reSheetData := regexp.MustCompile(`(?s)<sheetData\b[^>]*>.*?</sheetData>`)
 	if !reSheetData.MatchString(updated) {
@@ -167,8 +215,17 @@ func updateWorksheetXML(existing []byte, data ChartData, useSharedStrings bool,
 	}
 	updated = reSheetData.ReplaceAllString(updated, newSheetData)
 
-	lastCol := columnLetters(len(data.Series) + 1)
-	lastRow := len(data.Categories) + 1
+	categories := data.categoryData()
+	lastCol := columnLetters(categories.columns() + len(data.Series))
+	lastRow := categories.count() + 1
+	if isXYChartKind(kind) {
+		_, y, size := seriesColumns(kind, len(data.Series)-1, 0)
+		lastCol = columnLetters(max(y, size))
+		lastRow = 1
+		for _, s := range data.Series {
+			lastRow = max(lastRow, len(s.Values)+1)
+		}
+	}
 	newDimension := `<dimension ref="A1:` + lastCol + strconv.Itoa(lastRow) + `"/>`
 
 	reDimension := regexp.MustCompile(`<dimension\b[^>]*ref=\"[^\"]*\"[^>]*/>`)
@@ -179,28 +236,92 @@ func updateWorksheetXML(existing []byte, data ChartData, useSharedStrings bool,
 	return []byte(updated), nil
 }
 
-func buildSheetDataXML(data ChartData, useSharedStrings bool, stringIndexes map[string]int) string {
+// buildSheetDataXML builds the sheet data of a chart: the categories from
+// column A, outer levels first, and a column per series. Outer level labels
+// are written at the start of their groups only, as Excel reads them.
+func buildSheetDataXML(data ChartData, useSharedStrings bool, stringIndexes map[string]int, categoryStyle int) string {
 	var b strings.Builder
 	b.WriteString(`<sheetData>`)
 
-	// Header row: A1 blank, then series names.
+	categories := data.categoryData()
+	catCol := categories.columns()
+
+	// Header row: category headers blank, then series names.
 	b.WriteString(`<row r="1">`)
-	b.WriteString(stringCell("A1", "", useSharedStrings, stringIndexes))
+	for c := 1; c <= catCol; c++ {
+		b.WriteString(stringCell(cellRef(c, 1), "", useSharedStrings, stringIndexes))
+	}
 	for i, s := range data.Series {
-		b.WriteString(stringCell(cellRef(i+2, 1), s.Name, useSharedStrings, stringIndexes))
+		b.WriteString(stringCell(cellRef(catCol+i+1, 1), s.Name, useSharedStrings, stringIndexes))
 	}
 	b.WriteString(`</row>`)
 
-	for rowIdx, cat := range data.Categories {
+	values := categories.values()
+	for rowIdx := range categories.count() {
 		r := rowIdx + 2
 		b.WriteString(`<row r="` + strconv.Itoa(r) + `">`)
-		b.WriteString(stringCell(cellRef(1, r), cat, useSharedStrings, stringIndexes))
+		for level := range categories.levels {
+			if label, ok := categories.groupLabel(level, rowIdx); ok {
+				b.WriteString(stringCell(cellRef(level+1, r), label, useSharedStrings, stringIndexes))
+			}
+		}
+		if categories.numeric() {
+			b.WriteString(styledNumberCell(cellRef(catCol, r), values[rowIdx], categoryStyle))
+		} else {
+			b.WriteString(stringCell(cellRef(catCol, r), categories.labels[rowIdx], useSharedStrings, stringIndexes))
+		}
 		for sIdx, s := range data.Series {
 			value := 0.0
 			if rowIdx < len(s.Values) {
 				value = s.Values[rowIdx]
 			}
-			b.WriteString(numberCell(cellRef(sIdx+2, r), value))
+			if IsMissing(value) {
+				continue // Empty cell
+			}
+			b.WriteString(numberCell(cellRef(catCol+sIdx+1, r), value))
+		}
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData>`)
+	return b.String()
+}
+
+// buildXYSheetDataXML builds the sheet data of a scatter or bubble chart:
+// each series has an X column, a Y column headed with the series name and,
+// for bubble charts, a size column
+func buildXYSheetDataXML(data ChartData, kind ChartKind, useSharedStrings bool, stringIndexes map[string]int) string {
+	var b strings.Builder
+	b.WriteString(`<sheetData>`)
+
+	b.WriteString(`<row r="1">`)
+	rows := 0
+	for i, s := range data.Series {
+		x, y, size := seriesColumns(kind, i, 0)
+		b.WriteString(stringCell(cellRef(x, 1), "X", useSharedStrings, stringIndexes))
+		b.WriteString(stringCell(cellRef(y, 1), s.Name, useSharedStrings, stringIndexes))
+		if size != 0 {
+			b.WriteString(stringCell(cellRef(size, 1), "Size", useSharedStrings, stringIndexes))
+		}
+		rows = max(rows, len(s.Values))
+	}
+	b.WriteString(`</row>`)
+
+	for rowIdx := range rows {
+		r := rowIdx + 2
+		b.WriteString(`<row r="` + strconv.Itoa(r) + `">`)
+		for i, s := range data.Series {
+			if rowIdx >= len(s.Values) {
+				continue
+			}
+			x, y, size := seriesColumns(kind, i, 0)
+			b.WriteString(numberCell(cellRef(x, r), s.XValues[rowIdx]))
+			if !IsMissing(s.Values[rowIdx]) {
+				b.WriteString(numberCell(cellRef(y, r), s.Values[rowIdx]))
+			}
+			if size != 0 {
+				b.WriteString(numberCell(cellRef(size, r), s.BubbleSizes[rowIdx]))
+			}
 		}
 		b.WriteString(`</row>`)
 	}
@@ -230,6 +351,95 @@ func numberCell(ref string, value float64) string {
 	return `<c r="` + ref + `"><v>` + strconv.FormatFloat(value, 'f', -1, 64) + `</v></c>`
 }
 
+// styledNumberCell is numberCell with a cell style index, 0 for the default
+func styledNumberCell(ref string, value float64, style int) string {
+	if style == 0 {
+		return numberCell(ref, value)
+	}
+	return `<c r="` + ref + `" s="` + strconv.Itoa(style) + `"><v>` + strconv.FormatFloat(value, 'f', -1, 64) + `</v></c>`
+}
+
+// addCellFormatStyle adds a cell style with the given number format to a
+// styles part, returning the part and the index of the style. A number
+// format and a plain cell style using it already in the part are reused.
+func addCellFormatStyle(styles []byte, format string) ([]byte, int, error) {
+	// Number format: the existing one, or the next custom ID (from 164)
+	numFmtID, nextID := -1, 164
+	for pos := 0; ; {
+		start := findNextTagStart(styles, pos, "numFmt")
+		if start == -1 {
+			break
+		}
+		end := findQualifiedElementEnd(styles, start, "numFmt")
+		if end == -1 {
+			return nil, 0, fmt.Errorf("styles.xml has an unclosed numFmt element")
+		}
+		id, _ := strconv.Atoi(xmlAttr(styles[start:end], "numFmtId"))
+		if numFmtID == -1 && xmlAttr(styles[start:end], "formatCode") == format {
+			numFmtID = id
+		}
+		nextID = max(nextID, id+1)
+		pos = end
+	}
+
+	var err error
+	if numFmtID == -1 {
+		numFmtID = nextID
+		if findNextTagStart(styles, 0, "numFmts") == -1 {
+			// Number formats come first in the style sheet
+			start := findNextTagStart(styles, 0, "styleSheet")
+			if start == -1 {
+				return nil, 0, fmt.Errorf("styles.xml has no styleSheet element")
+			}
+			openEnd := start + bytes.IndexByte(styles[start:], '>') + 1
+			styles = slices.Concat(styles[:openEnd], []byte(`<numFmts count="0"/>`), styles[openEnd:])
+		}
+		numFmt := `<numFmt numFmtId="` + strconv.Itoa(numFmtID) + `" formatCode="` + xmlEscape(format) + `"/>`
+		if styles, _, err = appendStyleListChild(styles, "numFmts", []byte(numFmt)); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Cell style
+	xf := `<xf numFmtId="` + strconv.Itoa(numFmtID) + `" fontId="0" fillId="0" borderId="0" applyNumberFormat="1"/>`
+	if start := findNextTagStart(styles, 0, "cellXfs"); start != -1 {
+		openEnd := start + bytes.IndexByte(styles[start:], '>') + 1
+		if end := findQualifiedElementEnd(styles, start, "cellXfs"); end != -1 && openEnd < end {
+			for i, child := range childElements(styles, openEnd, end) {
+				if string(styles[child.start:child.end]) == xf {
+					return styles, i, nil
+				}
+			}
+		}
+	}
+	return appendStyleListChild(styles, "cellXfs", []byte(xf))
+}
+
+// appendStyleListChild appends a child to a list element of a styles part,
+// such as numFmts or cellXfs, and updates its count. It returns the part
+// and the index of the child.
+func appendStyleListChild(styles []byte, list string, child []byte) ([]byte, int, error) {
+	start := findNextTagStart(styles, 0, list)
+	if start == -1 {
+		return nil, 0, fmt.Errorf("styles.xml has no %s element", list)
+	}
+	end := findQualifiedElementEnd(styles, start, list)
+	if end == -1 {
+		return nil, 0, fmt.Errorf("styles.xml has an unclosed %s element", list)
+	}
+
+	var children []byte
+	count := 0
+	if openEnd := start + bytes.IndexByte(styles[start:], '>') + 1; openEnd < end {
+		closeStart := bytes.LastIndex(styles[:end], []byte("</"))
+		children = styles[openEnd:closeStart]
+		count = len(childElements(styles, openEnd, closeStart))
+	}
+
+	element := `<` + list + ` count="` + strconv.Itoa(count+1) + `">` + string(children) + string(child) + `</` + list + `>`
+	return slices.Concat(styles[:start], []byte(element), styles[end:]), count, nil
+}
+
 // cellRef and columnLetters are now in helpers.go
 
 type sharedStringTable struct {
@@ -241,9 +451,25 @@ type sharedStringTable struct {
 }
 
 type sharedStringItem struct {
+	T string            `xml:"t"`
+	R []sharedStringRun `xml:"r,omitempty"`
+}
+
+// sharedStringRun is a run of rich text in a shared string
+type sharedStringRun struct {
 	T string `xml:"t"`
 }
 
+// text returns the plain text of the shared string, joining rich text runs
+func (item sharedStringItem) text() string {
+	var sb strings.Builder
+	sb.WriteString(item.T)
+	for _, r := range item.R {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
 func updateSharedStringsXML(existing []byte, data ChartData) ([]byte, map[string]int, error) {
 	var parsed sharedStringTable
 	if err := xml.Unmarshal(existing, &parsed); err != nil {
@@ -273,7 +499,7 @@ func updateSharedStringsXML(existing []byte, data ChartData) ([]byte, map[string
 	for _, s := range data.Series {
 		appendIfMissing(s.Name)
 	}
-	for _, c := range data.Categories {
+	for _, c := range data.categoryData().allLabels() {
 		appendIfMissing(c)
 	}
 	parsed.Count = len(parsed.SI)
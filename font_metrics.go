@@ -0,0 +1,310 @@
+package godocx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+)
+
+// FontMetrics holds the advance widths of the glyphs of a font, used to
+// measure text for MeasuredColumnWidths. Load a TrueType or OpenType font
+// with LoadFontMetrics or ParseFontMetrics.
+type FontMetrics struct {
+	unitsPerEm int
+	advances   []uint16        // Advance width by glyph index
+	glyphs     map[rune]uint16 // Glyph index by character
+	groups     []cmapGroup     // Glyph ranges of a format 12 cmap, by start
+	wideGlyph  uint16          // Glyph of unmapped wide characters, or 0
+}
+
+// cmapGroup maps the characters start to end to consecutive glyphs from
+// glyph
+type cmapGroup struct {
+	start, end, glyph uint32
+}
+
+// LoadFontMetrics reads the metrics of a TrueType (.ttf) or OpenType (.otf)
+// font file
+func LoadFontMetrics(path string) (*FontMetrics, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read font: %w", err)
+	}
+	return ParseFontMetrics(data)
+}
+
+// ParseFontMetrics parses the metrics of a TrueType or OpenType font from
+// its contents. Only the head, hhea, hmtx and cmap tables are read.
+func ParseFontMetrics(data []byte) (*FontMetrics, error) {
+	tables, err := readFontTables(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "cmap"} {
+		if tables[tag] == nil {
+			return nil, fmt.Errorf("parse font: missing %s table", tag)
+		}
+	}
+
+	head, hhea, hmtx := tables["head"], tables["hhea"], tables["hmtx"]
+	if len(head) < 20 || len(hhea) < 36 {
+		return nil, fmt.Errorf("parse font: truncated head or hhea table")
+	}
+	unitsPerEm := int(binary.BigEndian.Uint16(head[18:]))
+	if unitsPerEm == 0 {
+		return nil, fmt.Errorf("parse font: units per em is 0")
+	}
+
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if numMetrics == 0 || len(hmtx) < numMetrics*4 {
+		return nil, fmt.Errorf("parse font: truncated hmtx table")
+	}
+	advances := make([]uint16, numMetrics)
+	for i := range advances {
+		advances[i] = binary.BigEndian.Uint16(hmtx[i*4:])
+	}
+
+	glyphs, groups, err := readFontCmap(tables["cmap"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &FontMetrics{unitsPerEm: unitsPerEm, advances: advances, glyphs: glyphs, groups: groups}, nil
+}
+
+// readFontTables returns the tables of an sfnt font by tag
+func readFontTables(data []byte) (map[string][]byte, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("parse font: file too short")
+	}
+	switch version := binary.BigEndian.Uint32(data); version {
+	case 0x00010000, 0x74727565, 0x4F54544F: // TrueType, 'true', 'OTTO'
+	default:
+		return nil, fmt.Errorf("parse font: unsupported font format %08X", version)
+	}
+
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+numTables*16 {
+		return nil, fmt.Errorf("parse font: truncated table directory")
+	}
+
+	tables := make(map[string][]byte, numTables)
+	for i := range numTables {
+		record := data[12+i*16:]
+		offset := int(binary.BigEndian.Uint32(record[8:]))
+		length := int(binary.BigEndian.Uint32(record[12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("parse font: table %s out of bounds", record[:4])
+		}
+		tables[string(record[:4])] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+// readFontCmap maps characters to glyph indexes using the best Unicode
+// subtable of a cmap table: format 12 (full Unicode) or format 4 (BMP).
+// Format 12 groups are kept as ranges, since one group can cover every
+// character.
+func readFontCmap(cmap []byte) (map[rune]uint16, []cmapGroup, error) {
+	if len(cmap) < 4 {
+		return nil, nil, fmt.Errorf("parse font: truncated cmap table")
+	}
+
+	var best []byte
+	bestRank := 0
+	numTables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := range numTables {
+		if len(cmap) < 4+(i+1)*8 {
+			return nil, nil, fmt.Errorf("parse font: truncated cmap table")
+		}
+		record := cmap[4+i*8:]
+		platform := binary.BigEndian.Uint16(record)
+		encoding := binary.BigEndian.Uint16(record[2:])
+		offset := int(binary.BigEndian.Uint32(record[4:]))
+		if offset+2 > len(cmap) {
+			continue
+		}
+		subtable := cmap[offset:]
+
+		rank := 0
+		switch format := binary.BigEndian.Uint16(subtable); {
+		case format == 12 && (platform == 0 || platform == 3 && encoding == 10):
+			rank = 2
+		case format == 4 && (platform == 0 || platform == 3 && encoding == 1):
+			rank = 1
+		}
+		if rank > bestRank {
+			best, bestRank = subtable, rank
+		}
+	}
+
+	glyphs := make(map[rune]uint16)
+	switch bestRank {
+	case 2:
+		if len(best) < 16 {
+			return nil, nil, fmt.Errorf("parse font: truncated cmap subtable")
+		}
+		groups := int(binary.BigEndian.Uint32(best[12:]))
+		if len(best) < 16+groups*12 {
+			return nil, nil, fmt.Errorf("parse font: truncated cmap subtable")
+		}
+		ranges := make([]cmapGroup, 0, groups)
+		for i := range groups {
+			group := best[16+i*12:]
+			start := binary.BigEndian.Uint32(group)
+			end := min(binary.BigEndian.Uint32(group[4:]), 0x10FFFF)
+			if start <= end {
+				ranges = append(ranges, cmapGroup{start: start, end: end, glyph: binary.BigEndian.Uint32(group[8:])})
+			}
+		}
+		// Groups are sorted by the spec, but lookups rely on it
+		slices.SortFunc(ranges, func(a, b cmapGroup) int { return int(a.start) - int(b.start) })
+		return glyphs, ranges, nil
+	case 1:
+		if len(best) < 14 {
+			return nil, nil, fmt.Errorf("parse font: truncated cmap subtable")
+		}
+		segCount := int(binary.BigEndian.Uint16(best[6:])) / 2
+		if len(best) < 16+segCount*8 {
+			return nil, nil, fmt.Errorf("parse font: truncated cmap subtable")
+		}
+		ends := best[14:]
+		starts := best[16+segCount*2:]
+		deltas := best[16+segCount*4:]
+		rangeOffsets := best[16+segCount*6:]
+		for i := range segCount {
+			start := int(binary.BigEndian.Uint16(starts[i*2:]))
+			end := int(binary.BigEndian.Uint16(ends[i*2:]))
+			delta := binary.BigEndian.Uint16(deltas[i*2:])
+			rangeOffset := int(binary.BigEndian.Uint16(rangeOffsets[i*2:]))
+			for c := start; c <= end && c != 0xFFFF; c++ {
+				glyph := uint16(c) + delta
+				if rangeOffset != 0 {
+					// The offset is relative to its own position in the array
+					at := 16 + segCount*6 + i*2 + rangeOffset + (c-start)*2
+					if at+2 > len(best) {
+						continue
+					}
+					if glyph = binary.BigEndian.Uint16(best[at:]); glyph != 0 {
+						glyph += delta
+					}
+				}
+				if glyph != 0 {
+					glyphs[rune(c)] = glyph
+				}
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("parse font: no Unicode cmap subtable")
+	}
+	return glyphs, nil, nil
+}
+
+// glyph returns the glyph index of a character. Missing characters use
+// glyph 0 (.notdef), or the wide glyph for wide characters.
+func (f *FontMetrics) glyph(r rune) uint16 {
+	if glyph, ok := f.glyphs[r]; ok {
+		return glyph
+	}
+	c := uint32(r)
+	i := sort.Search(len(f.groups), func(i int) bool { return f.groups[i].end >= c })
+	if i < len(f.groups) && f.groups[i].start <= c {
+		return uint16(f.groups[i].glyph + c - f.groups[i].start)
+	}
+	if f.wideGlyph != 0 && isWideRune(r) {
+		return f.wideGlyph
+	}
+	return 0
+}
+
+// advance returns the advance width of a character in font units
+func (f *FontMetrics) advance(r rune) int {
+	glyph := int(f.glyph(r))
+	if glyph >= len(f.advances) {
+		// Glyphs past the metrics share the last advance width
+		glyph = len(f.advances) - 1
+	}
+	return int(f.advances[glyph])
+}
+
+// measure returns the width of text in twips at a font size in half-points
+func (f *FontMetrics) measure(text string, halfPoints int) int {
+	units := 0
+	for _, r := range text {
+		units += f.advance(r)
+	}
+	// One point is 20 twips, so one half-point is 10
+	return (units*halfPoints*10 + f.unitsPerEm - 1) / f.unitsPerEm
+}
+
+// builtinFontMetrics returns metrics with the widths of Arial (Helvetica
+// compatible) regular or bold, for characters from space to tilde. East Asian
+// wide and fullwidth characters get a full em and other characters the width
+// of a digit.
+func builtinFontMetrics(bold bool) *FontMetrics {
+	widths := arialWidths
+	if bold {
+		widths = arialBoldWidths
+	}
+
+	// Glyph 0 is the fallback width; glyph i+1 is character ' '+i
+	f := &FontMetrics{unitsPerEm: 1000, advances: []uint16{556}, glyphs: make(map[rune]uint16, len(widths))}
+	for i, w := range widths {
+		f.advances = append(f.advances, w)
+		f.glyphs[rune(' '+i)] = uint16(i + 1)
+	}
+	f.wideGlyph = uint16(len(f.advances))
+	f.advances = append(f.advances, 1000)
+	return f
+}
+
+// wideRanges are the main blocks of East Asian wide and fullwidth characters
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo initial consonants
+	{0x2E80, 0x303E},   // CJK radicals, Kangxi radicals, CJK symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, Hangul compatibility Jamo, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK unified ideographs extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi syllables and radicals
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Pictographs and emoticons
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x20000, 0x3FFFD}, // CJK unified ideographs extensions B and later
+}
+
+// isWideRune reports whether r is an East Asian wide or fullwidth character
+func isWideRune(r rune) bool {
+	for _, wide := range wideRanges {
+		if r >= wide[0] && r <= wide[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// arialWidths are the advance widths of Arial for ' ' to '~' in 1/1000 em
+var arialWidths = []uint16{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // ' ' to '/'
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // '0' to '?'
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // '@' to 'O'
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // 'P' to '_'
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // '`' to 'o'
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // 'p' to '~'
+}
+
+// arialBoldWidths are the advance widths of Arial Bold for ' ' to '~' in
+// 1/1000 em
+var arialBoldWidths = []uint16{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278, // ' ' to '/'
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611, // '0' to '?'
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778, // '@' to 'O'
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556, // 'P' to '_'
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611, // '`' to 'o'
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584, // 'p' to '~'
+}
@@ -13,9 +13,20 @@ type HyperlinkOptions struct {
 	// Position where to insert the hyperlink
 	Position InsertPosition
 
-	// Anchor text for position-based insertion (for PositionAfterText/PositionBeforeText)
+	// Target overrides Position and Anchor with an insertion point such as
+	// a FindText match, a table cell or a Range
+	Target *Target
+
+	// Anchor text for position-based insertion (for PositionAfterText/PositionBeforeText),
+	// or bookmark name for PositionAfterBookmark/PositionBeforeBookmark
 	Anchor string
 
+	// Which paragraph containing Anchor to use (1-based, default: first)
+	Occurrence int
+
+	// Treat Anchor as a regular expression
+	AnchorRegex bool
+
 	// Tooltip text shown on hover
 	Tooltip string
 
@@ -280,24 +291,7 @@ func (u *Updater) generateInternalHyperlinkXML(text, bookmarkName string, opts H
 
 // insertHyperlinkAtPosition inserts hyperlink at the specified position
 func (u *Updater) insertHyperlinkAtPosition(docXML, hyperlinkXML []byte, opts HyperlinkOptions) ([]byte, error) {
-	switch opts.Position {
-	case PositionBeginning:
-		return insertAtBodyStart(docXML, hyperlinkXML)
-	case PositionEnd:
-		return insertAtBodyEnd(docXML, hyperlinkXML)
-	case PositionAfterText:
-		if opts.Anchor == "" {
-			return nil, NewValidationError("anchor", "anchor text required for PositionAfterText")
-		}
-		return insertAfterText(docXML, hyperlinkXML, opts.Anchor)
-	case PositionBeforeText:
-		if opts.Anchor == "" {
-			return nil, NewValidationError("anchor", "anchor text required for PositionBeforeText")
-		}
-		return insertBeforeText(docXML, hyperlinkXML, opts.Anchor)
-	default:
-		return insertAtBodyEnd(docXML, hyperlinkXML)
-	}
+	return insertAtPosition(docXML, hyperlinkXML, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
 }
 
 // validateURL checks if the URL is valid
@@ -9,6 +9,7 @@ import (
 	_ "image/png"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -37,37 +38,9 @@ func (u *Updater) InsertImage(opts ImageOptions) error {
 	// Calculate final dimensions (with proportions if needed)
 	finalDims := calculateProportionalDimensions(actualDims, opts.Width, opts.Height)
 
-	// Get next image index
-	imageIndex, err := u.getNextImageIndex()
+	imageXML, err := u.addImagePart(opts.Path, finalDims, opts.AltText)
 	if err != nil {
-		return fmt.Errorf("get next image index: %w", err)
-	}
-
-	// Determine content type and file extension
-	contentType := getImageContentType(opts.Path)
-	ext := strings.ToLower(filepath.Ext(opts.Path))
-
-	// Copy image to media folder
-	imageFileName := fmt.Sprintf("image%d%s", imageIndex, ext)
-	if err := u.copyImageToMedia(opts.Path, imageFileName); err != nil {
-		return fmt.Errorf("copy image to media: %w", err)
-	}
-
-	// Add relationship for the image
-	relId, err := u.addImageRelationship(imageFileName)
-	if err != nil {
-		return fmt.Errorf("add image relationship: %w", err)
-	}
-
-	// Add content type for the image
-	if err := u.addImageContentType(ext, contentType); err != nil {
-		return fmt.Errorf("add image content type: %w", err)
-	}
-
-	// Generate image drawing XML
-	imageXML, err := u.generateImageDrawingXML(imageIndex, relId, finalDims, opts.AltText)
-	if err != nil {
-		return fmt.Errorf("generate image drawing: %w", err)
+		return err
 	}
 
 	// Read document.xml
@@ -113,6 +86,109 @@ func (u *Updater) InsertImage(opts ImageOptions) error {
 	return nil
 }
 
+// addImagePart copies an image into the package, registers it with the
+// document and returns the paragraph with its inline drawing
+func (u *Updater) addImagePart(imagePath string, dims ImageDimensions, altText string) ([]byte, error) {
+	// Get next image index
+	imageIndex, err := u.getNextImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("get next image index: %w", err)
+	}
+
+	// Determine content type and file extension
+	contentType := getImageContentType(imagePath)
+	ext := strings.ToLower(filepath.Ext(imagePath))
+
+	// Copy image to media folder
+	imageFileName := fmt.Sprintf("image%d%s", imageIndex, ext)
+	if err := u.copyImageToMedia(imagePath, imageFileName); err != nil {
+		return nil, fmt.Errorf("copy image to media: %w", err)
+	}
+
+	// Add relationship for the image
+	relId, err := u.addImageRelationship(imageFileName)
+	if err != nil {
+		return nil, fmt.Errorf("add image relationship: %w", err)
+	}
+
+	// Add content type for the image
+	if err := u.addImageContentType(ext, contentType); err != nil {
+		return nil, fmt.Errorf("add image content type: %w", err)
+	}
+
+	// Generate image drawing XML
+	imageXML, err := u.generateImageDrawingXML(imageIndex, relId, dims, altText)
+	if err != nil {
+		return nil, fmt.Errorf("generate image drawing: %w", err)
+	}
+
+	return imageXML, nil
+}
+
+// DeleteImage removes the first image in the document body that matches ref.
+// ref is the media file name (e.g., "image1.png"), the relationship ID, or the
+// image name or alt text. The media file is removed from the package when no
+// other image uses it.
+func (u *Updater) DeleteImage(ref string) error {
+	if u == nil {
+		return fmt.Errorf("updater is nil")
+	}
+	if ref == "" {
+		return NewValidationError("ref", "image reference cannot be empty")
+	}
+
+	rels, err := readRelationships(filepath.Join(u.tempDir, "word", "_rels", "document.xml.rels"))
+	if err != nil {
+		return err
+	}
+	targets := make(map[string]string)
+	for _, rel := range rels.Relationships {
+		targets[rel.ID] = rel.Target
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return err
+	}
+
+	updated, removed := removeDrawings(docXML, func(drawingXML []byte) bool {
+		// DrawingML pictures reference the image from a:blip, VML pictures
+		// from v:imagedata
+		relID := xmlAttr(startTag(drawingXML, "<a:blip "), "r:embed")
+		if relID == "" {
+			imageData := startTag(drawingXML, "<v:imagedata ")
+			relID = xmlAttr(imageData, "r:id")
+			if relID == "" {
+				relID = xmlAttr(imageData, "o:relid")
+			}
+		}
+		if relID == "" {
+			return false
+		}
+		if relID == ref || path.Base(targets[relID]) == ref {
+			return true
+		}
+
+		if docPrTag := startTag(drawingXML, "<wp:docPr "); docPrTag != nil {
+			return xmlAttr(docPrTag, "name") == ref || xmlAttr(docPrTag, "descr") == ref
+		}
+		shapeTag := startTag(drawingXML, "<v:shape ")
+		return shapeTag != nil && xmlAttr(shapeTag, "alt") == ref
+	}, 1)
+	if removed == nil {
+		return NewImageNotFoundError(ref)
+	}
+
+	if err := u.writeDocumentXML(updated); err != nil {
+		return err
+	}
+	if err := u.releaseRelationships(updated, relationshipRefs(removed)); err != nil {
+		return fmt.Errorf("remove image parts: %w", err)
+	}
+
+	return nil
+}
+
 // getImageDimensions reads the image file and returns its dimensions in pixels
 func getImageDimensions(path string) (ImageDimensions, error) {
 	file, err := os.Open(path)
@@ -362,94 +438,5 @@ func (u *Updater) copyImageToMedia(srcPath, destFileName string) error {
 
 // insertImageAtPosition inserts the image XML at the specified position in document.xml
 func insertImageAtPosition(raw []byte, imageXML []byte, opts ImageOptions) ([]byte, error) {
-	bodyStart := []byte("<w:body>")
-	bodyEnd := []byte("</w:body>")
-
-	startIdx := bytes.Index(raw, bodyStart)
-	endIdx := bytes.LastIndex(raw, bodyEnd)
-	if startIdx == -1 || endIdx == -1 {
-		return nil, fmt.Errorf("invalid document.xml: missing <w:body>")
-	}
-	startIdx += len(bodyStart)
-
-	switch opts.Position {
-	case PositionBeginning:
-		// Insert at the beginning of body
-		result := make([]byte, 0, len(raw)+len(imageXML))
-		result = append(result, raw[:startIdx]...)
-		result = append(result, imageXML...)
-		result = append(result, raw[startIdx:]...)
-		return result, nil
-
-	case PositionEnd:
-		// Insert at the end of body (before </w:body>)
-		result := make([]byte, 0, len(raw)+len(imageXML))
-		result = append(result, raw[:endIdx]...)
-		result = append(result, imageXML...)
-		result = append(result, raw[endIdx:]...)
-		return result, nil
-
-	case PositionAfterText:
-		if opts.Anchor == "" {
-			return nil, fmt.Errorf("anchor text required for PositionAfterText")
-		}
-		// Find the anchor text and insert after its paragraph
-		return insertAfterAnchor(raw, imageXML, opts.Anchor)
-
-	case PositionBeforeText:
-		if opts.Anchor == "" {
-			return nil, fmt.Errorf("anchor text required for PositionBeforeText")
-		}
-		// Find the anchor text and insert before its paragraph
-		return insertBeforeAnchor(raw, imageXML, opts.Anchor)
-
-	default:
-		return nil, fmt.Errorf("invalid position: %d", opts.Position)
-	}
-}
-
-// insertAfterAnchor inserts the image XML after the paragraph containing the anchor text
-func insertAfterAnchor(raw []byte, imageXML []byte, anchor string) ([]byte, error) {
-	anchorBytes := []byte(anchor)
-	pos := bytes.Index(raw, anchorBytes)
-	if pos == -1 {
-		return nil, fmt.Errorf("anchor text not found: %s", anchor)
-	}
-
-	// Find the end of the paragraph containing the anchor
-	paraEnd := []byte("</w:p>")
-	endPos := bytes.Index(raw[pos:], paraEnd)
-	if endPos == -1 {
-		return nil, fmt.Errorf("paragraph end not found after anchor text")
-	}
-	insertPos := pos + endPos + len(paraEnd)
-
-	result := make([]byte, 0, len(raw)+len(imageXML))
-	result = append(result, raw[:insertPos]...)
-	result = append(result, imageXML...)
-	result = append(result, raw[insertPos:]...)
-	return result, nil
-}
-
-// insertBeforeAnchor inserts the image XML before the paragraph containing the anchor text
-func insertBeforeAnchor(raw []byte, imageXML []byte, anchor string) ([]byte, error) {
-	anchorBytes := []byte(anchor)
-	before, _, ok := bytes.Cut(raw, anchorBytes)
-	if !ok {
-		return nil, fmt.Errorf("anchor text not found: %s", anchor)
-	}
-
-	// Find the start of the paragraph containing the anchor
-	paraStart := []byte("<w:p>")
-	// Search backwards from anchor position
-	startPos := bytes.LastIndex(before, paraStart)
-	if startPos == -1 {
-		return nil, fmt.Errorf("paragraph start not found before anchor text")
-	}
-
-	result := make([]byte, 0, len(raw)+len(imageXML))
-	result = append(result, raw[:startPos]...)
-	result = append(result, imageXML...)
-	result = append(result, raw[startPos:]...)
-	return result, nil
+	return insertAtPosition(raw, imageXML, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
 }
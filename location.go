@@ -0,0 +1,480 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Story names used in TextLocation.Story for the main document parts
+const (
+	StoryDocument  = "document.xml"
+	StoryFootnotes = "footnotes.xml"
+	StoryEndnotes  = "endnotes.xml"
+)
+
+// TextLocation identifies where a piece of text lives inside a document part.
+// Unlike TextMatch.Position it does not depend on the text of the rest of
+// the document, so it can be used to address the same paragraph later on.
+type TextLocation struct {
+	// Story is the part containing the text, relative to the word/ folder
+	// (e.g. "document.xml", "header2.xml", "footnotes.xml")
+	Story string
+
+	// Paragraph is the paragraph index (0-based) within its container:
+	// the story itself, or the table cell when Table >= 0
+	Paragraph int
+
+	// Table is the index (0-based) of the top-level table containing the
+	// text, or -1 when the text is not inside a table
+	Table int
+	Row   int // Row index within Table (-1 outside tables)
+	Cell  int // Cell index within Row (-1 outside tables)
+
+	// Run offsets of the text within the paragraph. Runs are counted in
+	// document order (including runs nested in hyperlinks); offsets are
+	// byte offsets into the run's text, EndOffset being exclusive.
+	StartRun    int
+	StartOffset int
+	EndRun      int
+	EndOffset   int
+}
+
+// InTable reports whether the location is inside a table cell
+func (l TextLocation) InTable() bool {
+	return l.Table >= 0
+}
+
+// paragraphSpan is a paragraph found by scanStoryParagraphs
+type paragraphSpan struct {
+	start, end int
+	loc        TextLocation
+}
+
+// tableSpan is a top-level table found by scanStoryTables
+type tableSpan struct {
+	start, end int
+	rows       []rowSpan
+}
+
+// rowSpan is a table row with its cells
+type rowSpan struct {
+	start, end int
+	cells      []cellSpan
+}
+
+// cellSpan is a table cell and the paragraphs it contains
+type cellSpan struct {
+	start, end int
+	paragraphs []paragraphSpan
+}
+
+// elementSpan is an XML element located by byte offsets
+type elementSpan struct {
+	start, end int
+	name       string // Qualified name, e.g. "w:rPr"
+}
+
+// runSpan is a run within a paragraph together with its text
+type runSpan struct {
+	start, end int
+	text       string
+}
+
+// findElementEnd returns the offset just past the element that starts at
+// start (e.g. "<w:tbl>"), accounting for nested elements of the same name.
+// Returns -1 if the element is not closed.
+func findElementEnd(data []byte, start int, tag string) int {
+	return findQualifiedElementEnd(data, start, "w:"+tag)
+}
+
+// findQualifiedElementEnd is findElementEnd for a qualified element name
+func findQualifiedElementEnd(data []byte, start int, qname string) int {
+	// Self-closing element such as <w:p/>, even when no close tag follows
+	if gt := bytes.IndexByte(data[start:], '>'); gt > 0 && data[start+gt-1] == '/' {
+		return start + gt + 1
+	}
+
+	closeTag := []byte("</" + qname + ">")
+	depth := 0
+	pos := start
+
+	for pos < len(data) {
+		nextOpen := findNextTagStart(data, pos, qname)
+		nextCloseRel := bytes.Index(data[pos:], closeTag)
+		if nextCloseRel == -1 {
+			return -1
+		}
+		nextClose := pos + nextCloseRel
+
+		if nextOpen != -1 && nextOpen < nextClose {
+			gt := bytes.IndexByte(data[nextOpen:], '>')
+			if gt == -1 {
+				return -1
+			}
+			tagEnd := nextOpen + gt + 1
+			if data[tagEnd-2] == '/' {
+				// Self-closing element such as <w:p/>
+				if depth == 0 {
+					return tagEnd
+				}
+				pos = tagEnd
+				continue
+			}
+			depth++
+			pos = tagEnd
+			continue
+		}
+
+		depth--
+		pos = nextClose + len(closeTag)
+		if depth <= 0 {
+			return pos
+		}
+	}
+
+	return -1
+}
+
+// findNextTagStart returns the offset of the next start tag with the given
+// qualified name at or after start, or -1
+func findNextTagStart(data []byte, start int, qname string) int {
+	needle := []byte("<" + qname)
+	for {
+		idx := bytes.Index(data[start:], needle)
+		if idx == -1 {
+			return -1
+		}
+		idx += start
+
+		next := idx + len(needle)
+		if next < len(data) {
+			ch := data[next]
+			if ch == '>' || ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '/' {
+				return idx
+			}
+		}
+
+		start = idx + len(needle)
+	}
+}
+
+// childElements returns the child elements found in data[from:to], which
+// must be the content of a single element. Text, comments and processing
+// instructions between elements are skipped.
+func childElements(data []byte, from, to int) []elementSpan {
+	var children []elementSpan
+
+	pos := from
+	for pos < to {
+		lt := bytes.IndexByte(data[pos:to], '<')
+		if lt == -1 {
+			break
+		}
+		start := pos + lt
+		if start+1 >= to || data[start+1] == '/' {
+			break
+		}
+		gt := bytes.IndexByte(data[start:to], '>')
+		if gt == -1 {
+			break
+		}
+		tagEnd := start + gt + 1
+		if data[start+1] == '?' || data[start+1] == '!' {
+			pos = tagEnd
+			continue
+		}
+
+		nameEnd := start + 1
+		for nameEnd < tagEnd {
+			ch := data[nameEnd]
+			if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '/' || ch == '>' {
+				break
+			}
+			nameEnd++
+		}
+		name := string(data[start+1 : nameEnd])
+
+		end := tagEnd
+		if data[tagEnd-2] != '/' {
+			end = findQualifiedElementEnd(data[:to], start, name)
+			if end == -1 {
+				break
+			}
+		}
+
+		children = append(children, elementSpan{start: start, end: end, name: name})
+		pos = end
+	}
+
+	return children
+}
+
+// scanStoryParagraphs returns every paragraph of a part in document order
+// together with its structural location. Paragraphs inside tables are
+// attributed to the outermost table; paragraphs of nested tables are
+// counted as paragraphs of the enclosing cell.
+func scanStoryParagraphs(data []byte, story string) []paragraphSpan {
+	var result []paragraphSpan
+	tables := scanStoryTables(data)
+
+	topParagraph := 0
+	pos := 0
+	for _, tbl := range tables {
+		result = appendTopLevelParagraphs(result, data, pos, tbl.start, story, &topParagraph)
+		for _, row := range tbl.rows {
+			for _, cell := range row.cells {
+				for _, para := range cell.paragraphs {
+					para.loc.Story = story
+					result = append(result, para)
+				}
+			}
+		}
+		pos = tbl.end
+	}
+	result = appendTopLevelParagraphs(result, data, pos, len(data), story, &topParagraph)
+
+	return result
+}
+
+// appendTopLevelParagraphs appends the paragraphs found in data[from:to]
+func appendTopLevelParagraphs(result []paragraphSpan, data []byte, from, to int, story string, counter *int) []paragraphSpan {
+	pos := from
+	for pos < to {
+		start := findNextWordTagStart(data, pos, "p")
+		if start == -1 || start >= to {
+			break
+		}
+		end := findElementEnd(data, start, "p")
+		if end == -1 || end > to {
+			break
+		}
+		result = append(result, paragraphSpan{
+			start: start,
+			end:   end,
+			loc: TextLocation{
+				Story:     story,
+				Paragraph: *counter,
+				Table:     -1,
+				Row:       -1,
+				Cell:      -1,
+			},
+		})
+		*counter++
+		pos = end
+	}
+	return result
+}
+
+// scanStoryTables returns the top-level tables of a part with their rows,
+// cells and cell paragraphs. Tables in text boxes belong to the paragraph
+// anchoring the text box and are skipped, like nested tables.
+func scanStoryTables(data []byte) []tableSpan {
+	var tables []tableSpan
+
+	pos := 0
+	textBox := findNextWordTagStart(data, 0, "txbxContent")
+	for {
+		start := findNextWordTagStart(data, pos, "tbl")
+		if start == -1 {
+			break
+		}
+		if textBox != -1 && textBox < pos {
+			textBox = findNextWordTagStart(data, pos, "txbxContent")
+		}
+		if textBox != -1 && textBox < start {
+			boxEnd := findElementEnd(data, textBox, "txbxContent")
+			if boxEnd == -1 {
+				break
+			}
+			pos = boxEnd
+			continue
+		}
+		end := findElementEnd(data, start, "tbl")
+		if end == -1 {
+			break
+		}
+
+		tbl := tableSpan{start: start, end: end}
+		tableIndex := len(tables)
+
+		rowPos := start + len("<w:tbl")
+		for {
+			rowStart := findNextWordTagStart(data, rowPos, "tr")
+			if rowStart == -1 || rowStart >= end {
+				break
+			}
+			rowEnd := findElementEnd(data, rowStart, "tr")
+			if rowEnd == -1 || rowEnd > end {
+				break
+			}
+
+			row := rowSpan{start: rowStart, end: rowEnd}
+			cellPos := rowStart
+			for {
+				cellStart := findNextWordTagStart(data, cellPos, "tc")
+				if cellStart == -1 || cellStart >= rowEnd {
+					break
+				}
+				cellEnd := findElementEnd(data, cellStart, "tc")
+				if cellEnd == -1 || cellEnd > rowEnd {
+					break
+				}
+
+				cell := cellSpan{start: cellStart, end: cellEnd}
+				paraPos := cellStart
+				for {
+					paraStart := findNextWordTagStart(data, paraPos, "p")
+					if paraStart == -1 || paraStart >= cellEnd {
+						break
+					}
+					paraEnd := findElementEnd(data, paraStart, "p")
+					if paraEnd == -1 || paraEnd > cellEnd {
+						break
+					}
+					cell.paragraphs = append(cell.paragraphs, paragraphSpan{
+						start: paraStart,
+						end:   paraEnd,
+						loc: TextLocation{
+							Paragraph: len(cell.paragraphs),
+							Table:     tableIndex,
+							Row:       len(tbl.rows),
+							Cell:      len(row.cells),
+						},
+					})
+					paraPos = paraEnd
+				}
+
+				row.cells = append(row.cells, cell)
+				cellPos = cellEnd
+			}
+
+			tbl.rows = append(tbl.rows, row)
+			rowPos = rowEnd
+		}
+
+		tables = append(tables, tbl)
+		pos = end
+	}
+
+	return tables
+}
+
+// paragraphRunTexts returns the text of each run in a paragraph, in order
+func paragraphRunTexts(paragraphXML []byte) []string {
+	runs := paragraphRuns(paragraphXML)
+	texts := make([]string, len(runs))
+	for i, run := range runs {
+		texts[i] = run.text
+	}
+	return texts
+}
+
+// paragraphRuns returns the runs of a paragraph in document order, including
+// runs nested in hyperlinks, fields and smart tags
+func paragraphRuns(paragraphXML []byte) []runSpan {
+	var runs []runSpan
+
+	pos := 0
+	for {
+		runStart := findNextWordTagStart(paragraphXML, pos, "r")
+		if runStart == -1 {
+			break
+		}
+		runEnd := findElementEnd(paragraphXML, runStart, "r")
+		if runEnd == -1 {
+			break
+		}
+		runs = append(runs, runSpan{
+			start: runStart,
+			end:   runEnd,
+			text:  runText(paragraphXML[runStart:runEnd]),
+		})
+		pos = runEnd
+	}
+
+	return runs
+}
+
+// runText concatenates the <w:t> contents of a single run
+func runText(runXML []byte) string {
+	var out strings.Builder
+	pos := 0
+	for {
+		tStart := findNextWordTagStart(runXML, pos, "t")
+		if tStart == -1 {
+			break
+		}
+		openEnd := bytes.IndexByte(runXML[tStart:], '>')
+		if openEnd == -1 {
+			break
+		}
+		textStart := tStart + openEnd + 1
+		if runXML[textStart-2] == '/' {
+			// Empty <w:t/>
+			pos = textStart
+			continue
+		}
+		closeRel := bytes.Index(runXML[textStart:], []byte("</w:t>"))
+		if closeRel == -1 {
+			break
+		}
+		out.WriteString(xmlUnescape(string(runXML[textStart : textStart+closeRel])))
+		pos = textStart + closeRel + len("</w:t>")
+	}
+	return out.String()
+}
+
+// runOffset converts a byte offset within the paragraph text into a run
+// index and an offset within that run. When end is true the offset is
+// treated as exclusive, so a match ending exactly at a run boundary is
+// attributed to the run it ends in.
+func runOffset(runStarts []int, runs []string, offset int, end bool) (int, int) {
+	for i := range runs {
+		runEnd := runStarts[i] + len(runs[i])
+		if end {
+			if offset > runStarts[i] && offset <= runEnd {
+				return i, offset - runStarts[i]
+			}
+		} else if offset >= runStarts[i] && offset < runEnd {
+			return i, offset - runStarts[i]
+		}
+	}
+	if len(runs) == 0 {
+		return 0, 0
+	}
+	last := len(runs) - 1
+	return last, len(runs[last])
+}
+
+// findParagraphByLocation returns the byte range of the paragraph addressed
+// by loc within a part
+func findParagraphByLocation(data []byte, loc TextLocation) (int, int, error) {
+	if loc.Table < 0 {
+		for _, para := range scanStoryParagraphs(data, loc.Story) {
+			if para.loc.Table < 0 && para.loc.Paragraph == loc.Paragraph {
+				return para.start, para.end, nil
+			}
+		}
+		return 0, 0, fmt.Errorf("paragraph %d not found", loc.Paragraph)
+	}
+
+	tables := scanStoryTables(data)
+	if loc.Table >= len(tables) {
+		return 0, 0, fmt.Errorf("table %d not found", loc.Table)
+	}
+	tbl := tables[loc.Table]
+	if loc.Row < 0 || loc.Row >= len(tbl.rows) {
+		return 0, 0, fmt.Errorf("row %d not found in table %d", loc.Row, loc.Table)
+	}
+	row := tbl.rows[loc.Row]
+	if loc.Cell < 0 || loc.Cell >= len(row.cells) {
+		return 0, 0, fmt.Errorf("cell %d not found in table %d row %d", loc.Cell, loc.Table, loc.Row)
+	}
+	cell := row.cells[loc.Cell]
+	if loc.Paragraph < 0 || loc.Paragraph >= len(cell.paragraphs) {
+		return 0, 0, fmt.Errorf("paragraph %d not found in table %d cell (%d, %d)", loc.Paragraph, loc.Table, loc.Row, loc.Cell)
+	}
+	para := cell.paragraphs[loc.Paragraph]
+	return para.start, para.end, nil
+}
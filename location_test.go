@@ -0,0 +1,260 @@
+package godocx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+const locationFixtureBody = `<w:p><w:r><w:t>Intro paragraph</w:t></w:r></w:p>` +
+	`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>A1</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Total: </w:t></w:r><w:r><w:t>42</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:p><w:r><w:t>Total: 7</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t xml:space="preserve">Split </w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>marker</w:t></w:r><w:r><w:t> text</w:t></w:r></w:p>`
+
+// buildFixtureDocxWithBody builds a minimal docx whose body contains the
+// given XML; extra holds additional parts keyed by zip path
+func buildFixtureDocxWithBody(t *testing.T, body string, extra map[string]string) string {
+	t.Helper()
+
+	docx := &bytes.Buffer{}
+	docxZip := zip.NewWriter(docx)
+
+	addZipEntry(t, docxZip, "[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"></Types>`)
+	addZipEntry(t, docxZip, "word/document.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>`+body+`<w:sectPr/></w:body></w:document>`)
+	addZipEntry(t, docxZip, "word/_rels/document.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"></Relationships>`)
+	for path, content := range extra {
+		addZipEntry(t, docxZip, path, content)
+	}
+
+	if err := docxZip.Close(); err != nil {
+		t.Fatalf("close docx zip: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "input.docx")
+	if err := os.WriteFile(path, docx.Bytes(), 0o644); err != nil {
+		t.Fatalf("write input docx: %v", err)
+	}
+	return path
+}
+
+func TestFindTextLocations(t *testing.T) {
+	header := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:p><w:r><w:t>Total: header</w:t></w:r></w:p></w:hdr>`
+	footnotes := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><w:footnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:footnote w:id="1"><w:p><w:r><w:t>Total: note</w:t></w:r></w:p></w:footnote></w:footnotes>`
+	path := buildFixtureDocxWithBody(t, locationFixtureBody, map[string]string{
+		"word/header2.xml":   header,
+		"word/footnotes.xml": footnotes,
+	})
+
+	u, err := godocx.New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer u.Cleanup()
+
+	opts := godocx.DefaultFindOptions()
+	opts.MatchCase = true
+	opts.InHeaders = true
+	opts.InFootnotes = true
+
+	matches, err := u.FindText("Total:", opts)
+	if err != nil {
+		t.Fatalf("FindText failed: %v", err)
+	}
+	if len(matches) != 4 {
+		t.Fatalf("expected 4 matches, got %d: %+v", len(matches), matches)
+	}
+
+	cell := matches[0].Location
+	if cell.Story != godocx.StoryDocument || cell.Table != 0 || cell.Row != 0 || cell.Cell != 1 || cell.Paragraph != 0 {
+		t.Errorf("unexpected table cell location: %+v", cell)
+	}
+	if !cell.InTable() {
+		t.Error("expected cell match to report InTable")
+	}
+
+	body := matches[1].Location
+	if body.Story != godocx.StoryDocument || body.Table != -1 || body.Paragraph != 1 {
+		t.Errorf("unexpected body location: %+v", body)
+	}
+	if matches[1].After != " 7Split marker text" {
+		t.Errorf("unexpected context after match: %q", matches[1].After)
+	}
+
+	if matches[2].Location.Story != "header2.xml" {
+		t.Errorf("expected header2.xml story, got %q", matches[2].Location.Story)
+	}
+	if matches[3].Location.Story != godocx.StoryFootnotes {
+		t.Errorf("expected footnotes.xml story, got %q", matches[3].Location.Story)
+	}
+
+	opts = godocx.DefaultFindOptions()
+	opts.InTables = false
+	matches, err = u.FindText("Total:", opts)
+	if err != nil {
+		t.Fatalf("FindText failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Location.InTable() {
+		t.Errorf("expected only the body match with InTables disabled, got %+v", matches)
+	}
+}
+
+func TestFindTextRunOffsets(t *testing.T) {
+	path := buildFixtureDocxWithBody(t, locationFixtureBody, nil)
+
+	u, err := godocx.New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer u.Cleanup()
+
+	matches, err := u.FindText("t marker t", godocx.DefaultFindOptions())
+	if err != nil {
+		t.Fatalf("FindText failed: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(matches))
+	}
+
+	loc := matches[0].Location
+	if loc.StartRun != 0 || loc.StartOffset != 4 {
+		t.Errorf("expected start at run 0 offset 4, got run %d offset %d", loc.StartRun, loc.StartOffset)
+	}
+	if loc.EndRun != 2 || loc.EndOffset != 2 {
+		t.Errorf("expected end at run 2 offset 2, got run %d offset %d", loc.EndRun, loc.EndOffset)
+	}
+}
+
+func TestInsertAtMatch(t *testing.T) {
+	path := buildFixtureDocxWithBody(t, locationFixtureBody, nil)
+
+	u, err := godocx.New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer u.Cleanup()
+
+	matches, err := u.FindText("Total:", godocx.DefaultFindOptions())
+	if err != nil {
+		t.Fatalf("FindText failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+
+	// The second occurrence is the body paragraph; PositionAfterText would
+	// have picked the table cell
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "After body total",
+		Target: godocx.TargetAfterMatch(matches[1]),
+	}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "Before cell total",
+		Target: godocx.TargetBeforeMatch(matches[0]),
+	}); err != nil {
+		t.Fatalf("InsertParagraph in cell failed: %v", err)
+	}
+
+	// Locations describe the document at search time, so search again after
+	// the cell gained a paragraph
+	matches, err = u.FindText("Total:", godocx.DefaultFindOptions())
+	if err != nil {
+		t.Fatalf("FindText failed: %v", err)
+	}
+	if err := u.InsertTable(godocx.TableOptions{
+		Columns: []godocx.ColumnDefinition{{Title: "Nested"}},
+		Rows:    [][]string{{"x"}},
+		Target:  godocx.TargetAfterMatch(matches[0]),
+	}); err != nil {
+		t.Fatalf("InsertTable in cell failed: %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "out.docx")
+	if err := u.Save(outPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	doc := readZipEntry(t, outPath, "word/document.xml")
+	bodyTotal := strings.Index(doc, "Total: 7")
+	after := strings.Index(doc, "After body total")
+	if bodyTotal == -1 || after == -1 || after < bodyTotal {
+		t.Errorf("expected paragraph after body total")
+	}
+
+	cellStart := strings.Index(doc, "<w:tc><w:p><w:r><w:t>A1")
+	before := strings.Index(doc, "Before cell total")
+	cellTotal := strings.Index(doc, "Total: </w:t>")
+	if before == -1 || before < cellStart || before > cellTotal {
+		t.Errorf("expected paragraph inside the cell before its total")
+	}
+
+	if !strings.Contains(doc, "</w:tbl><w:p/></w:tc>") {
+		t.Errorf("expected nested table to be followed by an empty paragraph in its cell")
+	}
+}
+
+func TestInsertAtMatchOutsideBody(t *testing.T) {
+	header := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:p><w:r><w:t>Header text</w:t></w:r></w:p></w:hdr>`
+	path := buildFixtureDocxWithBody(t, locationFixtureBody, map[string]string{"word/header1.xml": header})
+
+	u, err := godocx.New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer u.Cleanup()
+
+	opts := godocx.DefaultFindOptions()
+	opts.InHeaders = true
+	matches, err := u.FindText("Header text", opts)
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("FindText: %v, %d matches", err, len(matches))
+	}
+
+	err = u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "x",
+		Target: godocx.TargetAfterMatch(matches[0]),
+	})
+	if err == nil {
+		t.Fatal("expected error inserting at a header match")
+	}
+}
+
+func TestTextBoxTablesAreSkipped(t *testing.T) {
+	textBox := `<w:p><w:r><w:t>Anchor</w:t></w:r><w:r><mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006"><mc:Choice Requires="wps"><w:drawing><wps:txbx xmlns:wps="http://schemas.microsoft.com/office/word/2010/wordprocessingShape"><w:txbxContent>` +
+		`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Boxed</w:t></w:r></w:p></w:tc></w:tr></w:tbl><w:p/>` +
+		`</w:txbxContent></wps:txbx></w:drawing></mc:Choice></mc:AlternateContent></w:r></w:p>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, textBox+locationFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	matches, err := u.FindText("Total:", godocx.DefaultFindOptions())
+	if err != nil {
+		t.Fatalf("FindText failed: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Location.Table != 0 || matches[1].Location.Paragraph != 2 {
+		t.Fatalf("unexpected locations: %+v", matches)
+	}
+
+	// The first top-level table is the body table, not the one in the text box
+	tbl, err := u.TableRange(1)
+	if err != nil {
+		t.Fatalf("TableRange failed: %v", err)
+	}
+	if text, _ := tbl.Text(); !strings.Contains(text, "A1") {
+		t.Errorf("expected the body table, got %q", text)
+	}
+	if err := u.InsertParagraph(godocx.ParagraphOptions{Text: "After table", Target: godocx.TargetAfterTable(1)}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "Boxed", "</w:txbxContent>", "A1", "</w:tbl>", "After table", "Total: 7")
+}
@@ -42,30 +42,19 @@ const (
 	ParagraphAlignJustify ParagraphAlignment = "both"
 )
 
-// InsertPosition defines where to insert the paragraph
-type InsertPosition int
-
-const (
-	// PositionBeginning inserts at the start of the document body
-	PositionBeginning InsertPosition = iota
-	// PositionEnd inserts at the end of the document body
-	PositionEnd
-	// PositionAfterText inserts after the first occurrence of specified text
-	PositionAfterText
-	// PositionBeforeText inserts before the first occurrence of specified text
-	PositionBeforeText
-)
-
 // ParagraphOptions defines options for paragraph insertion
 type ParagraphOptions struct {
-	Text      string         // The text content of the paragraph
-	Style     ParagraphStyle // The style to apply (default: Normal)
-	Alignment ParagraphAlignment
-	Position  InsertPosition // Where to insert the paragraph
-	Anchor    string         // Text to anchor the insertion (for PositionAfterText/PositionBeforeText)
-	Bold      bool           // Make text bold
-	Italic    bool           // Make text italic
-	Underline bool           // Underline text
+	Text        string         // The text content of the paragraph
+	Style       ParagraphStyle // The style to apply (default: Normal)
+	Alignment   ParagraphAlignment
+	Position    InsertPosition // Where to insert the paragraph
+	Target      *Target        // Overrides Position and Anchor (match, table cell, Range...)
+	Anchor      string         // Text to anchor the insertion (for PositionAfterText/PositionBeforeText)
+	Occurrence  int            // Which paragraph containing Anchor to use (1-based, default: first)
+	AnchorRegex bool           // Treat Anchor as a regular expression
+	Bold        bool           // Make text bold
+	Italic      bool           // Make text italic
+	Underline   bool           // Underline text
 
 	// List properties (alternative to Style-based lists)
 	ListType  ListType // Type of list (bullet or numbered)
@@ -249,48 +238,16 @@ func paragraphAlignmentValue(alignment ParagraphAlignment) (string, bool) {
 
 // insertParagraphAtPosition inserts the paragraph XML at the specified position
 func insertParagraphAtPosition(docXML, paraXML []byte, opts ParagraphOptions) ([]byte, error) {
-	switch opts.Position {
-	case PositionBeginning:
-		return insertAtBodyStart(docXML, paraXML)
-	case PositionEnd:
-		return insertAtBodyEnd(docXML, paraXML)
-	case PositionAfterText:
-		if opts.Anchor == "" {
-			return nil, fmt.Errorf("anchor text required for PositionAfterText")
-		}
-		return insertAfterText(docXML, paraXML, opts.Anchor)
-	case PositionBeforeText:
-		if opts.Anchor == "" {
-			return nil, fmt.Errorf("anchor text required for PositionBeforeText")
-		}
-		return insertBeforeText(docXML, paraXML, opts.Anchor)
-	default:
-		return nil, fmt.Errorf("invalid insert position")
-	}
+	return insertAtPosition(docXML, paraXML, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
 }
 
-// insertAtBodyStart inserts paragraph at the start of document body
-func insertAtBodyStart(docXML, paraXML []byte) ([]byte, error) {
-	bodyContentStart, err := findBodyContentStart(docXML)
-	if err != nil {
-		return nil, err
-	}
-
-	insertPos := bodyContentStart
-
-	result := make([]byte, len(docXML)+len(paraXML))
-	n := copy(result, docXML[:insertPos])
-	n += copy(result[n:], paraXML)
-	copy(result[n:], docXML[insertPos:])
-
-	return result, nil
-}
-
-// insertAtBodyEnd inserts paragraph at the end of document body (before </w:body>)
-func insertAtBodyEnd(docXML, paraXML []byte) ([]byte, error) {
+// findBodyEndInsertPos returns the offset at which content is appended to
+// the document body: before the final body-level <w:sectPr>, or after a
+// trailing section break paragraph
+func findBodyEndInsertPos(docXML []byte) (int, error) {
 	bodyEnd := bytes.Index(docXML, []byte("</w:body>"))
 	if bodyEnd == -1 {
-		return nil, fmt.Errorf("could not find </w:body> tag")
+		return 0, fmt.Errorf("could not find </w:body> tag")
 	}
 
 	insertPos := bodyEnd
@@ -340,12 +297,7 @@ func insertAtBodyEnd(docXML, paraXML []byte) ([]byte, error) {
 		}
 	}
 
-	result := make([]byte, len(docXML)+len(paraXML))
-	n := copy(result, docXML[:insertPos])
-	n += copy(result[n:], paraXML)
-	copy(result[n:], docXML[insertPos:])
-
-	return result, nil
+	return insertPos, nil
 }
 
 func findBodyContentStart(docXML []byte) (int, error) {
@@ -362,45 +314,20 @@ func findBodyContentStart(docXML []byte) (int, error) {
 	return bodyStart + openTagEnd + 1, nil
 }
 
-// insertAfterText inserts paragraph after the paragraph containing the anchor text
-func insertAfterText(docXML, paraXML []byte, anchorText string) ([]byte, error) {
-	_, paraEnd, err := findParagraphRangeByAnchor(docXML, anchorText)
-	if err != nil {
-		return nil, err
+// findParagraphRangeByAnchor returns the range of the paragraph containing
+// the anchor text. anchor.occurrence selects the n-th such paragraph.
+func findParagraphRangeByAnchor(docXML []byte, anchor positionAnchor) (int, int, error) {
+	if anchor.text == "" {
+		return 0, 0, fmt.Errorf("anchor text cannot be empty")
 	}
 
-	insertPos := paraEnd
-
-	result := make([]byte, len(docXML)+len(paraXML))
-	n := copy(result, docXML[:insertPos])
-	n += copy(result[n:], paraXML)
-	copy(result[n:], docXML[insertPos:])
-
-	return result, nil
-}
-
-// insertBeforeText inserts paragraph before the paragraph containing the anchor text
-func insertBeforeText(docXML, paraXML []byte, anchorText string) ([]byte, error) {
-	paraStart, _, err := findParagraphRangeByAnchor(docXML, anchorText)
+	matches, err := anchor.matcher()
 	if err != nil {
-		return nil, err
+		return 0, 0, err
 	}
 
-	// Insert before this paragraph
-	result := make([]byte, len(docXML)+len(paraXML))
-	n := copy(result, docXML[:paraStart])
-	n += copy(result[n:], paraXML)
-	copy(result[n:], docXML[paraStart:])
-
-	return result, nil
-}
-
-func findParagraphRangeByAnchor(docXML []byte, anchorText string) (int, int, error) {
-	if anchorText == "" {
-		return 0, 0, fmt.Errorf("anchor text cannot be empty")
-	}
-
-	normalizedAnchor := normalizeWhitespace(anchorText)
+	occurrence := max(anchor.occurrence, 1)
+	found := 0
 
 	searchPos := 0
 	for {
@@ -416,19 +343,20 @@ func findParagraphRangeByAnchor(docXML []byte, anchorText string) (int, int, err
 		paraEnd := paraStart + paraEndRel + len("</w:p>")
 
 		paragraphXML := docXML[paraStart:paraEnd]
-		paragraphText := extractParagraphPlainText(paragraphXML)
-		if strings.Contains(paragraphText, anchorText) {
-			return paraStart, paraEnd, nil
-		}
-
-		if normalizedAnchor != "" && strings.Contains(normalizeWhitespace(paragraphText), normalizedAnchor) {
-			return paraStart, paraEnd, nil
+		if matches(extractParagraphPlainText(paragraphXML)) {
+			found++
+			if found == occurrence {
+				return paraStart, paraEnd, nil
+			}
 		}
 
 		searchPos = paraEnd
 	}
 
-	return 0, 0, fmt.Errorf("anchor text %q not found in document", anchorText)
+	if found > 0 {
+		return 0, 0, fmt.Errorf("anchor text %q found %d time(s), occurrence %d requested", anchor.text, found, occurrence)
+	}
+	return 0, 0, fmt.Errorf("anchor text %q not found in document", anchor.text)
 }
 
 func findNextParagraphStart(docXML []byte, start int) int {
@@ -516,24 +444,7 @@ func nextXMLTokenAbsolute(tPos, tabPos, brPos int) (int, string) {
 }
 
 func findNextWordTagStart(docXML []byte, start int, tag string) int {
-	needle := []byte("<w:" + tag)
-	for {
-		idx := bytes.Index(docXML[start:], needle)
-		if idx == -1 {
-			return -1
-		}
-		idx += start
-
-		next := idx + len(needle)
-		if next < len(docXML) {
-			ch := docXML[next]
-			if ch == '>' || ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '/' {
-				return idx
-			}
-		}
-
-		start = idx + len(needle)
-	}
+	return findNextTagStart(docXML, start, "w:"+tag)
 }
 
 func normalizeWhitespace(s string) string {
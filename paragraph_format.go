@@ -0,0 +1,253 @@
+package godocx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// selectorKind identifies how a ParagraphSelector matches paragraphs
+type selectorKind int
+
+const (
+	selectorNone selectorKind = iota
+	selectorPattern
+	selectorStyle
+	selectorRange
+)
+
+// ParagraphSelector selects existing paragraphs for FormatParagraphs.
+// Selectors are created with ParagraphsMatching, ParagraphsWithStyle and
+// ParagraphsInRange.
+type ParagraphSelector struct {
+	kind    selectorKind
+	pattern string
+	style   ParagraphStyle
+	from    int
+	to      int
+}
+
+// ParagraphsMatching selects the paragraphs whose text matches the regular
+// expression pattern, including paragraphs in table cells
+func ParagraphsMatching(pattern string) ParagraphSelector {
+	return ParagraphSelector{kind: selectorPattern, pattern: pattern}
+}
+
+// ParagraphsWithStyle selects the paragraphs with the given style ID,
+// including paragraphs in table cells. Paragraphs without a style match
+// StyleNormal.
+func ParagraphsWithStyle(style ParagraphStyle) ParagraphSelector {
+	return ParagraphSelector{kind: selectorStyle, style: style}
+}
+
+// ParagraphsInRange selects the body-level paragraphs from through to
+// (0-based, inclusive), counted as in ParagraphRange
+func ParagraphsInRange(from, to int) ParagraphSelector {
+	return ParagraphSelector{kind: selectorRange, from: from, to: to}
+}
+
+// ParagraphFormat defines the changes FormatParagraphs applies to each
+// selected paragraph. Nil and zero values leave the corresponding property
+// unchanged.
+type ParagraphFormat struct {
+	Style     ParagraphStyle     // New paragraph style; StyleNormal removes the style
+	Alignment ParagraphAlignment // New alignment
+
+	// Character formatting applied to every run of the paragraph; false
+	// turns the formatting off
+	Bold               *bool
+	Italic             *bool
+	Underline          *bool
+	ClearRunFormatting bool // Remove bold, italic and underline before applying the above
+
+	// List conversion
+	ListType   ListType // Turn the paragraph into a bullet or numbered list item
+	ListLevel  int      // Indentation level (0-8, default 0)
+	RemoveList bool     // Turn a list item back into a plain paragraph
+}
+
+// paragraphPropertyOrder is the schema order of <w:pPr> children (CT_PPr)
+var paragraphPropertyOrder = []string{
+	"w:pStyle", "w:keepNext", "w:keepLines", "w:pageBreakBefore", "w:framePr",
+	"w:widowControl", "w:numPr", "w:suppressLineNumbers", "w:pBdr", "w:shd",
+	"w:tabs", "w:suppressAutoHyphens", "w:kinsoku", "w:wordWrap",
+	"w:overflowPunct", "w:topLinePunct", "w:autoSpaceDE", "w:autoSpaceDN",
+	"w:bidi", "w:adjustRightInd", "w:snapToGrid", "w:spacing", "w:ind",
+	"w:contextualSpacing", "w:mirrorIndents", "w:suppressOverlap", "w:jc",
+	"w:textDirection", "w:textAlignment", "w:textboxTightWrap", "w:outlineLvl",
+	"w:divId", "w:cnfStyle", "w:rPr", "w:sectPr", "w:pPrChange",
+}
+
+// FormatParagraphs applies format to every paragraph of the document body
+// matched by selector and returns the number of paragraphs changed.
+// Converting paragraphs to lists uses the bullet and numbered list
+// definitions that InsertParagraph uses.
+func (u *Updater) FormatParagraphs(selector ParagraphSelector, format ParagraphFormat) (int, error) {
+	if u == nil {
+		return 0, fmt.Errorf("updater is nil")
+	}
+	if err := validateParagraphFormat(format); err != nil {
+		return 0, err
+	}
+
+	listIDs := listNumberingIDs{bulletNumID: BulletListNumID, numberedNumID: NumberedListNumID}
+	if format.ListType != "" {
+		if err := u.ensureNumberingXML(); err != nil {
+			return 0, fmt.Errorf("ensure numbering: %w", err)
+		}
+		listIDs = u.getListNumberingIDs()
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return 0, err
+	}
+
+	paragraphs, err := selectParagraphs(docXML, selector)
+	if err != nil {
+		return 0, err
+	}
+	if len(paragraphs) == 0 {
+		return 0, nil
+	}
+
+	// Format from the end so earlier offsets stay valid
+	updated := docXML
+	for i := len(paragraphs) - 1; i >= 0; i-- {
+		para := paragraphs[i]
+		formatted := formatParagraph(updated[para.start:para.end], format, listIDs)
+		updated = spliceBytes(updated, formatted, para.start, para.end)
+	}
+
+	if err := u.writeDocumentXML(updated); err != nil {
+		return 0, err
+	}
+	return len(paragraphs), nil
+}
+
+func validateParagraphFormat(format ParagraphFormat) error {
+	if format == (ParagraphFormat{}) {
+		return NewValidationError("format", "no formatting changes specified")
+	}
+	if format.Alignment != "" {
+		if _, ok := paragraphAlignmentValue(format.Alignment); !ok {
+			return NewValidationError("Alignment", fmt.Sprintf("unsupported alignment %q", format.Alignment))
+		}
+	}
+	if format.ListType != "" && format.ListType != ListTypeBullet && format.ListType != ListTypeNumbered {
+		return NewValidationError("ListType", fmt.Sprintf("unsupported list type %q", format.ListType))
+	}
+	if format.ListType != "" && format.RemoveList {
+		return NewValidationError("RemoveList", "cannot be combined with ListType")
+	}
+	return nil
+}
+
+// selectParagraphs returns the paragraphs of the document body matched by
+// selector in document order
+func selectParagraphs(docXML []byte, selector ParagraphSelector) ([]paragraphSpan, error) {
+	paragraphs := scanStoryParagraphs(docXML, StoryDocument)
+
+	var selected []paragraphSpan
+	switch selector.kind {
+	case selectorPattern:
+		if selector.pattern == "" {
+			return nil, NewValidationError("selector", "pattern cannot be empty")
+		}
+		re, err := regexp.Compile(selector.pattern)
+		if err != nil {
+			return nil, NewInvalidRegexError(selector.pattern, err)
+		}
+		for _, para := range paragraphs {
+			if re.MatchString(strings.Join(paragraphRunTexts(docXML[para.start:para.end]), "")) {
+				selected = append(selected, para)
+			}
+		}
+
+	case selectorStyle:
+		if selector.style == "" {
+			return nil, NewValidationError("selector", "style cannot be empty")
+		}
+		for _, para := range paragraphs {
+			style := string(StyleNormal)
+			if m := pStyleValPattern.FindSubmatch(paragraphProperties(docXML[para.start:para.end])); m != nil {
+				style = string(m[1])
+			}
+			if style == string(selector.style) {
+				selected = append(selected, para)
+			}
+		}
+
+	case selectorRange:
+		var bodyParagraphs []paragraphSpan
+		for _, para := range paragraphs {
+			if !para.loc.InTable() {
+				bodyParagraphs = append(bodyParagraphs, para)
+			}
+		}
+		if selector.from < 0 || selector.to < selector.from || selector.to >= len(bodyParagraphs) {
+			return nil, NewValidationError("selector", fmt.Sprintf("invalid paragraph range %d-%d (document has %d paragraphs)", selector.from, selector.to, len(bodyParagraphs)))
+		}
+		selected = bodyParagraphs[selector.from : selector.to+1]
+
+	default:
+		return nil, NewValidationError("selector", "selector must be created with ParagraphsMatching, ParagraphsWithStyle or ParagraphsInRange")
+	}
+
+	return selected, nil
+}
+
+// formatParagraph applies format to the paragraph properties and runs of a
+// single paragraph
+func formatParagraph(paraXML []byte, format ParagraphFormat, listIDs listNumberingIDs) []byte {
+	var props []propertyElement
+	var remove []string
+
+	switch format.Style {
+	case "":
+	case StyleNormal:
+		remove = append(remove, "w:pStyle")
+	default:
+		props = append(props, propertyElement{"w:pStyle", fmt.Appendf(nil, `<w:pStyle w:val="%s"/>`, xmlEscape(string(format.Style)))})
+	}
+
+	if alignment, ok := paragraphAlignmentValue(format.Alignment); ok {
+		props = append(props, propertyElement{"w:jc", fmt.Appendf(nil, `<w:jc w:val="%s"/>`, alignment)})
+	}
+
+	if format.ListType != "" {
+		numID := listIDs.bulletNumID
+		if format.ListType == ListTypeNumbered {
+			numID = listIDs.numberedNumID
+		}
+		level := min(max(format.ListLevel, 0), 8)
+		props = append(props, propertyElement{"w:numPr", fmt.Appendf(nil, `<w:numPr><w:ilvl w:val="%d"/><w:numId w:val="%d"/></w:numPr>`, level, numID)})
+	}
+	if format.RemoveList {
+		remove = append(remove, "w:numPr")
+	}
+
+	if len(props) > 0 || len(remove) > 0 {
+		paraXML = mergeProperties(paraXML, "w:pPr", paragraphPropertyOrder, props, remove)
+	}
+
+	if format.Bold == nil && format.Italic == nil && format.Underline == nil && !format.ClearRunFormatting {
+		return paraXML
+	}
+
+	var runRemove []string
+	if format.ClearRunFormatting {
+		runRemove = []string{"w:b", "w:bCs", "w:i", "w:iCs", "w:u"}
+	}
+	textFormat := TextFormat{Bold: format.Bold, Italic: format.Italic, Underline: format.Underline}
+
+	// Rewrite runs from the end so earlier offsets stay valid
+	runs := paragraphRuns(paraXML)
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		runXML := mergeRunProperties(paraXML[run.start:run.end], nil, runRemove...)
+		runXML = applyRunFormat(runXML, textFormat)
+		paraXML = spliceBytes(paraXML, runXML, run.start, run.end)
+	}
+	return paraXML
+}
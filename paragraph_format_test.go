@@ -0,0 +1,144 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+const formatFixtureBody = `<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Overview</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:rPr><w:b/><w:i/><w:sz w:val="28"/></w:rPr><w:t>Note: check the figures</w:t></w:r></w:p>` +
+	`<w:p><w:pPr><w:jc w:val="right"/></w:pPr><w:r><w:t>First item</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>Second item</w:t></w:r></w:p>` +
+	`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Note: in a cell</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:p/>`
+
+func openFormatFixture(t *testing.T) *godocx.Updater {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, formatFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+	return u
+}
+
+func TestFormatParagraphsByPattern(t *testing.T) {
+	u := openFormatFixture(t)
+
+	count, err := u.FormatParagraphs(godocx.ParagraphsMatching(`^Note:`), godocx.ParagraphFormat{
+		Style:              godocx.StyleQuote,
+		Alignment:          godocx.ParagraphAlignCenter,
+		Underline:          ptrBool(true),
+		ClearRunFormatting: true,
+	})
+	if err != nil {
+		t.Fatalf("FormatParagraphs failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 paragraphs formatted, got %d", count)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `<w:p><w:pPr><w:pStyle w:val="Quote"/><w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:sz w:val="28"/><w:u w:val="single"/></w:rPr><w:t>Note: check the figures</w:t>`) {
+		t.Errorf("unexpected formatting of body paragraph: %s", doc)
+	}
+	if !strings.Contains(doc, `<w:tc><w:p><w:pPr><w:pStyle w:val="Quote"/><w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:u w:val="single"/></w:rPr><w:t>Note: in a cell</w:t>`) {
+		t.Errorf("unexpected formatting of cell paragraph: %s", doc)
+	}
+}
+
+func TestFormatParagraphsByStyle(t *testing.T) {
+	u := openFormatFixture(t)
+
+	count, err := u.FormatParagraphs(godocx.ParagraphsWithStyle(godocx.StyleHeading1), godocx.ParagraphFormat{Style: godocx.StyleNormal, Bold: ptrBool(true)})
+	if err != nil {
+		t.Fatalf("FormatParagraphs failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 paragraph formatted, got %d", count)
+	}
+
+	// Paragraphs without a style count as Normal
+	count, err = u.FormatParagraphs(godocx.ParagraphsWithStyle(godocx.StyleNormal), godocx.ParagraphFormat{Alignment: godocx.ParagraphAlignJustify})
+	if err != nil {
+		t.Fatalf("FormatParagraphs failed: %v", err)
+	}
+	if count != 6 {
+		t.Errorf("expected 6 paragraphs formatted, got %d", count)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `<w:p><w:pPr><w:jc w:val="both"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>Overview</w:t>`) {
+		t.Errorf("expected heading to become a bold Normal paragraph: %s", doc)
+	}
+	if !strings.Contains(doc, `<w:p><w:pPr><w:jc w:val="both"/></w:pPr></w:p>`) {
+		t.Error("expected empty paragraph to get paragraph properties")
+	}
+
+	// False turns the formatting off again
+	if _, err := u.FormatParagraphs(godocx.ParagraphsMatching(`^Overview$`), godocx.ParagraphFormat{Bold: ptrBool(false)}); err != nil {
+		t.Fatalf("FormatParagraphs failed: %v", err)
+	}
+	doc = saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `<w:p><w:pPr><w:jc w:val="both"/></w:pPr><w:r><w:t>Overview</w:t>`) {
+		t.Errorf("expected heading to lose its bold: %s", doc)
+	}
+}
+
+func TestFormatParagraphsLists(t *testing.T) {
+	u := openFormatFixture(t)
+
+	count, err := u.FormatParagraphs(godocx.ParagraphsInRange(2, 3), godocx.ParagraphFormat{ListType: godocx.ListTypeNumbered, ListLevel: 1})
+	if err != nil {
+		t.Fatalf("FormatParagraphs failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 paragraphs formatted, got %d", count)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `<w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="2"/></w:numPr><w:jc w:val="right"/></w:pPr><w:r><w:t>First item</w:t>`) {
+		t.Errorf("expected first paragraph to become a numbered item: %s", doc)
+	}
+	if !strings.Contains(doc, `<w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="2"/></w:numPr></w:pPr><w:r><w:t>Second item</w:t>`) {
+		t.Errorf("expected second paragraph to become a numbered item: %s", doc)
+	}
+
+	if _, err := u.FormatParagraphs(godocx.ParagraphsMatching("item$"), godocx.ParagraphFormat{RemoveList: true}); err != nil {
+		t.Fatalf("FormatParagraphs failed: %v", err)
+	}
+	doc = saveAndReadDocument(t, u)
+	if strings.Contains(doc, "<w:numPr>") {
+		t.Error("expected list numbering to be removed")
+	}
+	if !strings.Contains(doc, `<w:p><w:r><w:t>Second item</w:t>`) {
+		t.Error("expected emptied paragraph properties to be removed")
+	}
+}
+
+func TestFormatParagraphsValidation(t *testing.T) {
+	u := openFormatFixture(t)
+
+	tests := []struct {
+		name     string
+		selector godocx.ParagraphSelector
+		format   godocx.ParagraphFormat
+	}{
+		{"empty format", godocx.ParagraphsMatching("Note"), godocx.ParagraphFormat{}},
+		{"zero selector", godocx.ParagraphSelector{}, godocx.ParagraphFormat{Bold: ptrBool(true)}},
+		{"invalid regex", godocx.ParagraphsMatching("("), godocx.ParagraphFormat{Bold: ptrBool(true)}},
+		{"range out of bounds", godocx.ParagraphsInRange(0, 10), godocx.ParagraphFormat{Bold: ptrBool(true)}},
+		{"list conflict", godocx.ParagraphsInRange(0, 0), godocx.ParagraphFormat{ListType: godocx.ListTypeBullet, RemoveList: true}},
+		{"bad alignment", godocx.ParagraphsInRange(0, 0), godocx.ParagraphFormat{Alignment: "middle"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := u.FormatParagraphs(tt.selector, tt.format); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
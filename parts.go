@@ -0,0 +1,523 @@
+package godocx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// documentPart is the package part name of the main document
+const documentPart = "word/document.xml"
+
+// relationshipRefs returns the relationship IDs referenced in XML (r:id,
+// r:embed, ...) in order of first appearance
+func relationshipRefs(data []byte) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range relationshipRefPattern.FindAllSubmatch(data, -1) {
+		id := string(m[1])
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// releaseRelationships removes the given document relationships that are no
+// longer referenced in docXML. Parts they point to are deleted when no other
+// relationship in the package targets them.
+func (u *Updater) releaseRelationships(docXML []byte, ids []string) error {
+	inUse := make(map[string]bool)
+	for _, id := range relationshipRefs(docXML) {
+		inUse[id] = true
+	}
+
+	relsPath := filepath.Join(u.tempDir, "word", "_rels", "document.xml.rels")
+	rels, err := readRelationships(relsPath)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if inUse[id] {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID != id {
+				continue
+			}
+			if err := removeRelationship(relsPath, id); err != nil {
+				return err
+			}
+			if rel.TargetMode != "External" {
+				if err := u.removePartIfUnreferenced(resolvePartTarget(documentPart, rel.Target)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// documentRelationshipIDs returns the IDs of the relationships of the main
+// document
+func (u *Updater) documentRelationshipIDs() (map[string]bool, error) {
+	rels, err := readRelationships(filepath.Join(u.tempDir, "word", "_rels", "document.xml.rels"))
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		ids[rel.ID] = true
+	}
+	return ids, nil
+}
+
+// releaseAddedRelationships removes the document relationships added since
+// the IDs in before were read, with their parts, when content that used them
+// could not be inserted. docXML is the unchanged document.
+func (u *Updater) releaseAddedRelationships(docXML []byte, before map[string]bool) error {
+	after, err := u.documentRelationshipIDs()
+	if err != nil {
+		return err
+	}
+	var added []string
+	for id := range after {
+		if !before[id] {
+			added = append(added, id)
+		}
+	}
+	return u.releaseRelationships(docXML, added)
+}
+
+// removePartIfUnreferenced deletes a package part, its relationships and
+// its content type override unless another relationship still targets it.
+// Parts only used by the deleted part are removed as well.
+func (u *Updater) removePartIfUnreferenced(part string) error {
+	referenced, err := u.partReferenced(part)
+	if err != nil {
+		return err
+	}
+	if referenced {
+		return nil
+	}
+
+	relsPath := filepath.Join(u.tempDir, filepath.FromSlash(partRelsName(part)))
+	if rels, err := readRelationships(relsPath); err == nil {
+		if err := os.Remove(relsPath); err != nil {
+			return fmt.Errorf("remove %s: %w", partRelsName(part), err)
+		}
+		for _, rel := range rels.Relationships {
+			if rel.TargetMode == "External" {
+				continue
+			}
+			if err := u.removePartIfUnreferenced(resolvePartTarget(part, rel.Target)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := os.Remove(filepath.Join(u.tempDir, filepath.FromSlash(part))); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove %s: %w", part, err)
+	}
+
+	return u.removeContentTypeOverride("/" + part)
+}
+
+// partReferenced reports whether any relationship in the package targets part
+func (u *Updater) partReferenced(part string) (bool, error) {
+	found := false
+	err := filepath.WalkDir(u.tempDir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if found || d.IsDir() || !strings.HasSuffix(d.Name(), ".rels") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(u.tempDir, p)
+		if err != nil {
+			return err
+		}
+		source := relsSourcePart(filepath.ToSlash(rel))
+
+		rels, err := readRelationships(p)
+		if err != nil {
+			return nil
+		}
+		for _, r := range rels.Relationships {
+			if r.TargetMode != "External" && resolvePartTarget(source, r.Target) == part {
+				found = true
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("scan relationships: %w", err)
+	}
+	return found, nil
+}
+
+// readRelationships parses a .rels file
+func readRelationships(relsPath string) (relationships, error) {
+	var rels relationships
+	raw, err := os.ReadFile(relsPath)
+	if err != nil {
+		return rels, fmt.Errorf("read relationships: %w", err)
+	}
+	if err := xml.Unmarshal(raw, &rels); err != nil {
+		return rels, fmt.Errorf("parse relationships: %w", err)
+	}
+	return rels, nil
+}
+
+// removeRelationship removes the relationship with the given ID from a .rels file
+func removeRelationship(relsPath, id string) error {
+	raw, err := os.ReadFile(relsPath)
+	if err != nil {
+		return fmt.Errorf("read relationships: %w", err)
+	}
+
+	updated, ok := removeElementWithAttr(raw, "Relationship", fmt.Sprintf(`Id="%s"`, id))
+	if !ok {
+		return NewRelationshipError(fmt.Sprintf("relationship %s not found", id), nil)
+	}
+
+	if err := os.WriteFile(relsPath, updated, 0o644); err != nil {
+		return fmt.Errorf("write relationships: %w", err)
+	}
+	return nil
+}
+
+// removeContentTypeOverride removes the Override for a part name from
+// [Content_Types].xml, if present
+func (u *Updater) removeContentTypeOverride(partName string) error {
+	contentTypesPath := filepath.Join(u.tempDir, "[Content_Types].xml")
+	raw, err := os.ReadFile(contentTypesPath)
+	if err != nil {
+		return fmt.Errorf("read content types: %w", err)
+	}
+
+	updated, ok := removeElementWithAttr(raw, "Override", fmt.Sprintf(`PartName="%s"`, partName))
+	if !ok {
+		return nil
+	}
+	return os.WriteFile(contentTypesPath, updated, 0o644)
+}
+
+// removeElementWithAttr removes the first element named tag that carries
+// the given attribute, along with the whitespace preceding it
+func removeElementWithAttr(raw []byte, tag, attr string) ([]byte, bool) {
+	searchPos := 0
+	for {
+		idx := bytes.Index(raw[searchPos:], []byte(attr))
+		if idx == -1 {
+			return raw, false
+		}
+		idx += searchPos
+
+		start := bytes.LastIndex(raw[:idx], []byte("<"+tag))
+		if start == -1 || bytes.IndexByte(raw[start:idx], '>') != -1 {
+			searchPos = idx + len(attr)
+			continue
+		}
+
+		end := findQualifiedElementEnd(raw, start, tag)
+		if end == -1 {
+			return raw, false
+		}
+		for start > 0 && isXMLSpace(raw[start-1]) {
+			start--
+		}
+
+		result := make([]byte, 0, len(raw)-(end-start))
+		result = append(result, raw[:start]...)
+		result = append(result, raw[end:]...)
+		return result, true
+	}
+}
+
+// isXMLSpace reports whether b is XML whitespace
+func isXMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// resolvePartTarget resolves a relationship target against its source part
+func resolvePartTarget(source, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Clean(path.Join(path.Dir(source), target))
+}
+
+// partRelsName returns the name of the .rels part belonging to part
+func partRelsName(part string) string {
+	return path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+}
+
+// relsSourcePart returns the part a .rels part belongs to; the package
+// relationships (_rels/.rels) have the package root as source
+func relsSourcePart(relsName string) string {
+	dir := path.Dir(path.Dir(relsName))
+	name := strings.TrimSuffix(path.Base(relsName), ".rels")
+	if dir == "." {
+		return name
+	}
+	return path.Join(dir, name)
+}
+
+// startTag returns the first start tag of data beginning with prefix (e.g.,
+// "<a:blip "), or nil
+func startTag(data []byte, prefix string) []byte {
+	start := bytes.Index(data, []byte(prefix))
+	if start == -1 {
+		return nil
+	}
+	end := bytes.IndexByte(data[start:], '>')
+	if end == -1 {
+		return nil
+	}
+	return data[start : start+end]
+}
+
+// xmlAttr returns the value of an attribute in a start tag
+func xmlAttr(tag []byte, name string) string {
+	needle := []byte(" " + name + `="`)
+	idx := bytes.Index(tag, needle)
+	if idx == -1 {
+		return ""
+	}
+	valueStart := idx + len(needle)
+	valueEnd := bytes.IndexByte(tag[valueStart:], '"')
+	if valueEnd == -1 {
+		return ""
+	}
+	return xmlUnescape(string(tag[valueStart : valueStart+valueEnd]))
+}
+
+// removeDrawings removes the drawings (<w:drawing>, or VML <w:pict>) of the
+// document body for which match returns true, up to limit drawings (0 for
+// all). The run holding a drawing is removed, or the whole paragraph when no
+// other run remains. It returns the updated document and the removed XML.
+func removeDrawings(docXML []byte, match func(drawingXML []byte) bool, limit int) ([]byte, []byte) {
+	paragraphs := scanStoryParagraphs(docXML, StoryDocument)
+	runsByParagraph := make(map[int][]elementSpan)
+	var order []int
+
+	count := 0
+	pos := 0
+	for limit == 0 || count < limit {
+		name := "w:drawing"
+		start := findNextTagStart(docXML, pos, name)
+		if pict := findNextTagStart(docXML, pos, "w:pict"); pict != -1 && (start == -1 || pict < start) {
+			name, start = "w:pict", pict
+		}
+		if start == -1 {
+			break
+		}
+		end := findQualifiedElementEnd(docXML, start, name)
+		if end == -1 {
+			break
+		}
+		pos = end
+
+		if !match(docXML[start:end]) {
+			continue
+		}
+		count++
+		if i, run, ok := drawingRun(docXML, paragraphs, start, end); ok {
+			runs, seen := runsByParagraph[i]
+			if !seen {
+				order = append(order, i)
+			}
+			if len(runs) == 0 || runs[len(runs)-1] != run {
+				runsByParagraph[i] = append(runs, run)
+			}
+		}
+	}
+
+	var spans []elementSpan
+	var removed bytes.Buffer
+	for _, i := range order {
+		para := paragraphs[i]
+		runs := runsByParagraph[i]
+		if len(runs) == len(paragraphRuns(docXML[para.start:para.end])) {
+			runs = []elementSpan{{start: para.start, end: para.end, name: "w:p"}}
+		}
+		for _, run := range runs {
+			removed.Write(docXML[run.start:run.end])
+		}
+		spans = append(spans, runs...)
+	}
+
+	if len(spans) == 0 {
+		return docXML, nil
+	}
+	return deleteElements(docXML, spans), removed.Bytes()
+}
+
+// drawingRun returns the paragraph index and the run holding the drawing at
+// docXML[start:end]
+func drawingRun(docXML []byte, paragraphs []paragraphSpan, start, end int) (int, elementSpan, bool) {
+	for i, para := range paragraphs {
+		if start < para.start || end > para.end {
+			continue
+		}
+		for _, run := range paragraphRuns(docXML[para.start:para.end]) {
+			if start >= para.start+run.start && end <= para.start+run.end {
+				return i, elementSpan{start: para.start + run.start, end: para.start + run.end, name: "w:r"}, true
+			}
+		}
+	}
+	return 0, elementSpan{}, false
+}
+
+// duplicatePart copies a package part under the next free name in its
+// folder (e.g., chart3.xml for chart1.xml when chart2.xml exists) and
+// returns the new part name. Parts it relates to are duplicated as well, so
+// the copy can be edited independently of the original.
+func (u *Updater) duplicatePart(part string) (string, error) {
+	newPart, err := u.nextPartName(part)
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := os.ReadFile(filepath.Join(u.tempDir, filepath.FromSlash(part)))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", part, err)
+	}
+	if err := os.WriteFile(filepath.Join(u.tempDir, filepath.FromSlash(newPart)), raw, 0o644); err != nil {
+		return "", fmt.Errorf("write %s: %w", newPart, err)
+	}
+	if err := u.copyContentTypeOverride("/"+part, "/"+newPart); err != nil {
+		return "", err
+	}
+
+	relsPath := filepath.Join(u.tempDir, filepath.FromSlash(partRelsName(part)))
+	rels, err := readRelationships(relsPath)
+	if err != nil {
+		// The part has no relationships
+		return newPart, nil
+	}
+	relsXML, err := os.ReadFile(relsPath)
+	if err != nil {
+		return "", fmt.Errorf("read relationships: %w", err)
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.TargetMode == "External" {
+			continue
+		}
+		target, err := u.duplicatePart(resolvePartTarget(part, rel.Target))
+		if err != nil {
+			return "", err
+		}
+		newTarget := path.Join(path.Dir(rel.Target), path.Base(target))
+		relsXML = bytes.Replace(relsXML, fmt.Appendf(nil, `Target="%s"`, rel.Target), fmt.Appendf(nil, `Target="%s"`, newTarget), 1)
+	}
+
+	newRelsPath := filepath.Join(u.tempDir, filepath.FromSlash(partRelsName(newPart)))
+	if err := os.WriteFile(newRelsPath, relsXML, 0o644); err != nil {
+		return "", fmt.Errorf("write relationships: %w", err)
+	}
+	return newPart, nil
+}
+
+// nextPartName returns an unused part name in the folder of part, numbered
+// one above the highest existing part with the same name prefix
+func (u *Updater) nextPartName(part string) (string, error) {
+	dir := path.Dir(part)
+	ext := path.Ext(part)
+	prefix := strings.TrimRight(strings.TrimSuffix(path.Base(part), ext), "0123456789")
+
+	entries, err := os.ReadDir(filepath.Join(u.tempDir, filepath.FromSlash(dir)))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", dir, err)
+	}
+
+	maxIndex := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || path.Ext(name) != ext {
+			continue
+		}
+		if index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)); err == nil && index > maxIndex {
+			maxIndex = index
+		}
+	}
+
+	return path.Join(dir, fmt.Sprintf("%s%d%s", prefix, maxIndex+1, ext)), nil
+}
+
+// copyContentTypeOverride registers newPartName with the content type
+// override of partName, if it has one
+func (u *Updater) copyContentTypeOverride(partName, newPartName string) error {
+	contentTypesPath := filepath.Join(u.tempDir, "[Content_Types].xml")
+	raw, err := os.ReadFile(contentTypesPath)
+	if err != nil {
+		return fmt.Errorf("read content types: %w", err)
+	}
+
+	idx := bytes.Index(raw, fmt.Appendf(nil, `PartName="%s"`, partName))
+	if idx == -1 {
+		return nil
+	}
+	start := bytes.LastIndex(raw[:idx], []byte("<Override"))
+	end := bytes.IndexByte(raw[idx:], '>')
+	if start == -1 || end == -1 {
+		return nil
+	}
+	contentType := xmlAttr(raw[start:idx+end], "ContentType")
+
+	insert := fmt.Sprintf("\n  <Override PartName=\"%s\" ContentType=\"%s\"/>\n", newPartName, contentType)
+	closer := []byte("</Types>")
+	pos := bytes.LastIndex(raw, closer)
+	if pos == -1 {
+		return fmt.Errorf("invalid [Content_Types].xml: missing </Types>")
+	}
+	result := make([]byte, len(raw)+len(insert))
+	n := copy(result, raw[:pos])
+	n += copy(result[n:], []byte(insert))
+	copy(result[n:], raw[pos:])
+	return os.WriteFile(contentTypesPath, result, 0o644)
+}
+
+// addDocumentRelationship appends a relationship to document.xml.rels and
+// returns its Id
+func (u *Updater) addDocumentRelationship(relType, target string) (string, error) {
+	relsPath := filepath.Join(u.tempDir, "word", "_rels", "document.xml.rels")
+	raw, err := os.ReadFile(relsPath)
+	if err != nil {
+		return "", fmt.Errorf("read document relationships: %w", err)
+	}
+
+	nextRelId, err := u.getNextDocumentRelId()
+	if err != nil {
+		return "", err
+	}
+
+	insert := fmt.Sprintf("\n  <Relationship Id=\"%s\" Type=\"%s\" Target=\"%s\"/>\n", nextRelId, relType, target)
+	closer := []byte("</Relationships>")
+	pos := bytes.LastIndex(raw, closer)
+	if pos == -1 {
+		return "", fmt.Errorf("invalid document.xml.rels: missing </Relationships>")
+	}
+	result := make([]byte, len(raw)+len(insert))
+	n := copy(result, raw[:pos])
+	n += copy(result[n:], []byte(insert))
+	copy(result[n:], raw[pos:])
+
+	if err := os.WriteFile(relsPath, result, 0o644); err != nil {
+		return "", fmt.Errorf("write relationships: %w", err)
+	}
+	return nextRelId, nil
+}
@@ -0,0 +1,474 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// InsertPosition defines where to insert content in the document body.
+// Insert operations whose options set a Target insert at the Target
+// instead.
+type InsertPosition int
+
+const (
+	// PositionBeginning inserts at the start of the document body
+	PositionBeginning InsertPosition = iota
+	// PositionEnd inserts at the end of the document body
+	PositionEnd
+	// PositionAfterText inserts after the paragraph containing the anchor text
+	PositionAfterText
+	// PositionBeforeText inserts before the paragraph containing the anchor text
+	PositionBeforeText
+	// PositionAfterBookmark inserts after the paragraph where the bookmark
+	// named by the anchor ends
+	PositionAfterBookmark
+	// PositionBeforeBookmark inserts before the paragraph where the bookmark
+	// named by the anchor starts
+	PositionBeforeBookmark
+)
+
+// String returns a readable name for the position
+func (p InsertPosition) String() string {
+	switch p {
+	case PositionBeginning:
+		return "PositionBeginning"
+	case PositionEnd:
+		return "PositionEnd"
+	case PositionAfterText:
+		return "PositionAfterText"
+	case PositionBeforeText:
+		return "PositionBeforeText"
+	case PositionAfterBookmark:
+		return "PositionAfterBookmark"
+	case PositionBeforeBookmark:
+		return "PositionBeforeBookmark"
+	default:
+		return fmt.Sprintf("InsertPosition(%d)", int(p))
+	}
+}
+
+// targetKind identifies how a Target is resolved
+type targetKind int
+
+const (
+	targetAfterMatch targetKind = iota
+	targetBeforeMatch
+	targetAfterTable
+	targetEndOfHeadingSection
+	targetInsideTableCell
+	targetBeforeRange
+	targetAfterRange
+)
+
+// Target is an insertion point that a plain InsertPosition cannot express:
+// a FindText match, a table or table cell, the end of a heading section or
+// a Range. Set it as the Target of an options struct; it takes precedence
+// over Position and Anchor.
+type Target struct {
+	kind  targetKind
+	match *TextMatch
+	rng   *Range
+	text  string
+	table int
+	row   int
+	cell  int
+}
+
+// positionAnchor carries the anchor options of an insert operation
+type positionAnchor struct {
+	text       string
+	occurrence int  // 1-based; 0 means first
+	regex      bool // text is a regular expression
+}
+
+// matcher returns a function reporting whether paragraph text contains the anchor
+func (a positionAnchor) matcher() (func(string) bool, error) {
+	if a.regex {
+		re, err := regexp.Compile(a.text)
+		if err != nil {
+			return nil, NewInvalidRegexError(a.text, err)
+		}
+		return re.MatchString, nil
+	}
+
+	normalizedAnchor := normalizeWhitespace(a.text)
+	return func(paragraphText string) bool {
+		if strings.Contains(paragraphText, a.text) {
+			return true
+		}
+		return normalizedAnchor != "" && strings.Contains(normalizeWhitespace(paragraphText), normalizedAnchor)
+	}, nil
+}
+
+// TargetAfterMatch inserts after the paragraph containing a match returned
+// by FindText. Unlike PositionAfterText it addresses the exact paragraph of
+// the match, even when the same text occurs several times. Locations
+// describe the document at search time, so search again after edits that
+// add or remove paragraphs ahead of the match.
+func TargetAfterMatch(match TextMatch) *Target {
+	return &Target{kind: targetAfterMatch, match: &match}
+}
+
+// TargetBeforeMatch inserts before the paragraph containing a match
+// returned by FindText
+func TargetBeforeMatch(match TextMatch) *Target {
+	return &Target{kind: targetBeforeMatch, match: &match}
+}
+
+// TargetAfterTable inserts directly after the n-th top-level table of the
+// document (1-based, like chart indexes)
+func TargetAfterTable(n int) *Target {
+	return &Target{kind: targetAfterTable, table: n}
+}
+
+// TargetEndOfHeadingSection inserts at the end of the section started by
+// the heading containing text, i.e. before the next heading of the same or a
+// higher level, or at the end of the document. The Occurrence of the
+// options picks among headings with the same text.
+func TargetEndOfHeadingSection(text string) *Target {
+	return &Target{kind: targetEndOfHeadingSection, text: text}
+}
+
+// TargetInsideTableCell inserts at the end of a cell of the t-th top-level
+// table (all indexes 1-based). An empty placeholder paragraph in the cell is
+// replaced by the inserted content.
+func TargetInsideTableCell(t, r, c int) *Target {
+	return &Target{kind: targetInsideTableCell, table: t, row: r, cell: c}
+}
+
+// String returns a readable name for the target
+func (t *Target) String() string {
+	switch t.kind {
+	case targetAfterMatch:
+		return "TargetAfterMatch"
+	case targetBeforeMatch:
+		return "TargetBeforeMatch"
+	case targetAfterTable:
+		return fmt.Sprintf("TargetAfterTable(%d)", t.table)
+	case targetEndOfHeadingSection:
+		return fmt.Sprintf("TargetEndOfHeadingSection(%q)", t.text)
+	case targetInsideTableCell:
+		return fmt.Sprintf("TargetInsideTableCell(%d, %d, %d)", t.table, t.row, t.cell)
+	case targetBeforeRange:
+		return "TargetBeforeRange"
+	case targetAfterRange:
+		return "TargetAfterRange"
+	default:
+		return fmt.Sprintf("Target(%d)", t.kind)
+	}
+}
+
+// insertAtPosition inserts block-level content (paragraphs, tables) into
+// document.xml at target, or at pos when target is nil
+func insertAtPosition(docXML, content []byte, pos InsertPosition, target *Target, anchor positionAnchor) ([]byte, error) {
+	start, end, err := resolveInsertPosition(docXML, pos, target, anchor)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Contains(content, []byte("<w:sectPr")) && offsetInTable(docXML, start) {
+		return nil, NewValidationError("position", "section breaks cannot be inserted inside a table")
+	}
+
+	return spliceBytes(docXML, content, start, end), nil
+}
+
+// resolveInsertPosition returns the byte range of document.xml replaced by
+// inserted content. For most positions the range is empty (start == end).
+func resolveInsertPosition(docXML []byte, pos InsertPosition, target *Target, anchor positionAnchor) (int, int, error) {
+	if target != nil {
+		return resolveTarget(docXML, target, anchor)
+	}
+
+	switch pos {
+	case PositionBeginning:
+		start, err := findBodyContentStart(docXML)
+		return start, start, err
+
+	case PositionEnd:
+		end, err := findBodyEndInsertPos(docXML)
+		return end, end, err
+
+	case PositionAfterText, PositionBeforeText:
+		if anchor.text == "" {
+			return 0, 0, NewValidationError("anchor", fmt.Sprintf("anchor text required for %s", pos))
+		}
+		paraStart, paraEnd, err := findParagraphRangeByAnchor(docXML, anchor)
+		if err != nil {
+			return 0, 0, err
+		}
+		if pos == PositionAfterText {
+			return paraEnd, paraEnd, nil
+		}
+		return paraStart, paraStart, nil
+
+	case PositionAfterBookmark, PositionBeforeBookmark:
+		if anchor.text == "" {
+			return 0, 0, NewValidationError("anchor", fmt.Sprintf("bookmark name required for %s", pos))
+		}
+		offset, err := findBookmarkInsertPos(docXML, anchor.text, pos == PositionAfterBookmark)
+		return offset, offset, err
+
+	default:
+		return 0, 0, fmt.Errorf("invalid insert position: %s", pos)
+	}
+}
+
+// resolveTarget returns the byte range of document.xml replaced by content
+// inserted at a Target
+func resolveTarget(docXML []byte, target *Target, anchor positionAnchor) (int, int, error) {
+	switch target.kind {
+	case targetAfterMatch, targetBeforeMatch:
+		paraStart, paraEnd, err := findParagraphByMatch(docXML, target.match)
+		if err != nil {
+			return 0, 0, err
+		}
+		if target.kind == targetAfterMatch {
+			return paraEnd, paraEnd, nil
+		}
+		return paraStart, paraStart, nil
+
+	case targetAfterTable:
+		tables := scanStoryTables(docXML)
+		if target.table < 1 || target.table > len(tables) {
+			return 0, 0, NewValidationError("position", fmt.Sprintf("table %d not found (document has %d tables)", target.table, len(tables)))
+		}
+		end := tables[target.table-1].end
+		return end, end, nil
+
+	case targetEndOfHeadingSection:
+		offset, err := findHeadingSectionEnd(docXML, positionAnchor{text: target.text, occurrence: anchor.occurrence})
+		return offset, offset, err
+
+	case targetInsideTableCell:
+		return findTableCellInsertRange(docXML, target.table, target.row, target.cell)
+
+	case targetBeforeRange, targetAfterRange:
+		offset, err := resolveRangePosition(docXML, target.rng, target.kind == targetBeforeRange)
+		return offset, offset, err
+
+	default:
+		return 0, 0, fmt.Errorf("invalid insert target: %s", target)
+	}
+}
+
+// findParagraphByMatch returns the paragraph range of a FindText match in
+// document.xml, verifying that the matched text is still present there
+func findParagraphByMatch(docXML []byte, match *TextMatch) (int, int, error) {
+	if match == nil {
+		return 0, 0, NewValidationError("match", "match cannot be nil")
+	}
+
+	loc := match.Location
+	if loc.Story != StoryDocument {
+		return 0, 0, NewValidationError("match", fmt.Sprintf("match is in %q; only matches in %s can be used as insertion anchors", loc.Story, StoryDocument))
+	}
+
+	paraStart, paraEnd, err := findParagraphByLocation(docXML, loc)
+	if err != nil {
+		return 0, 0, fmt.Errorf("locate match: %w", err)
+	}
+
+	paraText := strings.Join(paragraphRunTexts(docXML[paraStart:paraEnd]), "")
+	if match.Text != "" && !strings.Contains(paraText, match.Text) {
+		return 0, 0, fmt.Errorf("match %q is no longer at its recorded location", match.Text)
+	}
+
+	return paraStart, paraEnd, nil
+}
+
+// findBookmarkInsertPos returns the offset after the paragraph holding the
+// end of a bookmark (after == true) or before the paragraph holding its
+// start. Bookmarks placed between paragraphs are used as-is.
+func findBookmarkInsertPos(docXML []byte, name string, after bool) (int, error) {
+	startIdx, endIdx, err := findBookmarkMarkers(docXML, name)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, para := range scanStoryParagraphs(docXML, StoryDocument) {
+		if after && endIdx >= para.start && endIdx < para.end {
+			return para.end, nil
+		}
+		if !after && startIdx >= para.start && startIdx < para.end {
+			return para.start, nil
+		}
+	}
+
+	if after {
+		tagEnd := bytes.IndexByte(docXML[endIdx:], '>')
+		return endIdx + tagEnd + 1, nil
+	}
+	return startIdx, nil
+}
+
+// findBookmarkMarkers returns the offsets of the <w:bookmarkStart> and
+// matching <w:bookmarkEnd> elements of a named bookmark
+func findBookmarkMarkers(docXML []byte, name string) (int, int, error) {
+	nameAttr := []byte(`w:name="` + xmlEscape(name) + `"`)
+
+	pos := 0
+	for {
+		startIdx := findNextWordTagStart(docXML, pos, "bookmarkStart")
+		if startIdx == -1 {
+			return 0, 0, NewValidationError("bookmark", fmt.Sprintf("bookmark %q not found", name))
+		}
+		tagEnd := bytes.IndexByte(docXML[startIdx:], '>')
+		if tagEnd == -1 {
+			return 0, 0, fmt.Errorf("malformed bookmarkStart element")
+		}
+		tag := docXML[startIdx : startIdx+tagEnd+1]
+		pos = startIdx + tagEnd + 1
+
+		if !bytes.Contains(tag, nameAttr) {
+			continue
+		}
+
+		idMatch := bookmarkIDPattern.FindSubmatch(tag)
+		if idMatch == nil {
+			return 0, 0, fmt.Errorf("bookmark %q has no id", name)
+		}
+		endMarker := []byte(`<w:bookmarkEnd w:id="` + string(idMatch[1]) + `"`)
+		endRel := bytes.Index(docXML[pos:], endMarker)
+		if endRel == -1 {
+			// A bookmark without an end marker collapses to its start
+			return startIdx, startIdx, nil
+		}
+		return startIdx, pos + endRel, nil
+	}
+}
+
+// paragraphHeadingLevel returns the outline level of a paragraph (0 for
+// Title, 1-9 for headings) or -1 if it is not a heading
+func paragraphHeadingLevel(paragraphXML []byte) int {
+	pPrEnd := bytes.Index(paragraphXML, []byte("</w:pPr>"))
+	if pPrEnd == -1 {
+		return -1
+	}
+	pPr := paragraphXML[:pPrEnd]
+
+	if m := pStyleValPattern.FindSubmatch(pPr); m != nil {
+		style := string(m[1])
+		if strings.EqualFold(style, "Title") {
+			return 0
+		}
+		if lm := headingStylePattern.FindStringSubmatch(style); lm != nil {
+			level, _ := strconv.Atoi(lm[1])
+			return level
+		}
+	}
+
+	if m := outlineLvlPattern.FindSubmatch(pPr); m != nil {
+		level, err := strconv.Atoi(string(m[1]))
+		if err == nil && level < 9 {
+			return level + 1
+		}
+	}
+
+	return -1
+}
+
+// findHeadingSectionEnd returns the offset where a heading's section ends:
+// the start of the next body-level heading of the same or a higher level,
+// or the end of the body
+func findHeadingSectionEnd(docXML []byte, heading positionAnchor) (int, error) {
+	if heading.text == "" {
+		return 0, NewValidationError("position", "heading text required for TargetEndOfHeadingSection")
+	}
+
+	matches, err := heading.matcher()
+	if err != nil {
+		return 0, err
+	}
+	occurrence := max(heading.occurrence, 1)
+
+	found := 0
+	level := -1
+	for _, para := range scanStoryParagraphs(docXML, StoryDocument) {
+		if para.loc.InTable() {
+			continue
+		}
+		paraXML := docXML[para.start:para.end]
+		paraLevel := paragraphHeadingLevel(paraXML)
+		if paraLevel < 0 {
+			continue
+		}
+
+		if level >= 0 {
+			if paraLevel <= level {
+				return para.start, nil
+			}
+			continue
+		}
+
+		if matches(extractParagraphPlainText(paraXML)) {
+			found++
+			if found == occurrence {
+				level = paraLevel
+			}
+		}
+	}
+
+	if level < 0 {
+		return 0, NewValidationError("position", fmt.Sprintf("heading %q not found", heading.text))
+	}
+
+	return findBodyEndInsertPos(docXML)
+}
+
+// findTableCellInsertRange returns the range used to insert content into a
+// table cell (1-based indexes): the cell's empty placeholder paragraph if it
+// has one, otherwise the end of the cell content
+func findTableCellInsertRange(docXML []byte, t, r, c int) (int, int, error) {
+	tables := scanStoryTables(docXML)
+	if t < 1 || t > len(tables) {
+		return 0, 0, NewValidationError("position", fmt.Sprintf("table %d not found (document has %d tables)", t, len(tables)))
+	}
+	tbl := tables[t-1]
+	if r < 1 || r > len(tbl.rows) {
+		return 0, 0, NewValidationError("position", fmt.Sprintf("row %d not found in table %d (%d rows)", r, t, len(tbl.rows)))
+	}
+	row := tbl.rows[r-1]
+	if c < 1 || c > len(row.cells) {
+		return 0, 0, NewValidationError("position", fmt.Sprintf("cell %d not found in table %d row %d (%d cells)", c, t, r, len(row.cells)))
+	}
+	cell := row.cells[c-1]
+
+	if len(cell.paragraphs) == 1 {
+		para := cell.paragraphs[0]
+		if findNextWordTagStart(docXML[para.start:para.end], 0, "r") == -1 {
+			return para.start, para.end, nil
+		}
+	}
+
+	end := cell.end - len("</w:tc>")
+	return end, end, nil
+}
+
+// offsetInTable reports whether offset lies inside a table
+func offsetInTable(docXML []byte, offset int) bool {
+	for _, tbl := range scanStoryTables(docXML) {
+		if offset > tbl.start && offset < tbl.end {
+			return true
+		}
+	}
+	return false
+}
+
+// spliceBytes replaces data[start:end] with content. A table inserted as
+// the last element of a table cell is followed by an empty paragraph, since
+// Word requires every cell to end with a paragraph.
+func spliceBytes(data, content []byte, start, end int) []byte {
+	if bytes.HasSuffix(content, []byte("</w:tbl>")) && bytes.HasPrefix(data[end:], []byte("</w:tc>")) {
+		content = append(append([]byte{}, content...), []byte("<w:p/>")...)
+	}
+
+	result := make([]byte, 0, len(data)-(end-start)+len(content))
+	result = append(result, data[:start]...)
+	result = append(result, content...)
+	result = append(result, data[end:]...)
+	return result
+}
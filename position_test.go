@@ -0,0 +1,224 @@
+package godocx_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+const positionFixtureBody = `<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Results</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>Note: first</w:t></w:r></w:p>` +
+	`<w:p><w:pPr><w:pStyle w:val="Heading2"/></w:pPr><w:r><w:t>Details</w:t></w:r></w:p>` +
+	`<w:p><w:bookmarkStart w:id="3" w:name="Summary"/><w:r><w:t>Summary text</w:t></w:r><w:bookmarkEnd w:id="3"/></w:p>` +
+	`<w:tbl><w:tr><w:tc><w:p/></w:tc><w:tc><w:p><w:r><w:t>B1</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:p><w:r><w:t>Note: second</w:t></w:r></w:p>` +
+	`<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Appendix</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>Item 2024-01</w:t></w:r></w:p>`
+
+func openPositionFixture(t *testing.T) *godocx.Updater {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, positionFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+	return u
+}
+
+func saveAndReadDocument(t *testing.T, u *godocx.Updater) string {
+	t.Helper()
+
+	outPath := filepath.Join(t.TempDir(), "out.docx")
+	if err := u.Save(outPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	return readZipEntry(t, outPath, "word/document.xml")
+}
+
+// assertOrder checks that parts occur in doc in the given order
+func assertOrder(t *testing.T, doc string, parts ...string) {
+	t.Helper()
+
+	pos := 0
+	for i, part := range parts {
+		idx := strings.Index(doc[pos:], part)
+		if idx == -1 {
+			if i == 0 || !strings.Contains(doc, part) {
+				t.Errorf("expected %q in document", part)
+			} else {
+				t.Errorf("expected %q after %q", part, parts[i-1])
+			}
+			return
+		}
+		pos += idx + len(part)
+	}
+}
+
+func TestInsertAtBookmarkPositions(t *testing.T) {
+	u := openPositionFixture(t)
+
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:     "Before bookmark",
+		Position: godocx.PositionBeforeBookmark,
+		Anchor:   "Summary",
+	}); err != nil {
+		t.Fatalf("InsertParagraph before bookmark failed: %v", err)
+	}
+	if err := u.InsertPageBreak(godocx.BreakOptions{
+		Position: godocx.PositionAfterBookmark,
+		Anchor:   "Summary",
+	}); err != nil {
+		t.Fatalf("InsertPageBreak after bookmark failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "Details", "Before bookmark", "Summary text", `<w:br w:type="page"/>`, "<w:tbl>")
+
+	err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:     "x",
+		Position: godocx.PositionAfterBookmark,
+		Anchor:   "Missing",
+	})
+	if err == nil {
+		t.Error("expected error for missing bookmark")
+	}
+}
+
+func TestInsertAfterTable(t *testing.T) {
+	u := openPositionFixture(t)
+
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "After table",
+		Target: godocx.TargetAfterTable(1),
+	}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "</w:tbl>", "After table", "Note: second")
+
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "x",
+		Target: godocx.TargetAfterTable(2),
+	}); err == nil {
+		t.Error("expected error for missing table")
+	}
+}
+
+func TestInsertChartAtTarget(t *testing.T) {
+	u := openPositionFixture(t)
+
+	// Non-column charts go through the extended chart generator
+	if err := u.InsertChart(godocx.ChartOptions{
+		ChartKind:  godocx.ChartKindLine,
+		Target:     godocx.TargetAfterTable(1),
+		Categories: []string{"Q1", "Q2"},
+		Series:     []godocx.SeriesData{{Name: "Sales", Values: []float64{1, 2}}},
+	}); err != nil {
+		t.Fatalf("InsertChart failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "</w:tbl>", "<c:chart ", "Note: second")
+}
+
+func TestInsertAtEndOfHeadingSection(t *testing.T) {
+	u := openPositionFixture(t)
+
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "End of results",
+		Target: godocx.TargetEndOfHeadingSection("Results"),
+	}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "End of appendix",
+		Target: godocx.TargetEndOfHeadingSection("Appendix"),
+	}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	// The Heading2 "Details" belongs to the Results section
+	assertOrder(t, doc, "Note: second", "End of results", "Appendix", "Item 2024-01", "End of appendix", "<w:sectPr/>")
+}
+
+func TestInsertInsideTableCell(t *testing.T) {
+	u := openPositionFixture(t)
+
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "A1 text",
+		Target: godocx.TargetInsideTableCell(1, 1, 1),
+	}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "B1 extra",
+		Target: godocx.TargetInsideTableCell(1, 1, 2),
+	}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if strings.Contains(doc, "<w:tc><w:p/>") {
+		t.Error("expected placeholder paragraph to be replaced")
+	}
+	assertOrder(t, doc, "<w:tc>", "A1 text", "</w:tc>", "B1", "B1 extra", "</w:tc>")
+
+	if err := u.InsertSectionBreak(godocx.BreakOptions{
+		Target: godocx.TargetInsideTableCell(1, 1, 1),
+	}); err == nil {
+		t.Error("expected error inserting a section break inside a table")
+	}
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:   "x",
+		Target: godocx.TargetInsideTableCell(1, 2, 1),
+	}); err == nil {
+		t.Error("expected error for missing row")
+	}
+}
+
+func TestInsertAnchorOccurrenceAndRegex(t *testing.T) {
+	u := openPositionFixture(t)
+
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:       "After second note",
+		Position:   godocx.PositionAfterText,
+		Anchor:     "Note:",
+		Occurrence: 2,
+	}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+	if err := u.InsertTable(godocx.TableOptions{
+		Columns:     []godocx.ColumnDefinition{{Title: "Regex anchored"}},
+		Rows:        [][]string{{"1"}},
+		Position:    godocx.PositionBeforeText,
+		Anchor:      `Item \d{4}-\d{2}`,
+		AnchorRegex: true,
+	}); err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "Note: first", "Note: second", "After second note", "Appendix", "Regex anchored", "Item 2024-01")
+
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:       "x",
+		Position:   godocx.PositionAfterText,
+		Anchor:     "Note:",
+		Occurrence: 3,
+	}); err == nil {
+		t.Error("expected error for missing occurrence")
+	}
+	if err := u.InsertParagraph(godocx.ParagraphOptions{
+		Text:        "x",
+		Position:    godocx.PositionAfterText,
+		Anchor:      "[",
+		AnchorRegex: true,
+	}); err == nil {
+		t.Error("expected error for invalid regex anchor")
+	}
+}
@@ -0,0 +1,821 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Range is a contiguous region of the document body. Ranges are obtained
+// from FindRange, BookmarkRange and ParagraphRange and can be edited several
+// times in a row without searching the document again.
+//
+// A range returned by FindRange, or by BookmarkRange for a bookmark inside a
+// single paragraph, covers runs within one paragraph (a text range). Other
+// ranges cover whole paragraphs and tables (a block range).
+//
+// A range follows its own edits. After other changes to the document it is
+// relocated by its content; if that content is no longer unique the range
+// operations return an ErrCodeStaleRange error.
+type Range struct {
+	u      *Updater
+	start  int
+	end    int
+	block  bool
+	snap   []byte
+	docLen int
+
+	// A text range covers whole runs; headCut and tailCut are the bytes of
+	// text of the first and last run outside the range. The runs are split
+	// at these offsets when the range is edited.
+	headCut int
+	tailCut int
+}
+
+// TextFormat defines character formatting applied by Range.ApplyFormat.
+// Zero and nil values leave the corresponding property unchanged; a pointer
+// to false removes the property.
+type TextFormat struct {
+	Bold       *bool
+	Italic     *bool
+	Underline  *bool
+	Strike     *bool
+	FontSize   int    // Font size in half-points (e.g., 24 = 12pt)
+	FontColor  string // Hex color (e.g., "FF0000")
+	FontFamily string // Font name (e.g., "Arial")
+	Highlight  string // Highlight color name (e.g., "yellow")
+}
+
+// runPropertyOrder is the schema order of <w:rPr> children (CT_RPr)
+var runPropertyOrder = []string{
+	"w:rStyle", "w:rFonts", "w:b", "w:bCs", "w:i", "w:iCs", "w:caps", "w:smallCaps",
+	"w:strike", "w:dstrike", "w:outline", "w:shadow", "w:emboss", "w:imprint",
+	"w:noProof", "w:snapToGrid", "w:vanish", "w:webHidden", "w:color", "w:spacing",
+	"w:w", "w:kern", "w:position", "w:sz", "w:szCs", "w:highlight", "w:u", "w:effect",
+	"w:bdr", "w:shd", "w:fitText", "w:vertAlign", "w:rtl", "w:cs", "w:em", "w:lang",
+	"w:eastAsianLayout", "w:specVanish", "w:oMath",
+}
+
+// FindRange returns a text range covering the first match of pattern in the
+// document body. The range covers exactly the matched text; runs are split at
+// the match boundaries when the range is edited, so finding a range leaves the
+// document unchanged. When neither InParagraphs nor InTables is set,
+// both are searched; headers, footers and notes are never searched.
+func (u *Updater) FindRange(pattern string, opts FindOptions) (*Range, error) {
+	if u == nil {
+		return nil, fmt.Errorf("updater is nil")
+	}
+
+	if !opts.InParagraphs && !opts.InTables {
+		opts.InParagraphs = true
+		opts.InTables = true
+	}
+	opts.InHeaders = false
+	opts.InFooters = false
+	opts.InFootnotes = false
+	opts.InEndnotes = false
+	opts.MaxResults = 1
+
+	matches, err := u.FindText(pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, NewTextNotFoundError(pattern)
+	}
+
+	return u.matchRange(matches[0])
+}
+
+// BookmarkRange returns the range enclosed by a bookmark. A bookmark within
+// a single paragraph yields a text range; otherwise the range covers the
+// paragraphs from the bookmark start to the bookmark end.
+func (u *Updater) BookmarkRange(name string) (*Range, error) {
+	if u == nil {
+		return nil, fmt.Errorf("updater is nil")
+	}
+	if name == "" {
+		return nil, NewValidationError("name", "bookmark name cannot be empty")
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return nil, err
+	}
+
+	startIdx, endIdx, err := findBookmarkMarkers(docXML, name)
+	if err != nil {
+		return nil, err
+	}
+	startTagEnd := startIdx + bytes.IndexByte(docXML[startIdx:], '>') + 1
+	if endIdx < startTagEnd {
+		endIdx = startTagEnd
+	}
+
+	var startPara, endPara *paragraphSpan
+	paragraphs := scanStoryParagraphs(docXML, StoryDocument)
+	for i := range paragraphs {
+		para := &paragraphs[i]
+		if startIdx >= para.start && startIdx < para.end {
+			startPara = para
+		}
+		if endIdx >= para.start && endIdx < para.end {
+			endPara = para
+		}
+	}
+
+	r := &Range{u: u}
+	if startPara != nil && startPara == endPara {
+		r.sync(docXML, startTagEnd, endIdx)
+		return r, nil
+	}
+
+	start, end := startTagEnd, endIdx
+	if startPara != nil {
+		start = startPara.start
+	}
+	if endPara != nil {
+		end = endPara.end
+	}
+	r.block = true
+	r.sync(docXML, start, end)
+	return r, nil
+}
+
+// ParagraphRange returns a block range covering the body-level paragraphs
+// i through j (0-based, inclusive) and any tables between them
+func (u *Updater) ParagraphRange(i, j int) (*Range, error) {
+	if u == nil {
+		return nil, fmt.Errorf("updater is nil")
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyParagraphs []paragraphSpan
+	for _, para := range scanStoryParagraphs(docXML, StoryDocument) {
+		if !para.loc.InTable() {
+			bodyParagraphs = append(bodyParagraphs, para)
+		}
+	}
+
+	if i < 0 || j < i || j >= len(bodyParagraphs) {
+		return nil, NewValidationError("range", fmt.Sprintf("invalid paragraph range %d-%d (document has %d paragraphs)", i, j, len(bodyParagraphs)))
+	}
+
+	r := &Range{u: u, block: true}
+	r.sync(docXML, bodyParagraphs[i].start, bodyParagraphs[j].end)
+	return r, nil
+}
+
+// TableRange returns a block range covering the n-th top-level table
+// (1-based) of the document body
+func (u *Updater) TableRange(n int) (*Range, error) {
+	if u == nil {
+		return nil, fmt.Errorf("updater is nil")
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return nil, err
+	}
+
+	tables := scanStoryTables(docXML)
+	if n < 1 || n > len(tables) {
+		return nil, NewValidationError("index", fmt.Sprintf("table %d not found (document has %d tables)", n, len(tables)))
+	}
+
+	r := &Range{u: u, block: true}
+	r.sync(docXML, tables[n-1].start, tables[n-1].end)
+	return r, nil
+}
+
+// IsBlock reports whether the range covers whole paragraphs rather than
+// runs within a paragraph
+func (r *Range) IsBlock() bool {
+	return r.block
+}
+
+// Text returns the text of the range. Paragraphs of a block range are
+// separated by newlines.
+func (r *Range) Text() (string, error) {
+	docXML, err := r.load()
+	if err != nil {
+		return "", err
+	}
+
+	fragment := docXML[r.start:r.end]
+	if !r.block {
+		text := strings.Join(paragraphRunTexts(fragment), "")
+		return text[r.headCut : len(text)-r.tailCut], nil
+	}
+
+	var lines []string
+	for _, para := range scanStoryParagraphs(fragment, StoryDocument) {
+		lines = append(lines, strings.Join(paragraphRunTexts(fragment[para.start:para.end]), ""))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Paragraphs returns a block range for each paragraph in the range, including
+// paragraphs in table cells. For a text range it returns the paragraph
+// containing the range.
+func (r *Range) Paragraphs() ([]*Range, error) {
+	docXML, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Range
+	for _, para := range scanStoryParagraphs(docXML, StoryDocument) {
+		inRange := para.start >= r.start && para.end <= r.end
+		if !r.block {
+			inRange = r.start >= para.start && r.start < para.end
+		}
+		if inRange {
+			p := &Range{u: r.u, block: true}
+			p.sync(docXML, para.start, para.end)
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+// InsertBefore inserts text before the range. In a text range the text
+// becomes a run with the formatting of the first run; in a block range each
+// line becomes a new paragraph.
+func (r *Range) InsertBefore(text string) error {
+	return r.insertText(text, true)
+}
+
+// InsertAfter inserts text after the range. In a text range the text
+// becomes a run with the formatting of the last run; in a block range each
+// line becomes a new paragraph.
+func (r *Range) InsertAfter(text string) error {
+	return r.insertText(text, false)
+}
+
+func (r *Range) insertText(text string, before bool) error {
+	if text == "" {
+		return NewValidationError("text", "text cannot be empty")
+	}
+
+	docXML, err := r.loadForEdit()
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	if r.block {
+		content = generatePlainParagraphsXML(text, nil, nil)
+	} else {
+		runs := paragraphRuns(docXML[r.start:r.end])
+		var rPr []byte
+		if len(runs) > 0 {
+			run := runs[len(runs)-1]
+			if before {
+				run = runs[0]
+			}
+			rPr = runProperties(docXML[r.start+run.start : r.start+run.end])
+		}
+		content = generateRunXML(text, rPr)
+	}
+
+	if before {
+		updated := spliceBytes(docXML, content, r.start, r.start)
+		return r.store(updated, r.start+len(content), r.end+len(content))
+	}
+	updated := spliceBytes(docXML, content, r.end, r.end)
+	return r.store(updated, r.start, r.end)
+}
+
+// Replace replaces the content of the range with text, keeping the
+// formatting of the first run (and, for block ranges, of the first
+// paragraph). The range then covers the new content. In a text range,
+// hyperlinks left without runs are removed as in Delete, and empty text
+// removes the runs without adding one.
+func (r *Range) Replace(text string) error {
+	docXML, err := r.loadForEdit()
+	if err != nil {
+		return err
+	}
+
+	fragment := docXML[r.start:r.end]
+
+	if r.block {
+		var pPr, rPr []byte
+		paragraphs := scanStoryParagraphs(fragment, StoryDocument)
+		if len(paragraphs) > 0 {
+			first := fragment[paragraphs[0].start:paragraphs[0].end]
+			pPr = paragraphProperties(first)
+			if runs := paragraphRuns(first); len(runs) > 0 {
+				rPr = runProperties(first[runs[0].start:runs[0].end])
+			}
+		}
+		content := generatePlainParagraphsXML(text, pPr, rPr)
+		updated := spliceBytes(docXML, content, r.start, r.end)
+		return r.store(updated, r.start, r.start+len(content))
+	}
+
+	runs := paragraphRuns(fragment)
+	var rPr []byte
+	if len(runs) > 0 {
+		rPr = runProperties(fragment[runs[0].start:runs[0].end])
+	}
+
+	// Drop all runs, then put the new run where the first one was
+	newFragment := removeRuns(fragment, runs)
+	if text != "" {
+		insertAt := 0
+		if len(runs) > 0 {
+			insertAt = runs[0].start
+		}
+		newFragment = spliceBytes(newFragment, generateRunXML(text, rPr), insertAt, insertAt)
+	}
+
+	updated := spliceBytes(docXML, newFragment, r.start, r.end)
+	updated, start, end := removeEmptyHyperlinks(updated, r.start, r.start+len(newFragment))
+	return r.store(updated, start, end)
+}
+
+// Delete removes the content of the range. Deleting all paragraphs of a
+// table cell leaves an empty paragraph, as Word requires, and section breaks
+// are handled as in DeleteBetween. Bookmark markers inside a text range are
+// kept. The range becomes empty.
+func (r *Range) Delete() error {
+	docXML, err := r.loadForEdit()
+	if err != nil {
+		return err
+	}
+
+	if r.block {
+		updated := deleteElements(docXML, childElements(docXML, r.start, r.end))
+		return r.store(updated, r.start, r.start)
+	}
+
+	fragment := docXML[r.start:r.end]
+	newFragment := removeRuns(fragment, paragraphRuns(fragment))
+	updated := spliceBytes(docXML, newFragment, r.start, r.end)
+	updated, start, end := removeEmptyHyperlinks(updated, r.start, r.start+len(newFragment))
+	return r.store(updated, start, end)
+}
+
+// ApplyFormat applies character formatting to every run in the range
+func (r *Range) ApplyFormat(format TextFormat) error {
+	docXML, err := r.loadForEdit()
+	if err != nil {
+		return err
+	}
+
+	fragment := docXML[r.start:r.end]
+	runs := paragraphRuns(fragment)
+
+	var buf bytes.Buffer
+	pos := 0
+	for _, run := range runs {
+		buf.Write(fragment[pos:run.start])
+		buf.Write(applyRunFormat(fragment[run.start:run.end], format))
+		pos = run.end
+	}
+	buf.Write(fragment[pos:])
+
+	newFragment := buf.Bytes()
+	updated := spliceBytes(docXML, newFragment, r.start, r.end)
+	return r.store(updated, r.start, r.start+len(newFragment))
+}
+
+// TargetBeforeRange inserts before the range: before its first paragraph
+// for a text range, or directly before the range for a block range
+func TargetBeforeRange(r *Range) *Target {
+	return &Target{kind: targetBeforeRange, rng: r}
+}
+
+// TargetAfterRange inserts after the range: after its last paragraph for
+// a text range, or directly after the range for a block range
+func TargetAfterRange(r *Range) *Target {
+	return &Target{kind: targetAfterRange, rng: r}
+}
+
+// resolveRangePosition returns the insertion offset for a range position
+func resolveRangePosition(docXML []byte, r *Range, before bool) (int, error) {
+	if r == nil {
+		return 0, NewValidationError("range", "range cannot be nil")
+	}
+	if err := r.locate(docXML); err != nil {
+		return 0, err
+	}
+
+	if r.block {
+		if before {
+			return r.start, nil
+		}
+		return r.end, nil
+	}
+
+	for _, para := range scanStoryParagraphs(docXML, StoryDocument) {
+		if before && r.start >= para.start && r.start < para.end {
+			return para.start, nil
+		}
+		if !before && r.end > para.start && r.end <= para.end {
+			return para.end, nil
+		}
+	}
+	return 0, NewStaleRangeError("range is not inside a paragraph")
+}
+
+// matchRange builds a text range for a FindText match. The range covers the
+// runs of the match; the parts of the first and last runs outside the match
+// are recorded as cuts instead of splitting the runs.
+func (u *Updater) matchRange(match TextMatch) (*Range, error) {
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return nil, err
+	}
+
+	paraStart, paraEnd, err := findParagraphByMatch(docXML, &match)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := match.Location
+	runs := paragraphRuns(docXML[paraStart:paraEnd])
+	if loc.StartRun >= len(runs) || loc.EndRun >= len(runs) {
+		return nil, NewStaleRangeError("match runs not found")
+	}
+
+	startRun, endRun := runs[loc.StartRun], runs[loc.EndRun]
+	r := &Range{u: u}
+	r.sync(docXML, paraStart+startRun.start, paraStart+endRun.end)
+	if loc.StartOffset > 0 && loc.StartOffset < len(startRun.text) {
+		r.headCut = loc.StartOffset
+	}
+	if loc.EndOffset > 0 && loc.EndOffset < len(endRun.text) {
+		r.tailCut = len(endRun.text) - loc.EndOffset
+	}
+	return r, nil
+}
+
+// load reads document.xml and locates the range in it
+func (r *Range) load() ([]byte, error) {
+	if r == nil || r.u == nil {
+		return nil, fmt.Errorf("range is nil")
+	}
+
+	docXML, err := r.u.readDocumentXML()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.locate(docXML); err != nil {
+		return nil, err
+	}
+	return docXML, nil
+}
+
+// loadForEdit loads the range like load and splits the runs at the range
+// boundaries, so that the range covers whole runs. The split document is
+// returned but not written; the edit stores it.
+func (r *Range) loadForEdit() ([]byte, error) {
+	docXML, err := r.load()
+	if err != nil || r.block || (r.headCut == 0 && r.tailCut == 0) {
+		return docXML, err
+	}
+
+	fragment := docXML[r.start:r.end]
+	runs := paragraphRuns(fragment)
+	if len(runs) == 0 {
+		return nil, NewStaleRangeError("range runs not found")
+	}
+
+	// Split the last run first so the offsets of earlier runs stay valid
+	rangeStart, rangeEnd := 0, len(fragment)
+	first, last := runs[0], runs[len(runs)-1]
+	firstEnd := first.end
+	if r.tailCut > 0 {
+		left, right := splitRun(fragment[last.start:last.end], len(last.text)-r.tailCut)
+		fragment = slices.Concat(fragment[:last.start], left, right, fragment[last.end:])
+		rangeEnd = last.start + len(left)
+		if len(runs) == 1 {
+			firstEnd = rangeEnd
+		}
+	}
+	if r.headCut > 0 {
+		left, right := splitRun(fragment[first.start:firstEnd], r.headCut)
+		fragment = slices.Concat(fragment[:first.start], left, right, fragment[firstEnd:])
+		rangeStart = first.start + len(left)
+		rangeEnd += len(left) + len(right) - (firstEnd - first.start)
+	}
+
+	updated := slices.Concat(docXML[:r.start], fragment, docXML[r.end:])
+	r.start, r.end = r.start+rangeStart, r.start+rangeEnd
+	r.headCut, r.tailCut = 0, 0
+	return updated, nil
+}
+
+// locate updates the range offsets for the current document content
+func (r *Range) locate(docXML []byte) error {
+	if r.end <= len(docXML) && bytes.Equal(docXML[r.start:r.end], r.snap) {
+		if len(r.snap) > 0 || len(docXML) == r.docLen {
+			return nil
+		}
+	}
+
+	if len(r.snap) > 0 {
+		idx := bytes.Index(docXML, r.snap)
+		if idx != -1 && !bytes.Contains(docXML[idx+1:], r.snap) {
+			r.start = idx
+			r.end = idx + len(r.snap)
+			r.docLen = len(docXML)
+			return nil
+		}
+	}
+
+	return NewStaleRangeError("range content was changed by another edit; obtain a new range")
+}
+
+// store writes document.xml and records the new range offsets
+func (r *Range) store(docXML []byte, start, end int) error {
+	if err := r.u.writeDocumentXML(docXML); err != nil {
+		return err
+	}
+	r.sync(docXML, start, end)
+	return nil
+}
+
+// sync records the range offsets and content for later relocation
+func (r *Range) sync(docXML []byte, start, end int) {
+	r.start = start
+	r.end = end
+	r.snap = append([]byte{}, docXML[start:end]...)
+	r.docLen = len(docXML)
+}
+
+// readDocumentXML reads word/document.xml
+func (u *Updater) readDocumentXML() ([]byte, error) {
+	docPath := filepath.Join(u.tempDir, "word", "document.xml")
+	raw, err := os.ReadFile(docPath)
+	if err != nil {
+		return nil, fmt.Errorf("read document.xml: %w", err)
+	}
+	return raw, nil
+}
+
+// writeDocumentXML writes word/document.xml
+func (u *Updater) writeDocumentXML(docXML []byte) error {
+	docPath := filepath.Join(u.tempDir, "word", "document.xml")
+	if err := os.WriteFile(docPath, docXML, 0o644); err != nil {
+		return fmt.Errorf("write document.xml: %w", err)
+	}
+	return nil
+}
+
+// splitRun splits a run at a byte offset of its text into two runs that
+// share the original run properties
+func splitRun(runXML []byte, offset int) ([]byte, []byte) {
+	openEnd := bytes.IndexByte(runXML, '>') + 1
+	closeStart := len(runXML) - len("</w:r>")
+	openTag := runXML[:openEnd]
+
+	var left, right bytes.Buffer
+	left.Write(openTag)
+	right.Write(openTag)
+
+	consumed := 0
+	for _, child := range childElements(runXML, openEnd, closeStart) {
+		element := runXML[child.start:child.end]
+		switch {
+		case child.name == "w:rPr":
+			left.Write(element)
+			right.Write(element)
+		case child.name == "w:t":
+			text := runText(element)
+			switch {
+			case consumed >= offset:
+				right.Write(element)
+			case consumed+len(text) <= offset:
+				left.Write(element)
+			default:
+				cut := offset - consumed
+				writeTextElement(&left, text[:cut])
+				writeTextElement(&right, text[cut:])
+			}
+			consumed += len(text)
+		case consumed < offset:
+			left.Write(element)
+		default:
+			right.Write(element)
+		}
+	}
+
+	left.WriteString("</w:r>")
+	right.WriteString("</w:r>")
+	return left.Bytes(), right.Bytes()
+}
+
+// writeTextElement writes a <w:t> element preserving spaces
+func writeTextElement(buf *bytes.Buffer, text string) {
+	buf.WriteString(`<w:t xml:space="preserve">`)
+	buf.WriteString(xmlEscape(text))
+	buf.WriteString("</w:t>")
+}
+
+// runProperties returns the <w:rPr> element of a run, or nil
+func runProperties(runXML []byte) []byte {
+	openEnd := bytes.IndexByte(runXML, '>') + 1
+	closeStart := len(runXML) - len("</w:r>")
+	if openEnd <= 0 || closeStart < openEnd {
+		return nil
+	}
+	children := childElements(runXML, openEnd, closeStart)
+	if len(children) > 0 && children[0].name == "w:rPr" {
+		return runXML[children[0].start:children[0].end]
+	}
+	return nil
+}
+
+// paragraphProperties returns the <w:pPr> element of a paragraph, or nil
+func paragraphProperties(paragraphXML []byte) []byte {
+	openEnd := bytes.IndexByte(paragraphXML, '>') + 1
+	if openEnd <= 0 || paragraphXML[openEnd-2] == '/' {
+		return nil
+	}
+	closeStart := len(paragraphXML) - len("</w:p>")
+	children := childElements(paragraphXML, openEnd, closeStart)
+	if len(children) > 0 && children[0].name == "w:pPr" {
+		return paragraphXML[children[0].start:children[0].end]
+	}
+	return nil
+}
+
+// generateRunXML creates a run with the given properties and text
+func generateRunXML(text string, rPr []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<w:r>")
+	buf.Write(rPr)
+	writeRunTextWithControls(&buf, text)
+	buf.WriteString("</w:r>")
+	return buf.Bytes()
+}
+
+// generatePlainParagraphsXML creates one paragraph per line of text with the
+// given paragraph and run properties
+func generatePlainParagraphsXML(text string, pPr, rPr []byte) []byte {
+	var buf bytes.Buffer
+	for line := range strings.SplitSeq(text, "\n") {
+		buf.WriteString("<w:p>")
+		buf.Write(pPr)
+		if line != "" {
+			buf.Write(generateRunXML(line, rPr))
+		}
+		buf.WriteString("</w:p>")
+	}
+	return buf.Bytes()
+}
+
+// removeRuns removes the given runs (offsets relative to fragment)
+func removeRuns(fragment []byte, runs []runSpan) []byte {
+	var buf bytes.Buffer
+	pos := 0
+	for _, run := range runs {
+		buf.Write(fragment[pos:run.start])
+		pos = run.end
+	}
+	buf.Write(fragment[pos:])
+	return buf.Bytes()
+}
+
+// ensureCellParagraph adds an empty paragraph at offset when a deletion has
+// left a table cell without any paragraph
+func ensureCellParagraph(docXML []byte, offset int) []byte {
+	if !bytes.HasPrefix(docXML[offset:], []byte("</w:tc>")) {
+		return docXML
+	}
+	before := docXML[:offset]
+	if bytes.HasSuffix(before, []byte("</w:tcPr>")) || bytes.HasSuffix(before, []byte("<w:tc>")) || bytes.HasSuffix(before, []byte("</w:tbl>")) {
+		return spliceBytes(docXML, []byte("<w:p/>"), offset, offset)
+	}
+	return docXML
+}
+
+// applyRunFormat merges character formatting into a run's properties
+func applyRunFormat(runXML []byte, format TextFormat) []byte {
+	var props []propertyElement
+	set := func(name, xml string) {
+		props = append(props, propertyElement{name, []byte(xml)})
+	}
+
+	if format.FontFamily != "" {
+		family := xmlEscape(format.FontFamily)
+		set("w:rFonts", fmt.Sprintf(`<w:rFonts w:ascii="%s" w:hAnsi="%s" w:cs="%s"/>`, family, family, family))
+	}
+	var remove []string
+	toggle := func(value *bool, name, xml string, related ...string) {
+		switch {
+		case value == nil:
+		case *value:
+			set(name, xml)
+		default:
+			remove = append(remove, name)
+			remove = append(remove, related...)
+		}
+	}
+
+	toggle(format.Bold, "w:b", "<w:b/>", "w:bCs")
+	toggle(format.Italic, "w:i", "<w:i/>", "w:iCs")
+	toggle(format.Strike, "w:strike", "<w:strike/>")
+	if color := normalizeHexColor(format.FontColor); color != "" {
+		set("w:color", fmt.Sprintf(`<w:color w:val="%s"/>`, color))
+	}
+	if format.FontSize > 0 {
+		set("w:sz", fmt.Sprintf(`<w:sz w:val="%d"/>`, format.FontSize))
+		set("w:szCs", fmt.Sprintf(`<w:szCs w:val="%d"/>`, format.FontSize))
+	}
+	if format.Highlight != "" {
+		set("w:highlight", fmt.Sprintf(`<w:highlight w:val="%s"/>`, xmlEscape(format.Highlight)))
+	}
+	toggle(format.Underline, "w:u", `<w:u w:val="single"/>`)
+
+	if len(props) == 0 && len(remove) == 0 {
+		return runXML
+	}
+
+	return mergeRunProperties(runXML, props, remove...)
+}
+
+// propertyElement is a single child element of a properties element such
+// as <w:rPr> or <w:pPr>
+type propertyElement struct {
+	name string
+	xml  []byte
+}
+
+// mergeRunProperties replaces or adds <w:rPr> children of a run, keeping the
+// schema order of the properties. Properties named in remove are dropped.
+func mergeRunProperties(runXML []byte, props []propertyElement, remove ...string) []byte {
+	return mergeProperties(runXML, "w:rPr", runPropertyOrder, props, remove)
+}
+
+// mergeProperties replaces, adds or removes children of the properties
+// element propsName (the first child of element, e.g. <w:rPr> of a run),
+// sorting them by the schema order. An emptied properties element is removed.
+func mergeProperties(element []byte, propsName string, order []string, props []propertyElement, remove []string) []byte {
+	openEnd := bytes.IndexByte(element, '>') + 1
+	nameEnd := bytes.IndexAny(element[1:openEnd], " \t\r\n/>") + 1
+	closeTag := "</" + string(element[1:nameEnd]) + ">"
+	if element[openEnd-2] == '/' {
+		// Self-closing element such as <w:p/>
+		element = slices.Concat(element[:openEnd-2], []byte(">"), []byte(closeTag))
+		openEnd--
+	}
+	closeStart := len(element) - len(closeTag)
+	children := childElements(element, openEnd, closeStart)
+
+	var existing []propertyElement
+	contentStart := openEnd
+	if len(children) > 0 && children[0].name == propsName {
+		propsXML := element[children[0].start:children[0].end]
+		propsOpenEnd := bytes.IndexByte(propsXML, '>') + 1
+		if propsXML[propsOpenEnd-2] != '/' {
+			for _, child := range childElements(propsXML, propsOpenEnd, len(propsXML)-len("</"+propsName+">")) {
+				existing = append(existing, propertyElement{child.name, propsXML[child.start:child.end]})
+			}
+		}
+		contentStart = children[0].end
+	}
+
+	merged := existing[:0:0]
+	for _, prop := range existing {
+		replaced := slices.ContainsFunc(props, func(p propertyElement) bool { return p.name == prop.name })
+		if !replaced && !slices.Contains(remove, prop.name) {
+			merged = append(merged, prop)
+		}
+	}
+	merged = append(merged, props...)
+
+	position := func(name string) int {
+		if idx := slices.Index(order, name); idx != -1 {
+			return idx
+		}
+		return len(order)
+	}
+	slices.SortStableFunc(merged, func(a, b propertyElement) int {
+		return position(a.name) - position(b.name)
+	})
+
+	var buf bytes.Buffer
+	buf.Write(element[:openEnd])
+	if len(merged) > 0 {
+		buf.WriteString("<" + propsName + ">")
+		for _, prop := range merged {
+			buf.Write(prop.xml)
+		}
+		buf.WriteString("</" + propsName + ">")
+	}
+	buf.Write(element[contentStart:])
+	return buf.Bytes()
+}
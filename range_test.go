@@ -0,0 +1,218 @@
+package godocx_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+const rangeFixtureBody = `<w:p><w:r><w:t>Title line</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:rPr><w:i/></w:rPr><w:t xml:space="preserve">The total </w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>is 42 units</w:t></w:r></w:p>` +
+	`<w:p><w:bookmarkStart w:id="1" w:name="Inline"/><w:r><w:t>Bookmarked words</w:t></w:r><w:bookmarkEnd w:id="1"/><w:r><w:t> tail</w:t></w:r></w:p>` +
+	`<w:bookmarkStart w:id="2" w:name="Block"/><w:p><w:r><w:t>Block one</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>Block two</w:t></w:r></w:p><w:bookmarkEnd w:id="2"/>` +
+	`<w:p><w:r><w:t>Last line</w:t></w:r></w:p>`
+
+func openRangeFixture(t *testing.T) *godocx.Updater {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, rangeFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+	return u
+}
+
+func TestFindRangeSplitsRuns(t *testing.T) {
+	u := openRangeFixture(t)
+
+	r, err := u.FindRange("total is 42", godocx.FindOptions{MatchCase: true})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if r.IsBlock() {
+		t.Error("expected text range")
+	}
+
+	text, err := r.Text()
+	if err != nil {
+		t.Fatalf("Text failed: %v", err)
+	}
+	if text != "total is 42" {
+		t.Errorf("expected range text %q, got %q", "total is 42", text)
+	}
+
+	// Several edits at the same spot without searching again
+	if err := r.ApplyFormat(godocx.TextFormat{Underline: ptrBool(true), FontColor: "FF0000"}); err != nil {
+		t.Fatalf("ApplyFormat failed: %v", err)
+	}
+	if err := r.InsertBefore("["); err != nil {
+		t.Fatalf("InsertBefore failed: %v", err)
+	}
+	if err := r.InsertAfter("]"); err != nil {
+		t.Fatalf("InsertAfter failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "The ", "[", "total ", "is 42", "]", " units")
+	if !strings.Contains(doc, `<w:rPr><w:i/><w:color w:val="FF0000"/><w:u w:val="single"/></w:rPr><w:t xml:space="preserve">total </w:t>`) {
+		t.Error("expected formatting merged into the italic run in schema order")
+	}
+	if !strings.Contains(doc, `<w:rPr><w:b/><w:color w:val="FF0000"/><w:u w:val="single"/></w:rPr><w:t xml:space="preserve">is 42</w:t>`) {
+		t.Error("expected formatting merged into the bold run")
+	}
+	if !strings.Contains(doc, `<w:rPr><w:b/></w:rPr><w:t xml:space="preserve"> units</w:t>`) {
+		t.Error("expected text after the match to keep its original formatting")
+	}
+}
+
+func ptrBool(b bool) *bool {
+	return &b
+}
+
+func TestFindRangeLeavesDocumentUnchanged(t *testing.T) {
+	u := openRangeFixture(t)
+	docPath := filepath.Join(u.TempDir(), "word", "document.xml")
+	before, err := os.ReadFile(docPath)
+	if err != nil {
+		t.Fatalf("read document: %v", err)
+	}
+
+	r, err := u.FindRange("total is 42", godocx.FindOptions{MatchCase: true})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if text, _ := r.Text(); text != "total is 42" {
+		t.Errorf("unexpected range text %q", text)
+	}
+	if _, err := r.Paragraphs(); err != nil {
+		t.Fatalf("Paragraphs failed: %v", err)
+	}
+
+	after, err := os.ReadFile(docPath)
+	if err != nil {
+		t.Fatalf("read document: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("expected finding and reading a range to leave the document unchanged")
+	}
+}
+
+func TestApplyFormatRemovesProperties(t *testing.T) {
+	u := openRangeFixture(t)
+
+	r, err := u.FindRange("is 42", godocx.FindOptions{MatchCase: true})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if err := r.ApplyFormat(godocx.TextFormat{Bold: ptrBool(false), Italic: ptrBool(true)}); err != nil {
+		t.Fatalf("ApplyFormat failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `<w:r><w:rPr><w:i/></w:rPr><w:t xml:space="preserve">is 42</w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve"> units</w:t></w:r>`) {
+		t.Errorf("expected bold removed from the range only, got %s", doc)
+	}
+}
+
+func TestRangeReplaceAndDelete(t *testing.T) {
+	u := openRangeFixture(t)
+
+	r, err := u.FindRange("total is 42", godocx.FindOptions{})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if err := r.Replace("sum is 43"); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if text, _ := r.Text(); text != "sum is 43" {
+		t.Errorf("expected replaced range text, got %q", text)
+	}
+
+	inline, err := u.BookmarkRange("Inline")
+	if err != nil {
+		t.Fatalf("BookmarkRange failed: %v", err)
+	}
+	if err := inline.Delete(); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `<w:rPr><w:i/></w:rPr><w:t>sum is 43</w:t>`) {
+		t.Error("expected replacement to keep the first run's formatting")
+	}
+	if strings.Contains(doc, "Bookmarked words") {
+		t.Error("expected bookmarked text to be deleted")
+	}
+	if !strings.Contains(doc, `<w:bookmarkStart w:id="1" w:name="Inline"/><w:bookmarkEnd w:id="1"/>`) {
+		t.Error("expected bookmark markers to remain")
+	}
+}
+
+func TestBlockRanges(t *testing.T) {
+	u := openRangeFixture(t)
+
+	block, err := u.BookmarkRange("Block")
+	if err != nil {
+		t.Fatalf("BookmarkRange failed: %v", err)
+	}
+	if !block.IsBlock() {
+		t.Fatal("expected block range for a multi-paragraph bookmark")
+	}
+	if text, _ := block.Text(); text != "Block one\nBlock two" {
+		t.Errorf("unexpected block text %q", text)
+	}
+	paragraphs, err := block.Paragraphs()
+	if err != nil || len(paragraphs) != 2 {
+		t.Fatalf("expected 2 paragraphs, got %d (%v)", len(paragraphs), err)
+	}
+
+	if err := u.InsertTable(godocx.TableOptions{
+		Columns: []godocx.ColumnDefinition{{Title: "After block"}},
+		Rows:    [][]string{{"1"}},
+		Target:  godocx.TargetAfterRange(block),
+	}); err != nil {
+		t.Fatalf("InsertTable after range failed: %v", err)
+	}
+
+	// The range is relocated after the table insertion
+	if err := block.Replace("Replaced one\nReplaced two"); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	first, err := u.ParagraphRange(0, 0)
+	if err != nil {
+		t.Fatalf("ParagraphRange failed: %v", err)
+	}
+	if err := first.InsertAfter("Subtitle"); err != nil {
+		t.Fatalf("InsertAfter failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	assertOrder(t, doc, "Title line", "Subtitle", "Replaced one", "Replaced two", "After block", "Last line")
+
+	if _, err := u.ParagraphRange(3, 99); err == nil {
+		t.Error("expected error for out-of-range paragraphs")
+	}
+}
+
+func TestStaleRange(t *testing.T) {
+	u := openRangeFixture(t)
+
+	r, err := u.FindRange("Last line", godocx.FindOptions{})
+	if err != nil {
+		t.Fatalf("FindRange failed: %v", err)
+	}
+	if err := u.InsertParagraph(godocx.ParagraphOptions{Text: "Last line", Position: godocx.PositionBeginning}); err != nil {
+		t.Fatalf("InsertParagraph failed: %v", err)
+	}
+
+	if err := r.Delete(); err == nil {
+		t.Error("expected stale range error once the content is ambiguous")
+	}
+}
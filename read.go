@@ -11,10 +11,15 @@ import (
 // TextMatch represents a text match with context
 type TextMatch struct {
 	Text      string // The matched text
-	Paragraph int    // Paragraph index (0-based)
-	Position  int    // Character position in document
+	Paragraph int    // Paragraph index (0-based) in document order within the part
+	Position  int    // Character position in the text of the part
 	Before    string // Context before match (up to 50 chars)
 	After     string // Context after match (up to 50 chars)
+
+	// Location is the structural location of the match: the part it was
+	// found in, the paragraph or table cell, and the run offsets. Pass the
+	// match to TargetAfterMatch/TargetBeforeMatch to insert next to it.
+	Location TextLocation
 }
 
 // FindOptions defines options for text search
@@ -42,6 +47,12 @@ type FindOptions struct {
 
 	// InFooters enables search in footers
 	InFooters bool
+
+	// InFootnotes enables search in footnotes
+	InFootnotes bool
+
+	// InEndnotes enables search in endnotes
+	InEndnotes bool
 }
 
 // DefaultFindOptions returns find options with sensible defaults
@@ -55,6 +66,8 @@ func DefaultFindOptions() FindOptions {
 		InTables:     true,
 		InHeaders:    false,
 		InFooters:    false,
+		InFootnotes:  false,
+		InEndnotes:   false,
 	}
 }
 
@@ -113,31 +126,13 @@ func (u *Updater) FindText(pattern string, opts FindOptions) ([]TextMatch, error
 		return nil, NewValidationError("pattern", "search pattern cannot be empty")
 	}
 
-	var matches []TextMatch
-	var searchPattern *regexp.Regexp
-	var err error
-
-	// Compile search pattern
-	if opts.UseRegex {
-		if !opts.MatchCase {
-			pattern = "(?i)" + pattern
-		}
-		searchPattern, err = regexp.Compile(pattern)
-		if err != nil {
-			return nil, NewInvalidRegexError(pattern, err)
-		}
-	} else {
-		// Escape regex metachars for literal search
-		escapedPattern := regexp.QuoteMeta(pattern)
-		if opts.WholeWord {
-			escapedPattern = `\b` + escapedPattern + `\b`
-		}
-		if !opts.MatchCase {
-			escapedPattern = "(?i)" + escapedPattern
-		}
-		searchPattern = regexp.MustCompile(escapedPattern)
+	searchPattern, err := compileFindPattern(pattern, opts)
+	if err != nil {
+		return nil, err
 	}
 
+	var matches []TextMatch
+
 	// Search in document body
 	if opts.InParagraphs || opts.InTables {
 		docPath := filepath.Join(u.tempDir, "word", "document.xml")
@@ -146,51 +141,80 @@ func (u *Updater) FindText(pattern string, opts FindOptions) ([]TextMatch, error
 			return nil, NewXMLParseError("document.xml", err)
 		}
 
-		docMatches := u.findInXML(raw, searchPattern, opts.MaxResults-len(matches))
+		docMatches := u.findInXML(raw, StoryDocument, searchPattern, opts.MaxResults, func(loc TextLocation) bool {
+			if loc.InTable() {
+				return opts.InTables
+			}
+			return opts.InParagraphs
+		})
 		matches = append(matches, docMatches...)
 	}
 
-	// Search in headers
-	if opts.InHeaders && (opts.MaxResults == 0 || len(matches) < opts.MaxResults) {
-		headerFiles, _ := filepath.Glob(filepath.Join(u.tempDir, "word", "header*.xml"))
-		for _, headerPath := range headerFiles {
-			raw, err := os.ReadFile(headerPath)
-			if err != nil {
-				continue
-			}
-			headerMatches := u.findInXML(raw, searchPattern, opts.MaxResults-len(matches))
-			matches = append(matches, headerMatches...)
+	// Search in headers, footers, footnotes and endnotes
+	storyGlobs := []struct {
+		enabled bool
+		pattern string
+	}{
+		{opts.InHeaders, "header*.xml"},
+		{opts.InFooters, "footer*.xml"},
+		{opts.InFootnotes, StoryFootnotes},
+		{opts.InEndnotes, StoryEndnotes},
+	}
+	for _, sg := range storyGlobs {
+		if !sg.enabled {
+			continue
+		}
+		storyFiles, _ := filepath.Glob(filepath.Join(u.tempDir, "word", sg.pattern))
+		for _, storyPath := range storyFiles {
 			if opts.MaxResults > 0 && len(matches) >= opts.MaxResults {
-				break
+				return matches, nil
 			}
-		}
-	}
-
-	// Search in footers
-	if opts.InFooters && (opts.MaxResults == 0 || len(matches) < opts.MaxResults) {
-		footerFiles, _ := filepath.Glob(filepath.Join(u.tempDir, "word", "footer*.xml"))
-		for _, footerPath := range footerFiles {
-			raw, err := os.ReadFile(footerPath)
+			raw, err := os.ReadFile(storyPath)
 			if err != nil {
 				continue
 			}
-			footerMatches := u.findInXML(raw, searchPattern, opts.MaxResults-len(matches))
-			matches = append(matches, footerMatches...)
-			if opts.MaxResults > 0 && len(matches) >= opts.MaxResults {
-				break
+			remaining := 0
+			if opts.MaxResults > 0 {
+				remaining = opts.MaxResults - len(matches)
 			}
+			storyMatches := u.findInXML(raw, filepath.Base(storyPath), searchPattern, remaining, nil)
+			matches = append(matches, storyMatches...)
 		}
 	}
 
 	return matches, nil
 }
 
+// compileFindPattern compiles a search pattern according to the find options
+func compileFindPattern(pattern string, opts FindOptions) (*regexp.Regexp, error) {
+	if opts.UseRegex {
+		if !opts.MatchCase {
+			pattern = "(?i)" + pattern
+		}
+		searchPattern, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, NewInvalidRegexError(pattern, err)
+		}
+		return searchPattern, nil
+	}
+
+	// Escape regex metachars for literal search
+	escapedPattern := regexp.QuoteMeta(pattern)
+	if opts.WholeWord {
+		escapedPattern = `\b` + escapedPattern + `\b`
+	}
+	if !opts.MatchCase {
+		escapedPattern = "(?i)" + escapedPattern
+	}
+	return regexp.MustCompile(escapedPattern), nil
+}
+
 // extractTextFromXML extracts all visible text from XML content
 func (u *Updater) extractTextFromXML(raw []byte) string {
 	var result strings.Builder
 
 	// Extract text from <w:t> elements
-	textPattern := regexp.MustCompile(`<w:t[^>]*>(.*?)</w:t>`)
+	textPattern := regexp.MustCompile(`<w:t(?:\s[^>]*)?>(.*?)</w:t>`)
 	matches := textPattern.FindAllSubmatch(raw, -1)
 
 	for _, match := range matches {
@@ -269,64 +293,73 @@ func (u *Updater) extractTableData(tableXML []byte) [][]string {
 	return rows
 }
 
-// findInXML finds all matches of the pattern in XML content
-func (u *Updater) findInXML(raw []byte, pattern *regexp.Regexp, maxResults int) []TextMatch {
+// findInXML finds all matches of the pattern in a part. Matches never span
+// paragraphs. If include is non-nil, only paragraphs for which it returns
+// true are searched.
+func (u *Updater) findInXML(raw []byte, story string, pattern *regexp.Regexp, maxResults int, include func(TextLocation) bool) []TextMatch {
 	var matches []TextMatch
 
-	// Extract full text for searching
-	fullText := u.extractTextFromXML(raw)
-
-	// Find paragraphs for indexing
-	paragraphs := u.extractParagraphsFromXML(raw)
+	paragraphs := scanStoryParagraphs(raw, story)
 
-	// Find all matches in the full text
-	indices := pattern.FindAllStringIndex(fullText, -1)
-
-	for _, idx := range indices {
-		if maxResults > 0 && len(matches) >= maxResults {
-			break
+	// Collect run texts first so context can reach into neighbouring paragraphs
+	paraRuns := make([][]string, len(paragraphs))
+	paraOffsets := make([]int, len(paragraphs))
+	var fullText strings.Builder
+	for i, para := range paragraphs {
+		paraRuns[i] = paragraphRunTexts(raw[para.start:para.end])
+		paraOffsets[i] = fullText.Len()
+		for _, run := range paraRuns[i] {
+			fullText.WriteString(run)
 		}
+	}
+	text := fullText.String()
 
-		matchText := fullText[idx[0]:idx[1]]
-
-		// Determine which paragraph this match belongs to
-		paraIndex := u.findParagraphIndex(fullText, idx[0], paragraphs)
-
-		// Extract context (50 chars before and after)
-		contextBefore := ""
-		contextAfter := ""
+	for i, para := range paragraphs {
+		if include != nil && !include(para.loc) {
+			continue
+		}
 
-		beforeStart := max(idx[0]-50, 0)
-		contextBefore = fullText[beforeStart:idx[0]]
+		runs := paraRuns[i]
+		runStarts := make([]int, len(runs))
+		paraText := ""
+		for r, run := range runs {
+			runStarts[r] = len(paraText)
+			paraText += run
+		}
 
-		afterEnd := min(idx[1]+50, len(fullText))
-		contextAfter = fullText[idx[1]:afterEnd]
+		for _, idx := range pattern.FindAllStringIndex(paraText, -1) {
+			if maxResults > 0 && len(matches) >= maxResults {
+				return matches
+			}
+			if idx[0] == idx[1] {
+				continue
+			}
 
-		matches = append(matches, TextMatch{
-			Text:      matchText,
-			Paragraph: paraIndex,
-			Position:  idx[0],
-			Before:    contextBefore,
-			After:     contextAfter,
-		})
+			position := paraOffsets[i] + idx[0]
+			end := paraOffsets[i] + idx[1]
+
+			// Extract context (50 chars before and after)
+			beforeStart := max(position-50, 0)
+			afterEnd := min(end+50, len(text))
+
+			loc := para.loc
+			loc.StartRun, loc.StartOffset = runOffset(runStarts, runs, idx[0], false)
+			loc.EndRun, loc.EndOffset = runOffset(runStarts, runs, idx[1], true)
+
+			matches = append(matches, TextMatch{
+				Text:      paraText[idx[0]:idx[1]],
+				Paragraph: i,
+				Position:  position,
+				Before:    text[beforeStart:position],
+				After:     text[end:afterEnd],
+				Location:  loc,
+			})
+		}
 	}
 
 	return matches
 }
 
-// findParagraphIndex determines which paragraph contains the given position
-func (u *Updater) findParagraphIndex(fullText string, position int, paragraphs []string) int {
-	currentPos := 0
-	for i, para := range paragraphs {
-		paraLen := len(para)
-		if position >= currentPos && position < currentPos+paraLen {
-			return i
-		}
-		currentPos += paraLen
-	}
-	return -1
-}
-
 // unescapeXML unescapes XML entities
 func unescapeXML(s string) string {
 	s = strings.ReplaceAll(s, "&amp;", "&")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -79,8 +80,11 @@ const (
 // TableOptions defines comprehensive options for table creation
 type TableOptions struct {
 	// Position where to insert the table
-	Position InsertPosition
-	Anchor   string // Text anchor for relative positioning
+	Position    InsertPosition
+	Target      *Target // Overrides Position and Anchor (match, table cell, Range...)
+	Anchor      string  // Text anchor for relative positioning
+	Occurrence  int     // Which paragraph containing Anchor to use (1-based, default: first)
+	AnchorRegex bool    // Treat Anchor as a regular expression
 
 	// Column definitions
 	Columns      []ColumnDefinition // Column titles and properties
@@ -89,6 +93,11 @@ type TableOptions struct {
 	// Data rows (excluding header)
 	Rows [][]string // Each inner slice is a row of cell data
 
+	// CellRows are data rows with merged cells, used instead of Rows. A row
+	// lists only the cells that start in it: columns covered by a cell
+	// spanning down from an earlier row are skipped.
+	CellRows [][]TableCell
+
 	// ProportionalColumnWidths enables content-based proportional sizing
 	// When true, column widths are calculated based on the length of content
 	// (headers + longest cell) in each column. Wider content gets wider columns.
@@ -96,12 +105,24 @@ type TableOptions struct {
 	// Ignored if ColumnWidths is explicitly specified.
 	ProportionalColumnWidths bool
 
+	// MeasuredColumnWidths sizes columns by measuring their text with font
+	// metrics, honouring the FontSize and Bold of the header and row styles.
+	// Takes precedence over ProportionalColumnWidths; ignored if
+	// ColumnWidths is explicitly specified.
+	MeasuredColumnWidths *MeasuredWidths
+
 	// AvailableWidth specifies the usable page width in twips (page width - left margin - right margin)
 	// Used for auto-calculating column widths in percentage mode
 	// If 0 (default), uses standard Letter page width calculation: 12240 - 1440 - 1440 = 9360
 	// Automatically computed if not set
 	AvailableWidth int
 
+	// HeaderRows are grouped header rows placed above the column titles,
+	// e.g. a year spanning its quarters. Cells span columns with ColSpan and
+	// may reach down into the column title row with RowSpan, replacing the
+	// titles of the columns they cover.
+	HeaderRows [][]TableCell
+
 	// Header styling
 	HeaderStyle      CellStyle     // Style for header row
 	HeaderStyleName  string        // Named Word style for header paragraphs (e.g., "Heading 1")
@@ -146,6 +167,20 @@ type TableOptions struct {
 	// Matching cells will have their style overridden by the map value
 	// Non-empty conditional values take precedence over row-level styling
 	ConditionalStyles map[string]CellStyle
+
+	// ConditionalRules style data cells per column by numeric comparisons,
+	// ranges, regular expressions or contained text, and color scales.
+	// Rules apply in order after ConditionalStyles.
+	ConditionalRules []ConditionalRule
+
+	// Footer adds a totals row after the data rows (nil for none)
+	Footer *TableFooter
+
+	// Pagination
+	CantSplit        bool // Keep each row on one page instead of splitting it across a page break
+	KeepTogether     bool // Keep the whole table on one page when it fits (keep-with-next on every row but the last)
+	RepeatHeaderRows int  // Number of rows from the top repeated on each page, e.g. a multi-row header; 0 to use RepeatHeader
+	KeepWithCaption  bool // Keep the caption on the same page as the table: a caption before is kept with the first row, a caption after with the last
 }
 
 // ColumnDefinition defines properties for a table column
@@ -156,6 +191,18 @@ type ColumnDefinition struct {
 	Bold      bool          // Make header bold
 }
 
+// TableCell defines a table cell that can span several columns or rows
+type TableCell struct {
+	Text      string
+	ColSpan   int           // Number of columns the cell spans (default 1)
+	RowSpan   int           // Number of rows the cell spans (default 1)
+	Alignment CellAlignment // Optional: overrides the row alignment
+
+	// Content holds rich cell content: paragraphs, list items, images,
+	// hyperlinks and nested tables. When set it replaces Text.
+	Content []CellContent
+}
+
 // CellStyle defines styling for table cells
 type CellStyle struct {
 	Bold       bool
@@ -186,13 +233,19 @@ func (u *Updater) InsertTable(opts TableOptions) error {
 		return fmt.Errorf("read document.xml: %w", err)
 	}
 
-	// Generate table XML
-	tableXML := generateTableXML(opts)
+	// Images and hyperlinks in cells are added to the package while the
+	// table is generated, and released again if it cannot be inserted
+	relsBefore, err := u.documentRelationshipIDs()
+	if err != nil {
+		return err
+	}
 
-	// Insert table at the specified position
-	updated, err := insertTableAtPosition(raw, tableXML, opts)
+	updated, err := u.generateAndInsertTable(raw, opts)
 	if err != nil {
-		return fmt.Errorf("insert table: %w", err)
+		if releaseErr := u.releaseAddedRelationships(raw, relsBefore); releaseErr != nil {
+			return fmt.Errorf("%w (cleanup failed: %v)", err, releaseErr)
+		}
+		return err
 	}
 
 	// Write updated document
@@ -203,6 +256,92 @@ func (u *Updater) InsertTable(opts TableOptions) error {
 	return nil
 }
 
+// generateAndInsertTable generates the XML of a table and inserts it into
+// the document at the position of the options
+func (u *Updater) generateAndInsertTable(docXML []byte, opts TableOptions) ([]byte, error) {
+	tableXML, err := generateTableXML(opts, u.renderCellContent)
+	if err != nil {
+		return nil, fmt.Errorf("generate table: %w", err)
+	}
+
+	updated, err := insertTableAtPosition(docXML, tableXML, opts)
+	if err != nil {
+		return nil, fmt.Errorf("insert table: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteTable removes the n-th top-level table (1-based) from the document
+// body together with its caption paragraph, if any. Images, charts and
+// hyperlinks used only by the table are removed from the package as well.
+func (u *Updater) DeleteTable(n int) error {
+	if u == nil {
+		return fmt.Errorf("updater is nil")
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return err
+	}
+
+	tables := scanStoryTables(docXML)
+	if n < 1 || n > len(tables) {
+		return NewValidationError("index", fmt.Sprintf("table %d not found (document has %d tables)", n, len(tables)))
+	}
+	tbl := tables[n-1]
+
+	spans := []elementSpan{{start: tbl.start, end: tbl.end, name: "w:tbl"}}
+	if caption, ok := tableCaption(docXML, tbl); ok {
+		spans = append(spans, caption)
+	}
+
+	var relIDs []string
+	for _, span := range spans {
+		relIDs = append(relIDs, relationshipRefs(docXML[span.start:span.end])...)
+	}
+	updated := deleteElements(docXML, spans)
+
+	if err := u.writeDocumentXML(updated); err != nil {
+		return err
+	}
+	if err := u.releaseRelationships(updated, relIDs); err != nil {
+		return fmt.Errorf("remove table parts: %w", err)
+	}
+
+	return nil
+}
+
+// tableCaption returns the caption paragraph of a table: a paragraph with a
+// SEQ Table field directly before or after it. A caption between two tables
+// is left alone, as it may belong to either table.
+func tableCaption(docXML []byte, tbl tableSpan) (elementSpan, bool) {
+	blocks, err := bodyBlocks(docXML)
+	if err != nil {
+		return elementSpan{}, false
+	}
+	i := slices.IndexFunc(blocks, func(b elementSpan) bool { return b.start == tbl.start })
+	if i == -1 {
+		return elementSpan{}, false
+	}
+
+	isCaption := func(j, beyond int) bool {
+		if j < 0 || j >= len(blocks) || blocks[j].name != "w:p" {
+			return false
+		}
+		if beyond >= 0 && beyond < len(blocks) && blocks[beyond].name == "w:tbl" {
+			return false
+		}
+		return tableCaptionPattern.Match(docXML[blocks[j].start:blocks[j].end])
+	}
+	switch {
+	case isCaption(i-1, i-2):
+		return blocks[i-1], true
+	case isCaption(i+1, i+2):
+		return blocks[i+1], true
+	}
+	return elementSpan{}, false
+}
+
 // validateTableOptions validates table creation options
 func validateTableOptions(opts TableOptions) error {
 	if len(opts.Columns) == 0 {
@@ -216,6 +355,52 @@ func validateTableOptions(opts TableOptions) error {
 			return fmt.Errorf("row %d has %d cells, expected %d", i, len(row), expectedCols)
 		}
 	}
+	if len(opts.Rows) > 0 && len(opts.CellRows) > 0 {
+		return fmt.Errorf("rows and cell rows cannot be combined")
+	}
+
+	// Check that merged cells fill the grid
+	if _, _, err := layoutTable(opts); err != nil {
+		return err
+	}
+
+	// Check rich cell content
+	for i, row := range opts.HeaderRows {
+		for j, cell := range row {
+			if err := validateCellContent(cell.Content); err != nil {
+				return fmt.Errorf("header row %d cell %d: %w", i, j, err)
+			}
+		}
+	}
+	for i, row := range opts.CellRows {
+		for j, cell := range row {
+			if err := validateCellContent(cell.Content); err != nil {
+				return fmt.Errorf("row %d cell %d: %w", i, j, err)
+			}
+		}
+	}
+
+	if err := validateMeasuredWidths(opts.MeasuredColumnWidths); err != nil {
+		return err
+	}
+	if err := validateConditionalRules(opts.ConditionalRules, expectedCols); err != nil {
+		return err
+	}
+	if err := validateTableFooter(opts.Footer, expectedCols); err != nil {
+		return err
+	}
+
+	// Check pagination
+	if opts.RepeatHeaderRows < 0 {
+		return fmt.Errorf("repeat header rows cannot be negative")
+	}
+	headerRows, dataRows, _ := layoutTable(opts)
+	if rowCount := len(headerRows) + len(dataRows); opts.RepeatHeaderRows > rowCount {
+		return fmt.Errorf("repeat header rows (%d) exceeds the number of rows (%d)", opts.RepeatHeaderRows, rowCount)
+	}
+	if opts.KeepWithCaption && opts.Caption == nil {
+		return fmt.Errorf("keep with caption requires a caption")
+	}
 
 	// Validate column widths if specified
 	if len(opts.ColumnWidths) > 0 && len(opts.ColumnWidths) != expectedCols {
@@ -272,8 +457,9 @@ func applyTableDefaults(opts TableOptions) TableOptions {
 	return opts
 }
 
-// generateTableXML creates the complete XML for a table
-func generateTableXML(opts TableOptions) []byte {
+// generateTableXML creates the complete XML for a table. render produces the
+// XML of cells with rich content.
+func generateTableXML(opts TableOptions, render cellRenderer) ([]byte, error) {
 	var buf bytes.Buffer
 
 	buf.WriteString("<w:tbl>")
@@ -315,18 +501,77 @@ func generateTableXML(opts TableOptions) []byte {
 	// Table grid (column definitions)
 	buf.WriteString(generateTableGrid(opts))
 
-	// Header row
-	buf.WriteString(generateHeaderRow(opts))
+	// Cell placement on the grid (validated by validateTableOptions)
+	headerRows, dataRows, _ := layoutTable(opts)
+	widths := tableColumnWidths(opts)
+
+	// Rich cell content, rendered for the width inside the cell padding
+	renderRows := func(rows [][]layoutCell, defaultAlignment CellAlignment) error {
+		for _, row := range rows {
+			for i := range row {
+				cell := &row[i]
+				if len(cell.cell.Content) == 0 || cell.vMerge == "continue" {
+					continue
+				}
+				alignment := cell.cell.Alignment
+				if alignment == "" {
+					alignment = defaultAlignment
+				}
+				blocks, err := render(cell.cell.Content, cell.merge(widths, false).width-2*opts.CellPadding, alignment)
+				if err != nil {
+					return fmt.Errorf("cell content: %w", err)
+				}
+				cell.blocks = blocks
+			}
+		}
+		return nil
+	}
+	if err := renderRows(headerRows, opts.HeaderAlignment); err != nil {
+		return nil, err
+	}
+	if err := renderRows(dataRows, opts.RowAlignment); err != nil {
+		return nil, err
+	}
+
+	var rows []string
+
+	// Header rows
+	for _, row := range headerRows {
+		rows = append(rows, generateHeaderRow(opts, row, widths))
+	}
 
 	// Data rows
-	for i, rowData := range opts.Rows {
+	rules := compileConditionalRules(opts.ConditionalRules, dataRows)
+	for i, row := range dataRows {
 		isAlternate := (i % 2) == 1
-		buf.WriteString(generateDataRow(opts, rowData, isAlternate))
+		rows = append(rows, generateDataRow(opts, row, widths, isAlternate, rules))
+	}
+
+	// Totals row
+	if opts.Footer != nil {
+		rows = append(rows, generateFooterRow(opts, dataRows, widths))
+	}
+
+	// Pagination: keeping a row with the next one keeps all its paragraphs
+	// with the next paragraph
+	captionAfter := opts.KeepWithCaption && opts.Caption != nil && opts.Caption.Position != CaptionBefore
+	for i, row := range rows {
+		if opts.CantSplit {
+			row = addRowProperty(row, "<w:cantSplit/>")
+		}
+		if i < opts.RepeatHeaderRows {
+			row = addRowProperty(row, "<w:tblHeader/>")
+		}
+		last := i == len(rows)-1
+		if (opts.KeepTogether && !last) || (captionAfter && last) {
+			row = string(keepParagraphsWithNext([]byte(row)))
+		}
+		buf.WriteString(row)
 	}
 
 	buf.WriteString("</w:tbl>")
 
-	return buf.Bytes()
+	return buf.Bytes(), nil
 }
 
 // generateTableBorders creates border XML for the table
@@ -365,26 +610,49 @@ func calculateProportionalColumnWidths(opts TableOptions, totalWidth int) []int
 		return nil
 	}
 
-	// Calculate content length for each column
+	// Calculate content length for each column from the header titles and
+	// the longest cell in the column
 	// Each character roughly represents a certain width (default: 60 twips)
 	contentLengths := make([]int, len(opts.Columns))
-	totalContentLength := 0
+	headerRows, dataRows, err := layoutTable(opts)
+	if err != nil {
+		return nil
+	}
+	rows := slices.Concat(headerRows, dataRows)
 
-	for i, col := range opts.Columns {
-		// Start with header length
-		length := len(col.Title)
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell.span == 1 && cell.vMerge != "continue" {
+				contentLengths[cell.col] = max(contentLengths[cell.col], len(cell.cell.plainText()))
+			}
+		}
+	}
 
-		// Add length of longest cell in this column
-		for _, row := range opts.Rows {
-			if i < len(row) {
-				cellLen := len(row[i])
-				if cellLen > length {
-					length = cellLen
+	// A merged cell only widens its columns when its content is longer
+	// than the columns together; the excess is shared evenly
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell.span == 1 || cell.vMerge == "continue" {
+				continue
+			}
+			spanned := 0
+			for _, length := range contentLengths[cell.col : cell.col+cell.span] {
+				spanned += length
+			}
+			excess := len(cell.cell.plainText()) - spanned
+			for i := 0; excess > 0 && i < cell.span; i++ {
+				share := excess / (cell.span - i)
+				if excess%(cell.span-i) != 0 {
+					share++
 				}
+				contentLengths[cell.col+i] += share
+				excess -= share
 			}
 		}
+	}
 
-		contentLengths[i] = length
+	totalContentLength := 0
+	for _, length := range contentLengths {
 		totalContentLength += length
 	}
 
@@ -417,13 +685,25 @@ func calculateProportionalColumnWidths(opts TableOptions, totalWidth int) []int
 func generateTableGrid(opts TableOptions) string {
 	var buf bytes.Buffer
 	buf.WriteString("<w:tblGrid>")
+	for _, width := range tableColumnWidths(opts) {
+		buf.WriteString(fmt.Sprintf(`<w:gridCol w:w="%d"/>`, width))
+	}
+	buf.WriteString("</w:tblGrid>")
+	return buf.String()
+}
 
+// tableColumnWidths returns the width of each grid column in twips
+func tableColumnWidths(opts TableOptions) []int {
 	// Use specified widths or calculate
 	if len(opts.ColumnWidths) > 0 {
-		for _, width := range opts.ColumnWidths {
-			buf.WriteString(fmt.Sprintf(`<w:gridCol w:w="%d"/>`, width))
-		}
-	} else if opts.ProportionalColumnWidths {
+		return opts.ColumnWidths
+	}
+
+	if opts.MeasuredColumnWidths != nil {
+		return calculateMeasuredColumnWidths(opts)
+	}
+
+	if opts.ProportionalColumnWidths {
 		// Calculate proportional widths based on content
 		var totalWidth int
 
@@ -440,52 +720,49 @@ func generateTableGrid(opts TableOptions) string {
 			totalWidth = 11520
 		}
 
-		propWidths := calculateProportionalColumnWidths(opts, totalWidth)
-		for _, width := range propWidths {
-			buf.WriteString(fmt.Sprintf(`<w:gridCol w:w="%d"/>`, width))
-		}
-	} else {
-		// Auto-calculate equal widths based on table width mode
-		var colWidth int
+		return calculateProportionalColumnWidths(opts, totalWidth)
+	}
 
-		if opts.TableWidthType == TableWidthPercentage {
-			// For percentage mode, distribute based on available page width
-			// Grid columns must be in twips (absolute units) for proper sizing
-			availableWidth := opts.AvailableWidth
-			if availableWidth == 0 {
-				// Default: Letter portrait (12240) - 1" margins (1440 each) = 9360 twips
-				availableWidth = 9360
-			}
-			// Calculate proportion: how much of the available width this table takes
-			// Percentage is 5000-based (5000 = 100%)
-			tablePortionWidth := (availableWidth * opts.TableWidth) / 5000
-			colWidth = tablePortionWidth / len(opts.Columns)
-		} else if opts.TableWidthType == TableWidthFixed {
-			// For fixed mode, distribute the specified fixed width
-			colWidth = opts.TableWidth / len(opts.Columns)
-		} else {
-			// For auto mode, distribute a reasonable default width (8 inches = 11520 twips)
-			colWidth = 11520 / len(opts.Columns)
-		}
+	// Auto-calculate equal widths based on table width mode
+	var colWidth int
 
-		for range opts.Columns {
-			buf.WriteString(fmt.Sprintf(`<w:gridCol w:w="%d"/>`, colWidth))
+	if opts.TableWidthType == TableWidthPercentage {
+		// For percentage mode, distribute based on available page width
+		// Grid columns must be in twips (absolute units) for proper sizing
+		availableWidth := opts.AvailableWidth
+		if availableWidth == 0 {
+			// Default: Letter portrait (12240) - 1" margins (1440 each) = 9360 twips
+			availableWidth = 9360
 		}
+		// Calculate proportion: how much of the available width this table takes
+		// Percentage is 5000-based (5000 = 100%)
+		tablePortionWidth := (availableWidth * opts.TableWidth) / 5000
+		colWidth = tablePortionWidth / len(opts.Columns)
+	} else if opts.TableWidthType == TableWidthFixed {
+		// For fixed mode, distribute the specified fixed width
+		colWidth = opts.TableWidth / len(opts.Columns)
+	} else {
+		// For auto mode, distribute a reasonable default width (8 inches = 11520 twips)
+		colWidth = 11520 / len(opts.Columns)
 	}
 
-	buf.WriteString("</w:tblGrid>")
-	return buf.String()
+	widths := make([]int, len(opts.Columns))
+	for i := range widths {
+		widths[i] = colWidth
+	}
+	return widths
 }
 
-// generateHeaderRow creates the table header row
-func generateHeaderRow(opts TableOptions) string {
+// generateHeaderRow creates a table header row: a grouped header row or the
+// column title row
+func generateHeaderRow(opts TableOptions, cells []layoutCell, widths []int) string {
 	var buf bytes.Buffer
 
 	buf.WriteString("<w:tr>")
 
 	// Row properties for header
 	buf.WriteString("<w:trPr>")
-	if opts.RepeatHeader {
+	if opts.RepeatHeader && opts.RepeatHeaderRows == 0 {
 		buf.WriteString("<w:tblHeader/>") // Repeat on each page
 	}
 	// Header row height
@@ -499,16 +776,22 @@ func generateHeaderRow(opts TableOptions) string {
 	buf.WriteString("</w:trPr>")
 
 	// Header cells
-	for i, col := range opts.Columns {
+	for _, cell := range cells {
 		alignment := opts.HeaderAlignment
-		if col.Alignment != "" {
-			alignment = col.Alignment
+		bold := opts.HeaderBold
+		if cell.title {
+			col := opts.Columns[cell.col]
+			if col.Alignment != "" {
+				alignment = col.Alignment
+			}
+			bold = bold || col.Bold
+		}
+		if cell.cell.Alignment != "" {
+			alignment = cell.cell.Alignment
 		}
-
-		bold := opts.HeaderBold || col.Bold
 
 		buf.WriteString(generateCell(
-			col.Title,
+			cell.cell.Text,
 			alignment,
 			opts.VerticalAlign,
 			opts.HeaderBackground,
@@ -516,17 +799,18 @@ func generateHeaderRow(opts TableOptions) string {
 			false, // italic
 			opts.HeaderStyle,
 			opts.HeaderStyleName,
+			cell.merge(widths, len(opts.ColumnWidths) > 0),
+			cell.blocks,
 		))
-
-		_ = i // unused but kept for potential future use
 	}
 
 	buf.WriteString("</w:tr>")
 	return buf.String()
 }
 
-// resolveCellStyle determines the final cell style by merging row-level and conditional styles
-func resolveCellStyle(cellContent string, rowStyle CellStyle, background string, conditionalStyles map[string]CellStyle) (CellStyle, string) {
+// resolveCellStyle determines the final cell style by merging row-level and
+// conditional styles, then the conditional rules for the cell's column
+func resolveCellStyle(cellContent string, column int, rowStyle CellStyle, background string, conditionalStyles map[string]CellStyle, rules []cellRule) (CellStyle, string) {
 	mergedStyle := rowStyle
 	finalBackground := background
 
@@ -537,29 +821,45 @@ func resolveCellStyle(cellContent string, rowStyle CellStyle, background string,
 
 		for key, condStyle := range conditionalStyles {
 			if strings.EqualFold(normalizedContent, strings.TrimSpace(key)) {
-				// Merge conditional style - conditional values override row defaults
-				if condStyle.Background != "" {
-					finalBackground = condStyle.Background
-				}
-				if condStyle.FontColor != "" {
-					mergedStyle.FontColor = condStyle.FontColor
-				}
-				if condStyle.FontSize > 0 {
-					mergedStyle.FontSize = condStyle.FontSize
-				}
-				// For booleans, use OR logic (either row or conditional can enable)
-				mergedStyle.Bold = rowStyle.Bold || condStyle.Bold
-				mergedStyle.Italic = rowStyle.Italic || condStyle.Italic
+				mergedStyle, finalBackground = mergeCellStyle(mergedStyle, finalBackground, condStyle)
 				break
 			}
 		}
 	}
 
+	for _, rule := range rules {
+		if !rule.matches(column, cellContent) {
+			continue
+		}
+		mergedStyle, finalBackground = mergeCellStyle(mergedStyle, finalBackground, rule.Style)
+		if scaled, ok := rule.background(cellContent); ok {
+			finalBackground = scaled
+		}
+	}
+
 	return mergedStyle, finalBackground
 }
 
+// mergeCellStyle merges a conditional style into a cell style and background
+func mergeCellStyle(style CellStyle, background string, condStyle CellStyle) (CellStyle, string) {
+	// Conditional values override row defaults
+	if condStyle.Background != "" {
+		background = condStyle.Background
+	}
+	if condStyle.FontColor != "" {
+		style.FontColor = condStyle.FontColor
+	}
+	if condStyle.FontSize > 0 {
+		style.FontSize = condStyle.FontSize
+	}
+	// For booleans, use OR logic (either row or conditional can enable)
+	style.Bold = style.Bold || condStyle.Bold
+	style.Italic = style.Italic || condStyle.Italic
+	return style, background
+}
+
 // generateDataRow creates a table data row
-func generateDataRow(opts TableOptions, rowData []string, isAlternate bool) string {
+func generateDataRow(opts TableOptions, cells []layoutCell, widths []int, isAlternate bool, rules []cellRule) string {
 	var buf bytes.Buffer
 
 	buf.WriteString("<w:tr>")
@@ -583,19 +883,26 @@ func generateDataRow(opts TableOptions, rowData []string, isAlternate bool) stri
 	}
 
 	// Data cells
-	for _, cellData := range rowData {
+	for _, cell := range cells {
 		// Resolve cell style (applying conditional formatting if applicable)
-		cellStyle, cellBackground := resolveCellStyle(cellData, opts.RowStyle, background, opts.ConditionalStyles)
+		cellStyle, cellBackground := resolveCellStyle(cell.cell.plainText(), cell.col, opts.RowStyle, background, opts.ConditionalStyles, rules)
+
+		alignment := opts.RowAlignment
+		if cell.cell.Alignment != "" {
+			alignment = cell.cell.Alignment
+		}
 
 		buf.WriteString(generateCell(
-			cellData,
-			opts.RowAlignment,
+			cell.cell.Text,
+			alignment,
 			opts.VerticalAlign,
 			cellBackground,
 			cellStyle.Bold,
 			cellStyle.Italic,
 			cellStyle,
 			opts.RowStyleName,
+			cell.merge(widths, len(opts.ColumnWidths) > 0),
+			cell.blocks,
 		))
 	}
 
@@ -603,8 +910,17 @@ func generateDataRow(opts TableOptions, rowData []string, isAlternate bool) stri
 	return buf.String()
 }
 
-// generateCell creates a single table cell
-func generateCell(content string, align CellAlignment, vAlign VerticalAlignment, background string, bold, italic bool, style CellStyle, styleName string) string {
+// cellMerge describes the width and merging of a generated cell
+type cellMerge struct {
+	width  int    // Cell width in twips
+	span   int    // Number of grid columns (gridSpan)
+	vMerge string // Vertical merge: "", "restart" or "continue"
+	fixed  bool   // The caller fixed the column widths
+}
+
+// generateCell creates a single table cell. When blocks is set it is used as
+// the cell content instead of a paragraph with content.
+func generateCell(content string, align CellAlignment, vAlign VerticalAlignment, background string, bold, italic bool, style CellStyle, styleName string, merge cellMerge, blocks []byte) string {
 	var buf bytes.Buffer
 
 	buf.WriteString("<w:tc>")
@@ -612,6 +928,21 @@ func generateCell(content string, align CellAlignment, vAlign VerticalAlignment,
 	// Cell properties
 	buf.WriteString("<w:tcPr>")
 
+	// Width and merging. Only merged cells and fixed widths need a cell
+	// width; other cells follow the table grid.
+	if merge.fixed || merge.span > 1 || merge.vMerge != "" {
+		buf.WriteString(fmt.Sprintf(`<w:tcW w:w="%d" w:type="dxa"/>`, merge.width))
+	}
+	if merge.span > 1 {
+		buf.WriteString(fmt.Sprintf(`<w:gridSpan w:val="%d"/>`, merge.span))
+	}
+	switch merge.vMerge {
+	case "restart":
+		buf.WriteString(`<w:vMerge w:val="restart"/>`)
+	case "continue":
+		buf.WriteString(`<w:vMerge/>`)
+	}
+
 	// Vertical alignment
 	buf.WriteString(fmt.Sprintf(`<w:vAlign w:val="%s"/>`, vAlign))
 
@@ -622,6 +953,13 @@ func generateCell(content string, align CellAlignment, vAlign VerticalAlignment,
 
 	buf.WriteString("</w:tcPr>")
 
+	// Rich content
+	if len(blocks) > 0 {
+		buf.Write(blocks)
+		buf.WriteString("</w:tc>")
+		return buf.String()
+	}
+
 	// Cell content (paragraph)
 	buf.WriteString("<w:p>")
 
@@ -634,28 +972,18 @@ func generateCell(content string, align CellAlignment, vAlign VerticalAlignment,
 	buf.WriteString(fmt.Sprintf(`<w:jc w:val="%s"/>`, align))
 	buf.WriteString("</w:pPr>")
 
+	// A continued vertical merge shows the content of the first cell
+	if merge.vMerge == "continue" {
+		buf.WriteString("</w:p>")
+		buf.WriteString("</w:tc>")
+		return buf.String()
+	}
+
 	// Text run
 	buf.WriteString("<w:r>")
 
 	// Run properties (formatting)
-	needsRPr := bold || italic || style.Bold || style.Italic || style.FontSize > 0 || style.FontColor != ""
-	if needsRPr {
-		buf.WriteString("<w:rPr>")
-		if bold || style.Bold {
-			buf.WriteString("<w:b/>")
-		}
-		if italic || style.Italic {
-			buf.WriteString("<w:i/>")
-		}
-		if style.FontSize > 0 {
-			buf.WriteString(fmt.Sprintf(`<w:sz w:val="%d"/>`, style.FontSize))
-			buf.WriteString(fmt.Sprintf(`<w:szCs w:val="%d"/>`, style.FontSize))
-		}
-		if style.FontColor != "" {
-			buf.WriteString(fmt.Sprintf(`<w:color w:val="%s"/>`, style.FontColor))
-		}
-		buf.WriteString("</w:rPr>")
-	}
+	buf.WriteString(cellRunProperties(bold, italic, style))
 
 	// Text content
 	buf.WriteString("<w:t")
@@ -674,6 +1002,205 @@ func generateCell(content string, align CellAlignment, vAlign VerticalAlignment,
 	return buf.String()
 }
 
+// addRowProperty adds a row property such as <w:cantSplit/> to generated
+// row XML, unless the row already has it
+func addRowProperty(rowXML, prop string) string {
+	if rest, ok := strings.CutPrefix(rowXML, "<w:tr><w:trPr>"); ok {
+		if strings.Contains(rest[:strings.Index(rest, "</w:trPr>")], prop) {
+			return rowXML
+		}
+		return "<w:tr><w:trPr>" + prop + rest
+	}
+	return "<w:tr><w:trPr>" + prop + "</w:trPr>" + strings.TrimPrefix(rowXML, "<w:tr>")
+}
+
+// keepParagraphsWithNext sets keep-with-next on every paragraph in data
+func keepParagraphsWithNext(data []byte) []byte {
+	keepNext := []propertyElement{{"w:keepNext", []byte("<w:keepNext/>")}}
+
+	var buf bytes.Buffer
+	pos := 0
+	for {
+		start := findNextTagStart(data, pos, "w:p")
+		if start == -1 {
+			break
+		}
+		end := findQualifiedElementEnd(data, start, "w:p")
+		if end == -1 {
+			break
+		}
+		buf.Write(data[pos:start])
+		buf.Write(mergeProperties(data[start:end], "w:pPr", paragraphPropertyOrder, keepNext, nil))
+		pos = end
+	}
+	buf.Write(data[pos:])
+	return buf.Bytes()
+}
+
+// cellRunProperties returns the run properties for cell text, or an empty
+// string when no formatting is needed
+func cellRunProperties(bold, italic bool, style CellStyle) string {
+	needsRPr := bold || italic || style.Bold || style.Italic || style.FontSize > 0 || style.FontColor != ""
+	if !needsRPr {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<w:rPr>")
+	if bold || style.Bold {
+		buf.WriteString("<w:b/>")
+	}
+	if italic || style.Italic {
+		buf.WriteString("<w:i/>")
+	}
+	if style.FontSize > 0 {
+		buf.WriteString(fmt.Sprintf(`<w:sz w:val="%d"/>`, style.FontSize))
+		buf.WriteString(fmt.Sprintf(`<w:szCs w:val="%d"/>`, style.FontSize))
+	}
+	if style.FontColor != "" {
+		buf.WriteString(fmt.Sprintf(`<w:color w:val="%s"/>`, style.FontColor))
+	}
+	buf.WriteString("</w:rPr>")
+	return buf.String()
+}
+
+// layoutCell is a cell placed on the table grid
+type layoutCell struct {
+	cell   TableCell
+	col    int    // First grid column
+	span   int    // Number of grid columns
+	vMerge string // Vertical merge: "", "restart" or "continue"
+	title  bool   // Column title cell
+	blocks []byte // Rendered rich content
+}
+
+// merge returns the width and merging of the cell for generateCell; fixed
+// reports whether the caller set the column widths
+func (c layoutCell) merge(widths []int, fixed bool) cellMerge {
+	width := 0
+	for _, w := range widths[c.col : c.col+c.span] {
+		width += w
+	}
+	return cellMerge{width: width, span: c.span, vMerge: c.vMerge, fixed: fixed}
+}
+
+// gridLayout tracks the grid columns covered by vertically merged cells
+// while rows are placed
+type gridLayout struct {
+	cover []int // Rows still covered by a merged cell above, per column
+	span  []int // Span of the merged cell covering a column
+}
+
+// layoutTable places the header rows (grouped headers and column titles)
+// and the data rows on the column grid
+func layoutTable(opts TableOptions) ([][]layoutCell, [][]layoutCell, error) {
+	columns := len(opts.Columns)
+	grid := gridLayout{cover: make([]int, columns), span: make([]int, columns)}
+
+	// Grouped header rows and the column title row form one section
+	var headerRows [][]layoutCell
+	for i, row := range opts.HeaderRows {
+		cells, err := grid.place(row, len(opts.HeaderRows)-i+1)
+		if err != nil {
+			return nil, nil, fmt.Errorf("header row %d: %w", i, err)
+		}
+		headerRows = append(headerRows, cells)
+	}
+
+	var titles []TableCell
+	for i, col := range opts.Columns {
+		if grid.cover[i] == 0 {
+			titles = append(titles, TableCell{Text: col.Title})
+		}
+	}
+	titleRow, err := grid.place(titles, 1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("column titles: %w", err)
+	}
+	for i := range titleRow {
+		titleRow[i].title = titleRow[i].vMerge == ""
+	}
+	headerRows = append(headerRows, titleRow)
+
+	dataRows := opts.CellRows
+	if len(dataRows) == 0 {
+		for _, row := range opts.Rows {
+			cells := make([]TableCell, len(row))
+			for i, text := range row {
+				cells[i] = TableCell{Text: text}
+			}
+			dataRows = append(dataRows, cells)
+		}
+	}
+
+	var rows [][]layoutCell
+	for i, row := range dataRows {
+		cells, err := grid.place(row, len(dataRows)-i)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		rows = append(rows, cells)
+	}
+
+	return headerRows, rows, nil
+}
+
+// place lays out one row of cells. Covered columns get a continuation cell;
+// rowsLeft is the number of rows left in the section, including this one.
+func (g *gridLayout) place(row []TableCell, rowsLeft int) ([]layoutCell, error) {
+	columns := len(g.cover)
+	var cells []layoutCell
+
+	next := 0
+	for col := 0; col < columns; {
+		if g.cover[col] > 0 {
+			span := g.span[col]
+			cells = append(cells, layoutCell{col: col, span: span, vMerge: "continue"})
+			for c := col; c < col+span; c++ {
+				g.cover[c]--
+			}
+			col += span
+			continue
+		}
+
+		if next >= len(row) {
+			return nil, fmt.Errorf("cells cover %d of %d columns", col, columns)
+		}
+		cell := row[next]
+		next++
+
+		span := max(cell.ColSpan, 1)
+		rowSpan := max(cell.RowSpan, 1)
+		if col+span > columns {
+			return nil, fmt.Errorf("cell %d spans past the last column", next-1)
+		}
+		if rowSpan > rowsLeft {
+			return nil, fmt.Errorf("cell %d spans %d rows, only %d left", next-1, rowSpan, rowsLeft)
+		}
+		for c := col; c < col+span; c++ {
+			if g.cover[c] > 0 {
+				return nil, fmt.Errorf("cell %d overlaps a merged cell", next-1)
+			}
+		}
+
+		placed := layoutCell{cell: cell, col: col, span: span}
+		if rowSpan > 1 {
+			placed.vMerge = "restart"
+			for c := col; c < col+span; c++ {
+				g.cover[c] = rowSpan - 1
+			}
+			g.span[col] = span
+		}
+		cells = append(cells, placed)
+		col += span
+	}
+
+	if next < len(row) {
+		return nil, fmt.Errorf("row has %d cells, only %d fit", len(row), next)
+	}
+	return cells, nil
+}
+
 // insertTableAtPosition inserts the table XML at the specified position
 func insertTableAtPosition(docXML, tableXML []byte, opts TableOptions) ([]byte, error) {
 	// Handle caption if specified
@@ -691,27 +1218,13 @@ func insertTableAtPosition(docXML, tableXML []byte, opts TableOptions) ([]byte,
 
 		// Generate caption XML
 		captionXML := generateCaptionXML(*opts.Caption)
+		if opts.KeepWithCaption && opts.Caption.Position == CaptionBefore {
+			captionXML = keepParagraphsWithNext(captionXML)
+		}
 
 		// Combine table and caption based on position
 		contentToInsert = insertCaptionWithElement(docXML, captionXML, tableXML, opts.Caption.Position)
 	}
 
-	switch opts.Position {
-	case PositionBeginning:
-		return insertAtBodyStart(docXML, contentToInsert)
-	case PositionEnd:
-		return insertAtBodyEnd(docXML, contentToInsert)
-	case PositionAfterText:
-		if opts.Anchor == "" {
-			return nil, fmt.Errorf("anchor text required for PositionAfterText")
-		}
-		return insertAfterText(docXML, contentToInsert, opts.Anchor)
-	case PositionBeforeText:
-		if opts.Anchor == "" {
-			return nil, fmt.Errorf("anchor text required for PositionBeforeText")
-		}
-		return insertBeforeText(docXML, contentToInsert, opts.Anchor)
-	default:
-		return nil, fmt.Errorf("invalid insert position")
-	}
+	return insertAtPosition(docXML, contentToInsert, opts.Position, opts.Target, positionAnchor{opts.Anchor, opts.Occurrence, opts.AnchorRegex})
 }
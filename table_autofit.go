@@ -0,0 +1,204 @@
+package godocx
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MeasuredWidths configures TableOptions.MeasuredColumnWidths
+type MeasuredWidths struct {
+	// Font measures regular text. Built-in Arial metrics are used when nil.
+	Font *FontMetrics
+
+	// BoldFont measures bold text. When nil, built-in Arial Bold metrics are
+	// used with the built-in regular font, and Font is used otherwise.
+	BoldFont *FontMetrics
+
+	// FontSize is the size in half-points of text without a CellStyle
+	// FontSize (default: 22 = 11pt)
+	FontSize int
+
+	// MinColumnWidth is the narrowest width in twips that a column with long
+	// text wraps to when the table does not fit (default: 720 = 0.5").
+	// Columns never wrap inside a word.
+	MinColumnWidth int
+}
+
+// validateMeasuredWidths validates the measured column width options
+func validateMeasuredWidths(m *MeasuredWidths) error {
+	if m == nil {
+		return nil
+	}
+	if m.FontSize < 0 {
+		return fmt.Errorf("measured widths font size cannot be negative")
+	}
+	if m.MinColumnWidth < 0 {
+		return fmt.Errorf("measured widths minimum column width cannot be negative")
+	}
+	return nil
+}
+
+// calculateMeasuredColumnWidths sizes columns by measuring their text with
+// font metrics. Columns get their natural width (the widest line) when the
+// table fits; otherwise long columns wrap, down to the longest word or
+// MinColumnWidth. In auto width mode the table is only as wide as its
+// content, up to the available width; otherwise leftover width is shared in
+// proportion to the natural widths.
+func calculateMeasuredColumnWidths(opts TableOptions) []int {
+	m := *opts.MeasuredColumnWidths
+	if m.FontSize == 0 {
+		m.FontSize = 22
+	}
+	if m.MinColumnWidth == 0 {
+		m.MinColumnWidth = 720
+	}
+	regular, bold := m.Font, m.BoldFont
+	if regular == nil {
+		regular = builtinFontMetrics(false)
+		if bold == nil {
+			bold = builtinFontMetrics(true)
+		}
+	}
+	if bold == nil {
+		bold = regular
+	}
+
+	availableWidth := opts.AvailableWidth
+	if availableWidth == 0 {
+		availableWidth = 9360
+	}
+	budget := availableWidth
+	switch opts.TableWidthType {
+	case TableWidthPercentage:
+		budget = (availableWidth * opts.TableWidth) / 5000
+	case TableWidthFixed:
+		budget = opts.TableWidth
+	}
+
+	// Measure the widest line and the longest word of each cell, including
+	// the cell padding
+	padding := 2 * opts.CellPadding
+	measureCell := func(cell layoutCell, header bool) (int, int) {
+		style, isBold := opts.RowStyle, opts.RowStyle.Bold
+		if header {
+			style = opts.HeaderStyle
+			isBold = opts.HeaderBold || style.Bold || (cell.title && opts.Columns[cell.col].Bold)
+		}
+		size := style.FontSize
+		if size == 0 {
+			size = m.FontSize
+		}
+		font := regular
+		if isBold {
+			font = bold
+		}
+
+		natural, word := 0, 0
+		for line := range strings.SplitSeq(cell.cell.plainText(), "\n") {
+			natural = max(natural, font.measure(line, size))
+			for w := range strings.FieldsSeq(line) {
+				word = max(word, font.measure(w, size))
+			}
+		}
+		return natural + padding, word + padding
+	}
+
+	headerRows, dataRows, err := layoutTable(opts)
+	if err != nil {
+		return nil
+	}
+	natural := make([]int, len(opts.Columns))
+	words := make([]int, len(opts.Columns))
+	for i := range natural {
+		natural[i], words[i] = padding, padding
+	}
+
+	type measuredCell struct {
+		cell          layoutCell
+		natural, word int
+	}
+	var spanned []measuredCell
+	for i, row := range slices.Concat(headerRows, dataRows) {
+		for _, cell := range row {
+			if cell.vMerge == "continue" {
+				continue
+			}
+			n, w := measureCell(cell, i < len(headerRows))
+			if cell.span > 1 {
+				spanned = append(spanned, measuredCell{cell, n, w})
+				continue
+			}
+			natural[cell.col] = max(natural[cell.col], n)
+			words[cell.col] = max(words[cell.col], w)
+		}
+	}
+
+	// A merged cell only widens its columns when it is wider than the
+	// columns together; the excess is shared evenly
+	for _, mc := range spanned {
+		cols := mc.cell.col + mc.cell.span
+		for _, sizes := range []struct {
+			widths []int
+			need   int
+		}{{natural, mc.natural}, {words, mc.word}} {
+			have := 0
+			for _, w := range sizes.widths[mc.cell.col:cols] {
+				have += w
+			}
+			if excess := sizes.need - have; excess > 0 {
+				distributeWidth(sizes.widths[mc.cell.col:cols], nil, excess)
+			}
+		}
+	}
+
+	total := 0
+	for _, w := range natural {
+		total += w
+	}
+	if total <= budget {
+		if opts.TableWidthType != TableWidthAuto {
+			distributeWidth(natural, slices.Clone(natural), budget-total)
+		}
+		return natural
+	}
+
+	// Wrap: every column keeps its floor and the width left over goes to
+	// the columns that want more, in proportion to what they are missing
+	floors := make([]int, len(natural))
+	missing := make([]int, len(natural))
+	floorTotal := 0
+	for i := range natural {
+		floors[i] = min(natural[i], max(words[i], m.MinColumnWidth))
+		missing[i] = natural[i] - floors[i]
+		floorTotal += floors[i]
+	}
+	if floorTotal >= budget {
+		// Even the floors do not fit: scale them down to the budget
+		widths := make([]int, len(floors))
+		distributeWidth(widths, floors, budget)
+		return widths
+	}
+	distributeWidth(floors, missing, budget-floorTotal)
+	return floors
+}
+
+// distributeWidth adds extra to widths in proportion to weights (evenly when
+// weights are nil or all 0), giving the rounding remainder to the last column
+func distributeWidth(widths, weights []int, extra int) {
+	totalWeight := 0
+	for _, w := range weights {
+		totalWeight += w
+	}
+
+	added := 0
+	for i := range widths {
+		share := extra / len(widths)
+		if totalWeight > 0 {
+			share = extra * weights[i] / totalWeight
+		}
+		widths[i] += share
+		added += share
+	}
+	widths[len(widths)-1] += extra - added
+}
@@ -0,0 +1,225 @@
+package godocx_test
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+// buildTestFont builds a minimal TrueType font with 1000 units per em where
+// 'W' is 2000 units wide, 'i' 250 and every other character 500
+func buildTestFont(t *testing.T) []byte {
+	t.Helper()
+
+	u16 := func(b []byte, v int) []byte { return binary.BigEndian.AppendUint16(b, uint16(v)) }
+
+	// Format 4 subtable mapping 'W' to glyph 1 and 'i' to glyph 2
+	segments := []struct{ start, end, delta int }{{'W', 'W', 1 - 'W'}, {'i', 'i', 2 - 'i'}, {0xFFFF, 0xFFFF, 1}}
+	sub := u16(u16(u16(nil, 4), 16+len(segments)*8), 0)
+	sub = u16(u16(u16(u16(sub, len(segments)*2), 4), 1), 2)
+	for _, s := range segments {
+		sub = u16(sub, s.end)
+	}
+	sub = u16(sub, 0)
+	for _, s := range segments {
+		sub = u16(sub, s.start)
+	}
+	for _, s := range segments {
+		sub = u16(sub, s.delta)
+	}
+	for range segments {
+		sub = u16(sub, 0)
+	}
+	return buildTestFontWithCmap(t, 1, sub)
+}
+
+// buildTestFontWithCmap builds the test font with a Windows cmap subtable
+// of the given encoding
+func buildTestFontWithCmap(t *testing.T, encoding int, sub []byte) []byte {
+	t.Helper()
+
+	u16 := func(b []byte, v int) []byte { return binary.BigEndian.AppendUint16(b, uint16(v)) }
+	u32 := func(b []byte, v int) []byte { return binary.BigEndian.AppendUint32(b, uint32(v)) }
+
+	head := make([]byte, 54)
+	binary.BigEndian.PutUint16(head[18:], 1000)
+	hhea := make([]byte, 36)
+	binary.BigEndian.PutUint16(hhea[34:], 3)
+	var hmtx []byte
+	for _, advance := range []int{500, 2000, 250} {
+		hmtx = u16(u16(hmtx, advance), 0)
+	}
+
+	cmap := u32(u16(u16(u16(u16(nil, 0), 1), 3), encoding), 12)
+	cmap = append(cmap, sub...)
+
+	tables := []struct {
+		tag  string
+		data []byte
+	}{{"cmap", cmap}, {"head", head}, {"hhea", hhea}, {"hmtx", hmtx}}
+	font := u16(u16(u16(u16(u32(nil, 0x00010000), len(tables)), 0), 0), 0)
+	offset := 12 + len(tables)*16
+	for _, table := range tables {
+		font = append(font, table.tag...)
+		font = u32(u32(u32(font, 0), offset), len(table.data))
+		offset += len(table.data)
+	}
+	for _, table := range tables {
+		font = append(font, table.data...)
+	}
+	return font
+}
+
+func insertMeasuredTable(t *testing.T, opts godocx.TableOptions) []int {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	opts.Position = godocx.PositionEnd
+	if err := u.InsertTable(opts); err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+	return extractGridColumnWidths(saveAndReadDocument(t, u))
+}
+
+func TestInsertTableMeasuredColumnWidths(t *testing.T) {
+	columns := []godocx.ColumnDefinition{{Title: "ID"}, {Title: "Description"}}
+
+	// Auto width: columns get the width of their widest text at 11pt Arial
+	// plus the cell padding ("Description" is 5001/1000 em, "Short" 2390)
+	widths := insertMeasuredTable(t, godocx.TableOptions{
+		Columns:              columns,
+		Rows:                 [][]string{{"1", "Short"}},
+		TableWidthType:       godocx.TableWidthAuto,
+		MeasuredColumnWidths: &godocx.MeasuredWidths{},
+	})
+	if !reflect.DeepEqual(widths, []int{436, 1317}) {
+		t.Errorf("expected natural widths [436 1317], got %v", widths)
+	}
+
+	// Bold headers and a larger font are wider
+	widths = insertMeasuredTable(t, godocx.TableOptions{
+		Columns:              columns,
+		Rows:                 [][]string{{"1", "Short"}},
+		TableWidthType:       godocx.TableWidthAuto,
+		HeaderBold:           true,
+		HeaderStyle:          godocx.CellStyle{FontSize: 28},
+		MeasuredColumnWidths: &godocx.MeasuredWidths{},
+	})
+	if widths[1] <= 1317 {
+		t.Errorf("expected a wider bold 14pt header column, got %v", widths)
+	}
+
+	// East Asian wide characters are one em wide: 2 em at 11pt plus padding
+	widths = insertMeasuredTable(t, godocx.TableOptions{
+		Columns:              []godocx.ColumnDefinition{{Title: "漢字"}, {Title: "ＡＢ"}},
+		Rows:                 [][]string{{"", ""}},
+		TableWidthType:       godocx.TableWidthAuto,
+		MeasuredColumnWidths: &godocx.MeasuredWidths{},
+	})
+	if !reflect.DeepEqual(widths, []int{656, 656}) {
+		t.Errorf("expected full-em widths [656 656], got %v", widths)
+	}
+
+	// A fixed table shares the leftover width in proportion
+	widths = insertMeasuredTable(t, godocx.TableOptions{
+		Columns:              columns,
+		Rows:                 [][]string{{"1", "Short"}},
+		TableWidthType:       godocx.TableWidthFixed,
+		TableWidth:           3506,
+		MeasuredColumnWidths: &godocx.MeasuredWidths{},
+	})
+	if !reflect.DeepEqual(widths, []int{872, 2634}) {
+		t.Errorf("expected doubled widths [872 2634], got %v", widths)
+	}
+}
+
+func TestInsertTableMeasuredColumnWidthsWrap(t *testing.T) {
+	long := "This description is far too long to fit on one line of the table"
+	widths := insertMeasuredTable(t, godocx.TableOptions{
+		Columns:              []godocx.ColumnDefinition{{Title: "Code"}, {Title: "Description"}},
+		Rows:                 [][]string{{"A-100", long}},
+		TableWidthType:       godocx.TableWidthFixed,
+		TableWidth:           5000,
+		MeasuredColumnWidths: &godocx.MeasuredWidths{MinColumnWidth: 1000},
+	})
+	if len(widths) != 2 || widths[0]+widths[1] != 5000 {
+		t.Fatalf("expected widths totalling 5000, got %v", widths)
+	}
+	// The short column keeps its natural width ("A-100" is 2668/1000 em)
+	// and the long one wraps into the rest
+	if widths[0] != 803 {
+		t.Errorf("expected the code column at its natural 803 twips, got %v", widths)
+	}
+
+	// Columns do not wrap below the minimum width
+	widths = insertMeasuredTable(t, godocx.TableOptions{
+		Columns:              []godocx.ColumnDefinition{{Title: "Description"}, {Title: "Notes"}},
+		Rows:                 [][]string{{long, "A few short words here"}},
+		TableWidthType:       godocx.TableWidthFixed,
+		TableWidth:           5000,
+		MeasuredColumnWidths: &godocx.MeasuredWidths{MinColumnWidth: 2000},
+	})
+	if widths[1] < 2000 || widths[0]+widths[1] != 5000 {
+		t.Errorf("expected the notes column to keep 2000 twips, got %v", widths)
+	}
+}
+
+func TestInsertTableMeasuredColumnWidthsFont(t *testing.T) {
+	fontPath := filepath.Join(t.TempDir(), "test.ttf")
+	if err := os.WriteFile(fontPath, buildTestFont(t), 0o644); err != nil {
+		t.Fatalf("write font: %v", err)
+	}
+	font, err := godocx.LoadFontMetrics(fontPath)
+	if err != nil {
+		t.Fatalf("LoadFontMetrics failed: %v", err)
+	}
+
+	// At 10pt one em is 200 twips: "WW" is 800 twips, "iiii" 200, "ab" 200
+	widths := insertMeasuredTable(t, godocx.TableOptions{
+		Columns:              []godocx.ColumnDefinition{{Title: "WW"}, {Title: "iiii"}, {Title: "ab"}},
+		Rows:                 [][]string{{"", "", ""}},
+		TableWidthType:       godocx.TableWidthAuto,
+		CellPadding:          100,
+		MeasuredColumnWidths: &godocx.MeasuredWidths{Font: font, FontSize: 20},
+	})
+	if !reflect.DeepEqual(widths, []int{1000, 400, 400}) {
+		t.Errorf("expected [1000 400 400], got %v", widths)
+	}
+
+	// A format 12 group covering every supplementary character is read as a
+	// range: 'W' is glyph 1 and the rest glyph 2 or past the metrics (250)
+	u32 := func(b []byte, v int) []byte { return binary.BigEndian.AppendUint32(b, uint32(v)) }
+	sub := u32(u32(binary.BigEndian.AppendUint32(nil, 12<<16), 40), 0)
+	sub = u32(u32(u32(u32(sub, 2), 'W'), 'W'), 1)
+	sub = u32(u32(u32(sub, 0x10000), 0x10FFFF), 2)
+	font, err = godocx.ParseFontMetrics(buildTestFontWithCmap(t, 10, sub))
+	if err != nil {
+		t.Fatalf("ParseFontMetrics failed: %v", err)
+	}
+	widths = insertMeasuredTable(t, godocx.TableOptions{
+		Columns:              []godocx.ColumnDefinition{{Title: "W"}, {Title: "\U0001D400\U0010FFFD"}, {Title: "ab"}},
+		Rows:                 [][]string{{"", "", ""}},
+		TableWidthType:       godocx.TableWidthAuto,
+		CellPadding:          100,
+		MeasuredColumnWidths: &godocx.MeasuredWidths{Font: font, FontSize: 20},
+	})
+	if !reflect.DeepEqual(widths, []int{600, 300, 400}) {
+		t.Errorf("expected [600 300 400], got %v", widths)
+	}
+
+	if _, err := godocx.ParseFontMetrics([]byte("not a font")); err == nil {
+		t.Error("expected error for invalid font data")
+	}
+	if _, err := godocx.LoadFontMetrics(filepath.Join(t.TempDir(), "missing.ttf")); err == nil {
+		t.Error("expected error for missing font file")
+	}
+}
@@ -0,0 +1,263 @@
+package godocx
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// conditionKind identifies how a CellCondition tests cell text
+type conditionKind int
+
+const (
+	conditionAny conditionKind = iota
+	conditionCompare
+	conditionBetween
+	conditionMatches
+	conditionContains
+)
+
+// CellCondition tests the text of a data cell for a ConditionalRule.
+// Conditions are created with CellCompare, CellBetween, CellMatches and
+// CellContains; the zero value matches every cell.
+type CellCondition struct {
+	kind  conditionKind
+	op    string
+	value float64
+	high  float64
+	text  string
+}
+
+// CellCompare matches cells whose numeric value compares to value with op:
+// "<", "<=", ">", ">=", "=" or "!=". Cells that are not numbers never match.
+func CellCompare(op string, value float64) CellCondition {
+	return CellCondition{kind: conditionCompare, op: op, value: value}
+}
+
+// CellBetween matches cells whose numeric value is between low and high,
+// inclusive
+func CellBetween(low, high float64) CellCondition {
+	return CellCondition{kind: conditionBetween, value: low, high: high}
+}
+
+// CellMatches matches cells whose text matches the regular expression
+// pattern
+func CellMatches(pattern string) CellCondition {
+	return CellCondition{kind: conditionMatches, text: pattern}
+}
+
+// CellContains matches cells whose text contains text, ignoring case
+func CellContains(text string) CellCondition {
+	return CellCondition{kind: conditionContains, text: text}
+}
+
+// ConditionalRule styles the data cells of some columns that meet a
+// condition. Rules are applied in order after ConditionalStyles, so later
+// rules override earlier ones.
+type ConditionalRule struct {
+	Columns   []int         // Column indexes (0-based, as in TableOptions.Columns); empty for all columns
+	Condition CellCondition // Cells the rule applies to; the zero value matches every cell
+	Style     CellStyle     // Style applied to matching cells
+	Scale     *ColorScale   // Optional: background interpolated from the value of matching numeric cells
+}
+
+// ColorScale colors a cell background by its numeric value, interpolating
+// between two colors, or three with MidColor
+type ColorScale struct {
+	MinColor string // Hex color for the lowest value
+	MidColor string // Optional: hex color for the midpoint value
+	MaxColor string // Hex color for the highest value
+
+	// Min and Max are the values given MinColor and MaxColor; values outside
+	// are clamped. When both are 0 the range of the matching cells is used.
+	Min float64
+	Max float64
+}
+
+// cellRule is a validated ConditionalRule ready to be applied
+type cellRule struct {
+	ConditionalRule
+	regex    *regexp.Regexp
+	min, max float64 // Color scale range
+}
+
+// validateConditionalRules validates the conditional rules of a table
+func validateConditionalRules(rules []ConditionalRule, columns int) error {
+	for i, rule := range rules {
+		for _, col := range rule.Columns {
+			if col < 0 || col >= columns {
+				return fmt.Errorf("conditional rule %d: column %d out of range", i, col)
+			}
+		}
+
+		cond := rule.Condition
+		switch cond.kind {
+		case conditionCompare:
+			switch cond.op {
+			case "<", "<=", ">", ">=", "=", "!=":
+			default:
+				return fmt.Errorf("conditional rule %d: invalid operator %q", i, cond.op)
+			}
+		case conditionBetween:
+			if cond.value > cond.high {
+				return fmt.Errorf("conditional rule %d: low %g is above high %g", i, cond.value, cond.high)
+			}
+		case conditionMatches:
+			if _, err := regexp.Compile(cond.text); err != nil {
+				return fmt.Errorf("conditional rule %d: %w", i, NewInvalidRegexError(cond.text, err))
+			}
+		case conditionContains:
+			if cond.text == "" {
+				return fmt.Errorf("conditional rule %d: contains text cannot be empty", i)
+			}
+		}
+
+		if scale := rule.Scale; scale != nil {
+			if normalizeHexColor(scale.MinColor) == "" || normalizeHexColor(scale.MaxColor) == "" {
+				return fmt.Errorf("conditional rule %d: color scale needs valid MinColor and MaxColor", i)
+			}
+			if scale.MidColor != "" && normalizeHexColor(scale.MidColor) == "" {
+				return fmt.Errorf("conditional rule %d: invalid color scale MidColor %q", i, scale.MidColor)
+			}
+			if scale.Min > scale.Max {
+				return fmt.Errorf("conditional rule %d: color scale Min %g is above Max %g", i, scale.Min, scale.Max)
+			}
+		}
+	}
+	return nil
+}
+
+// compileConditionalRules prepares rules (validated by
+// validateConditionalRules) for the data rows, finding the value range of
+// color scales without explicit bounds
+func compileConditionalRules(rules []ConditionalRule, rows [][]layoutCell) []cellRule {
+	compiled := make([]cellRule, len(rules))
+	for i, rule := range rules {
+		r := cellRule{ConditionalRule: rule}
+		if rule.Condition.kind == conditionMatches {
+			r.regex = regexp.MustCompile(rule.Condition.text)
+		}
+
+		if rule.Scale != nil {
+			r.min, r.max = rule.Scale.Min, rule.Scale.Max
+			if r.min == 0 && r.max == 0 {
+				r.min, r.max = math.Inf(1), math.Inf(-1)
+				for _, row := range rows {
+					for _, cell := range row {
+						text := cell.cell.plainText()
+						if value, ok := parseCellNumber(text); ok && r.matches(cell.col, text) {
+							r.min, r.max = min(r.min, value), max(r.max, value)
+						}
+					}
+				}
+			}
+		}
+		compiled[i] = r
+	}
+	return compiled
+}
+
+// matches reports whether the rule applies to a cell in column col
+func (r cellRule) matches(col int, text string) bool {
+	if len(r.Columns) > 0 && !slices.Contains(r.Columns, col) {
+		return false
+	}
+
+	text = strings.TrimSpace(text)
+	cond := r.Condition
+	switch cond.kind {
+	case conditionCompare, conditionBetween:
+		value, ok := parseCellNumber(text)
+		if !ok {
+			return false
+		}
+		switch cond.op {
+		case "<":
+			return value < cond.value
+		case "<=":
+			return value <= cond.value
+		case ">":
+			return value > cond.value
+		case ">=":
+			return value >= cond.value
+		case "=":
+			return value == cond.value
+		case "!=":
+			return value != cond.value
+		}
+		return value >= cond.value && value <= cond.high
+	case conditionMatches:
+		return r.regex.MatchString(text)
+	case conditionContains:
+		return strings.Contains(strings.ToLower(text), strings.ToLower(cond.text))
+	}
+	return true
+}
+
+// background returns the color scale background for a cell, if any
+func (r cellRule) background(text string) (string, bool) {
+	if r.Scale == nil {
+		return "", false
+	}
+	value, ok := parseCellNumber(text)
+	if !ok {
+		return "", false
+	}
+
+	pos := 0.0
+	if r.max > r.min {
+		pos = min(max((value-r.min)/(r.max-r.min), 0), 1)
+	}
+
+	low, high := r.Scale.MinColor, r.Scale.MaxColor
+	if r.Scale.MidColor != "" {
+		if pos < 0.5 {
+			high = r.Scale.MidColor
+			pos *= 2
+		} else {
+			low = r.Scale.MidColor
+			pos = pos*2 - 1
+		}
+	}
+	return interpolateHexColor(low, high, pos), true
+}
+
+// parseCellNumber parses the number in cell text, ignoring surrounding
+// spaces, thousands separators, a leading currency symbol and a trailing
+// percent sign
+func parseCellNumber(text string) (float64, bool) {
+	text = strings.TrimSpace(text)
+	text = strings.TrimSuffix(text, "%")
+	sign := ""
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		sign, text = "-", rest
+	}
+	for _, symbol := range []string{"$", "€", "£", "¥"} {
+		text = strings.TrimPrefix(text, symbol)
+	}
+	text = strings.ReplaceAll(text, ",", "")
+
+	value, err := strconv.ParseFloat(sign+strings.TrimSpace(text), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
+
+// interpolateHexColor returns the color at pos (0 to 1) between two hex
+// colors
+func interpolateHexColor(from, to string, pos float64) string {
+	a, _ := strconv.ParseUint(normalizeHexColor(from), 16, 32)
+	b, _ := strconv.ParseUint(normalizeHexColor(to), 16, 32)
+
+	var out uint64
+	for shift := 16; shift >= 0; shift -= 8 {
+		ca := float64(a >> shift & 0xFF)
+		cb := float64(b >> shift & 0xFF)
+		out |= uint64(math.Round(ca+(cb-ca)*pos)) << shift
+	}
+	return fmt.Sprintf("%06X", out)
+}
@@ -0,0 +1,106 @@
+package godocx_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+// cellShading returns the shading fill of the cell holding text
+func cellShading(doc, text string) string {
+	fill := regexp.MustCompile(`<w:shd w:val="clear" w:color="auto" w:fill="([0-9A-F]{6})"/>`)
+	for _, cell := range strings.Split(doc, "<w:tc>") {
+		if strings.Contains(cell, "<w:t>"+text+"</w:t>") {
+			if m := fill.FindStringSubmatch(cell); m != nil {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
+
+func TestInsertTableConditionalRules(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionEnd,
+		Columns:  []godocx.ColumnDefinition{{Title: "KPI"}, {Title: "Change"}, {Title: "Score"}, {Title: "Status"}},
+		Rows: [][]string{
+			{"Revenue", "-3.5%", "95", "On track"},
+			{"Churn", "1,250", "40", "At risk"},
+			{"NPS", "0", "70", "n/a"},
+		},
+		ConditionalRules: []godocx.ConditionalRule{
+			{Columns: []int{1}, Condition: godocx.CellCompare("<", 0), Style: godocx.CellStyle{FontColor: "C00000", Bold: true}},
+			{Columns: []int{1}, Condition: godocx.CellBetween(1000, 2000), Style: godocx.CellStyle{Italic: true}},
+			{Columns: []int{2}, Scale: &godocx.ColorScale{MinColor: "F8696B", MidColor: "FFEB84", MaxColor: "63BE7B"}},
+			{Columns: []int{3}, Condition: godocx.CellContains("risk"), Style: godocx.CellStyle{Background: "FFC7CE"}},
+			{Columns: []int{3}, Condition: godocx.CellMatches(`^n/a$`), Style: godocx.CellStyle{FontColor: "808080"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	for _, want := range []string{
+		`<w:b/><w:color w:val="C00000"/></w:rPr><w:t>-3.5%</w:t>`,
+		`<w:i/></w:rPr><w:t>1,250</w:t>`,
+		`<w:color w:val="808080"/></w:rPr><w:t>n/a</w:t>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("expected %s in document", want)
+		}
+	}
+	if strings.Contains(doc, `<w:color w:val="C00000"/></w:rPr><w:t>0</w:t>`) {
+		t.Error("expected zero not to match the negative rule")
+	}
+
+	// The score scale runs from the lowest (40) to the highest (95) score
+	for text, want := range map[string]string{"95": "63BE7B", "40": "F8696B", "At risk": "FFC7CE"} {
+		if got := cellShading(doc, text); got != want {
+			t.Errorf("expected %s shading %s, got %q", text, want, got)
+		}
+	}
+	if got := cellShading(doc, "70"); got == "" || got == "FFEB84" || got == "63BE7B" {
+		t.Errorf("expected 70 to be between the mid and max colors, got %q", got)
+	}
+}
+
+func TestInsertTableConditionalRulesValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	tests := []struct {
+		name string
+		rule godocx.ConditionalRule
+	}{
+		{"column out of range", godocx.ConditionalRule{Columns: []int{2}}},
+		{"invalid operator", godocx.ConditionalRule{Condition: godocx.CellCompare("=>", 1)}},
+		{"reversed range", godocx.ConditionalRule{Condition: godocx.CellBetween(5, 1)}},
+		{"invalid regex", godocx.ConditionalRule{Condition: godocx.CellMatches("(")}},
+		{"empty contains", godocx.ConditionalRule{Condition: godocx.CellContains("")}},
+		{"invalid scale color", godocx.ConditionalRule{Scale: &godocx.ColorScale{MinColor: "red", MaxColor: "00FF00"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.InsertTable(godocx.TableOptions{
+				Columns:          []godocx.ColumnDefinition{{Title: "A"}, {Title: "B"}},
+				Rows:             [][]string{{"1", "2"}},
+				ConditionalRules: []godocx.ConditionalRule{tt.rule},
+			})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
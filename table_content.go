@@ -0,0 +1,197 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CellContent is a block of rich table cell content. Exactly one field must
+// be set.
+type CellContent struct {
+	// Paragraph with ParagraphOptions formatting: Text, Style, Alignment,
+	// Bold, Italic, Underline, ListType and ListLevel are used. Set ListType
+	// for bullet or numbered list items.
+	Paragraph *ParagraphOptions
+
+	// Image inline in its own paragraph. Without Width and Height an image
+	// wider than the cell is scaled down to the cell width.
+	Image *ImageOptions
+
+	// Hyperlink to an external URL in its own paragraph
+	Hyperlink *CellHyperlink
+
+	// Table nested in the cell. Without AvailableWidth the nested table is
+	// sized to the cell width. Captions are not supported.
+	Table *TableOptions
+}
+
+// CellHyperlink defines an external hyperlink inside a table cell
+type CellHyperlink struct {
+	Text    string // Link text
+	URL     string // Target URL
+	Tooltip string // Optional: text shown on hover
+}
+
+// cellRenderer renders rich cell content for a cell of the given width in
+// twips; alignment is the cell alignment used for paragraphs without one
+type cellRenderer func(content []CellContent, width int, alignment CellAlignment) ([]byte, error)
+
+// plainText returns the text of the cell, including the text of paragraphs
+// and hyperlinks in its rich content
+func (c TableCell) plainText() string {
+	if len(c.Content) == 0 {
+		return c.Text
+	}
+
+	var lines []string
+	for _, block := range c.Content {
+		switch {
+		case block.Paragraph != nil:
+			lines = append(lines, block.Paragraph.Text)
+		case block.Hyperlink != nil:
+			lines = append(lines, block.Hyperlink.Text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validateCellContent validates the rich content of a cell
+func validateCellContent(content []CellContent) error {
+	for i, block := range content {
+		set := 0
+		for _, isSet := range []bool{block.Paragraph != nil, block.Image != nil, block.Hyperlink != nil, block.Table != nil} {
+			if isSet {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("content block %d must set exactly one of Paragraph, Image, Hyperlink or Table", i)
+		}
+
+		switch {
+		case block.Image != nil:
+			if block.Image.Path == "" {
+				return fmt.Errorf("content block %d: image path cannot be empty", i)
+			}
+			if _, err := os.Stat(block.Image.Path); os.IsNotExist(err) {
+				return fmt.Errorf("content block %d: image file not found: %s", i, block.Image.Path)
+			}
+		case block.Hyperlink != nil:
+			if block.Hyperlink.Text == "" {
+				return fmt.Errorf("content block %d: hyperlink text cannot be empty", i)
+			}
+			if err := validateURL(block.Hyperlink.URL); err != nil {
+				return fmt.Errorf("content block %d: %w", i, err)
+			}
+		case block.Table != nil:
+			if block.Table.Caption != nil {
+				return fmt.Errorf("content block %d: nested tables cannot have captions", i)
+			}
+			if err := validateTableOptions(*block.Table); err != nil {
+				return fmt.Errorf("content block %d: nested table: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// renderCellContent renders rich cell content, adding images, hyperlinks and
+// list numbering to the package as needed
+func (u *Updater) renderCellContent(content []CellContent, width int, alignment CellAlignment) ([]byte, error) {
+	var buf bytes.Buffer
+	endsWithTable := false
+
+	for _, block := range content {
+		endsWithTable = false
+
+		switch {
+		case block.Paragraph != nil:
+			opts := *block.Paragraph
+			if opts.Style == "" {
+				opts.Style = StyleNormal
+			}
+			if opts.Alignment == "" {
+				opts.Alignment = cellParagraphAlignment(alignment)
+			}
+
+			listIDs := listNumberingIDs{bulletNumID: BulletListNumID, numberedNumID: NumberedListNumID}
+			if opts.ListType != "" {
+				if err := u.ensureNumberingXML(); err != nil {
+					return nil, fmt.Errorf("ensure numbering: %w", err)
+				}
+				listIDs = u.getListNumberingIDs()
+			}
+			buf.Write(generateParagraphXML(opts, listIDs))
+
+		case block.Image != nil:
+			actualDims, err := getImageDimensions(block.Image.Path)
+			if err != nil {
+				return nil, fmt.Errorf("get image dimensions: %w", err)
+			}
+			dims := calculateProportionalDimensions(actualDims, block.Image.Width, block.Image.Height)
+
+			// Fit the cell: twips to pixels at 96 DPI
+			maxWidth := width * DefaultImageDPI / 1440
+			if block.Image.Width == 0 && block.Image.Height == 0 && maxWidth > 0 && dims.Width > maxWidth {
+				dims = calculateProportionalDimensions(actualDims, maxWidth, 0)
+			}
+
+			imageXML, err := u.addImagePart(block.Image.Path, dims, block.Image.AltText)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(alignCellParagraph(imageXML, alignment))
+
+		case block.Hyperlink != nil:
+			relID, err := u.addHyperlinkRelationship(block.Hyperlink.URL)
+			if err != nil {
+				return nil, fmt.Errorf("add hyperlink relationship: %w", err)
+			}
+			opts := DefaultHyperlinkOptions()
+			opts.Style = ""
+			opts.Tooltip = block.Hyperlink.Tooltip
+			buf.Write(alignCellParagraph(u.generateHyperlinkXML(block.Hyperlink.Text, relID, opts), alignment))
+
+		case block.Table != nil:
+			opts := *block.Table
+			if opts.AvailableWidth == 0 {
+				opts.AvailableWidth = width
+			}
+			opts = applyTableDefaults(opts)
+			tableXML, err := generateTableXML(opts, u.renderCellContent)
+			if err != nil {
+				return nil, fmt.Errorf("nested table: %w", err)
+			}
+			buf.Write(tableXML)
+			endsWithTable = true
+		}
+	}
+
+	// A cell must end with a paragraph
+	if endsWithTable {
+		buf.WriteString("<w:p/>")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// alignCellParagraph applies the cell alignment to a generated paragraph
+func alignCellParagraph(paraXML []byte, alignment CellAlignment) []byte {
+	value, _ := paragraphAlignmentValue(cellParagraphAlignment(alignment))
+	jc := propertyElement{"w:jc", fmt.Appendf(nil, `<w:jc w:val="%s"/>`, value)}
+	return mergeProperties(paraXML, "w:pPr", paragraphPropertyOrder, []propertyElement{jc}, nil)
+}
+
+// cellParagraphAlignment converts a cell alignment to a paragraph alignment
+func cellParagraphAlignment(alignment CellAlignment) ParagraphAlignment {
+	switch alignment {
+	case CellAlignCenter:
+		return ParagraphAlignCenter
+	case CellAlignRight:
+		return ParagraphAlignRight
+	default:
+		return ParagraphAlignLeft
+	}
+}
@@ -0,0 +1,159 @@
+package godocx_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func TestInsertTableRichCellContent(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p><w:r><w:t>Results</w:t></w:r></w:p>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	iconPath := filepath.Join(t.TempDir(), "pass.png")
+	createTestImage(t, iconPath, 600, 300)
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position:     godocx.PositionEnd,
+		ColumnWidths: []int{3000, 4500},
+		Columns:      []godocx.ColumnDefinition{{Title: "Test"}, {Title: "Result"}},
+		CellRows: [][]godocx.TableCell{
+			{
+				{Text: "Login"},
+				{Alignment: godocx.CellAlignCenter, Content: []godocx.CellContent{
+					{Image: &godocx.ImageOptions{Path: iconPath, AltText: "Passed"}},
+					{Paragraph: &godocx.ParagraphOptions{Text: "All checks passed", Bold: true}},
+					{Paragraph: &godocx.ParagraphOptions{Text: "Token refreshed", ListType: godocx.ListTypeBullet}},
+					{Hyperlink: &godocx.CellHyperlink{Text: "Build log", URL: "https://example.com/log"}},
+				}},
+			},
+			{
+				{Text: "Matrix"},
+				{Content: []godocx.CellContent{
+					{Table: &godocx.TableOptions{
+						Columns: []godocx.ColumnDefinition{{Title: "Browser"}, {Title: "Status"}},
+						Rows:    [][]string{{"Firefox", "OK"}},
+					}},
+				}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "out.docx")
+	if err := u.Save(outPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	doc := readZipEntry(t, outPath, "word/document.xml")
+
+	// The 600px image is scaled to the 4500 twip column less the padding (285px)
+	if !strings.Contains(doc, `<wp:extent cx="2714625" cy="1352550"/>`) {
+		t.Errorf("expected image scaled to the cell width: %s", doc)
+	}
+	if !strings.Contains(doc, `<w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>All checks passed</w:t>`) {
+		t.Error("expected formatted paragraph with the cell alignment")
+	}
+	if !strings.Contains(doc, `<w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Token refreshed</w:t>`) {
+		t.Error("expected bullet list item")
+	}
+	if !strings.Contains(doc, `<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:drawing>`) {
+		t.Error("expected image paragraph with the cell alignment")
+	}
+	if !strings.Contains(doc, `<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:hyperlink r:id="`) {
+		t.Error("expected hyperlink paragraph with the cell alignment")
+	}
+	assertOrder(t, doc, `descr="Passed"`, "All checks passed", "Token refreshed", "Build log", "Matrix")
+
+	// The nested table is sized to the cell and followed by a paragraph
+	if got := strings.Count(doc, "<w:tbl>"); got != 2 {
+		t.Errorf("expected 2 tables, got %d", got)
+	}
+	if !strings.Contains(doc, `<w:gridCol w:w="2142"/><w:gridCol w:w="2142"/>`) {
+		t.Error("expected nested table columns to share the cell width")
+	}
+	if !strings.Contains(doc, `</w:tbl><w:p/></w:tc>`) {
+		t.Error("expected nested table to be followed by an empty paragraph")
+	}
+
+	rels := readZipEntry(t, outPath, "word/_rels/document.xml.rels")
+	if !strings.Contains(rels, "media/image1.png") || !strings.Contains(rels, "https://example.com/log") {
+		t.Errorf("expected image and hyperlink relationships: %s", rels)
+	}
+	if !strings.Contains(strings.Join(listZipEntries(t, outPath), "\n"), "word/numbering.xml") {
+		t.Error("expected numbering part for the list item")
+	}
+}
+
+func TestInsertTableRichCellContentValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	tests := []struct {
+		name    string
+		content godocx.CellContent
+	}{
+		{"empty block", godocx.CellContent{}},
+		{"two fields", godocx.CellContent{Paragraph: &godocx.ParagraphOptions{Text: "a"}, Hyperlink: &godocx.CellHyperlink{Text: "b", URL: "https://example.com"}}},
+		{"missing image", godocx.CellContent{Image: &godocx.ImageOptions{Path: "missing.png"}}},
+		{"invalid url", godocx.CellContent{Hyperlink: &godocx.CellHyperlink{Text: "link", URL: "not a url"}}},
+		{"nested caption", godocx.CellContent{Table: &godocx.TableOptions{Columns: []godocx.ColumnDefinition{{Title: "A"}}, Caption: &godocx.CaptionOptions{}}}},
+		{"invalid nested table", godocx.CellContent{Table: &godocx.TableOptions{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.InsertTable(godocx.TableOptions{
+				Columns:  []godocx.ColumnDefinition{{Title: "A"}},
+				CellRows: [][]godocx.TableCell{{{Content: []godocx.CellContent{tt.content}}}},
+			})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestInsertTableRichCellContentReleasedOnError(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	iconPath := filepath.Join(t.TempDir(), "pass.png")
+	createTestImage(t, iconPath, 60, 30)
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionAfterText,
+		Anchor:   "Missing anchor",
+		Columns:  []godocx.ColumnDefinition{{Title: "Result"}},
+		CellRows: [][]godocx.TableCell{{{Content: []godocx.CellContent{
+			{Image: &godocx.ImageOptions{Path: iconPath}},
+			{Hyperlink: &godocx.CellHyperlink{Text: "Build log", URL: "https://example.com/log"}},
+		}}}},
+	})
+	if err == nil {
+		t.Fatal("expected error for a missing anchor")
+	}
+
+	outPath := filepath.Join(t.TempDir(), "out.docx")
+	if err := u.Save(outPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	rels := readZipEntry(t, outPath, "word/_rels/document.xml.rels")
+	if strings.Contains(rels, "media/image") || strings.Contains(rels, "https://example.com/log") {
+		t.Errorf("expected no relationships for the table that was not inserted: %s", rels)
+	}
+	if strings.Contains(strings.Join(listZipEntries(t, outPath), "\n"), "word/media/") {
+		t.Error("expected no media parts for the table that was not inserted")
+	}
+}
@@ -0,0 +1,376 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Table is a handle for editing an existing table, obtained from
+// Updater.Table or Updater.TableAfter. Rows and cells are numbered from 1;
+// cells are counted per row, so a merged cell counts once.
+//
+// New rows copy the row and cell properties and the paragraph and run
+// formatting of an existing row. Like a Range, the handle follows its own
+// edits and is relocated by its content after other changes to the document.
+type Table struct {
+	r *Range
+}
+
+// Table returns a handle for the n-th top-level table (1-based) of the
+// document body
+func (u *Updater) Table(n int) (*Table, error) {
+	r, err := u.TableRange(n)
+	if err != nil {
+		return nil, err
+	}
+	return &Table{r: r}, nil
+}
+
+// TableAfter returns a handle for the first top-level table after the
+// paragraph containing anchor
+func (u *Updater) TableAfter(anchor string) (*Table, error) {
+	if u == nil {
+		return nil, fmt.Errorf("updater is nil")
+	}
+	if anchor == "" {
+		return nil, NewValidationError("anchor", "anchor text cannot be empty")
+	}
+
+	docXML, err := u.readDocumentXML()
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := positionAnchor{text: anchor}.matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, para := range scanStoryParagraphs(docXML, StoryDocument) {
+		if !matches(extractParagraphPlainText(docXML[para.start:para.end])) {
+			continue
+		}
+		for _, tbl := range scanStoryTables(docXML) {
+			if tbl.start >= para.end {
+				r := &Range{u: u, block: true}
+				r.sync(docXML, tbl.start, tbl.end)
+				return &Table{r: r}, nil
+			}
+		}
+		return nil, NewValidationError("anchor", fmt.Sprintf("no table follows %q", anchor))
+	}
+
+	return nil, NewTextNotFoundError(anchor)
+}
+
+// RowCount returns the number of rows in the table, including header rows
+func (t *Table) RowCount() (int, error) {
+	_, tbl, err := t.load()
+	if err != nil {
+		return 0, err
+	}
+	return len(tbl.rows), nil
+}
+
+// AppendRow adds a row at the end of the table with the formatting of the
+// last row. values are assigned to the cells in order; cells without a value
+// are left empty.
+func (t *Table) AppendRow(values []string) error {
+	count, err := t.RowCount()
+	if err != nil {
+		return err
+	}
+	return t.InsertRowAt(count+1, values)
+}
+
+// InsertRowAt inserts a row that becomes row i, with the formatting of the
+// row currently at i (or of the last row when i is one past the end)
+func (t *Table) InsertRowAt(i int, values []string) error {
+	docXML, tbl, err := t.load()
+	if err != nil {
+		return err
+	}
+	if i < 1 || i > len(tbl.rows)+1 {
+		return NewValidationError("row", fmt.Sprintf("row %d out of range (table has %d rows)", i, len(tbl.rows)))
+	}
+
+	template := tbl.rows[min(i, len(tbl.rows))-1]
+	newRow, err := fillRow(docXML[template.start:template.end], values)
+	if err != nil {
+		return err
+	}
+	newRow, err = t.r.u.prepareBlockCopy(docXML, newRow)
+	if err != nil {
+		return fmt.Errorf("copy row: %w", err)
+	}
+
+	insertAt := tbl.end - len("</w:tbl>")
+	if i <= len(tbl.rows) {
+		insertAt = tbl.rows[i-1].start
+	}
+	return t.store(docXML, spliceBytes(docXML, newRow, insertAt, insertAt))
+}
+
+// CloneRow inserts n copies of row template directly after it, keeping the
+// content and formatting of the row
+func (t *Table) CloneRow(template, n int) error {
+	if n < 1 {
+		return NewValidationError("n", "number of copies must be at least 1")
+	}
+
+	// Copy one row at a time so every copy gets its own IDs
+	for copied := range n {
+		docXML, tbl, err := t.load()
+		if err != nil {
+			return err
+		}
+		if template < 1 || template > len(tbl.rows) {
+			return NewValidationError("template", fmt.Sprintf("row %d out of range (table has %d rows)", template, len(tbl.rows)))
+		}
+
+		row := tbl.rows[template-1]
+		rowCopy, err := t.r.u.prepareBlockCopy(docXML, docXML[row.start:row.end])
+		if err != nil {
+			return fmt.Errorf("copy row: %w", err)
+		}
+		insertAt := tbl.rows[template-1+copied].end
+		if err := t.store(docXML, spliceBytes(docXML, rowCopy, insertAt, insertAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRow removes row i. The last remaining row cannot be deleted; use
+// DeleteTable to remove the whole table.
+func (t *Table) DeleteRow(i int) error {
+	docXML, tbl, err := t.load()
+	if err != nil {
+		return err
+	}
+	if i < 1 || i > len(tbl.rows) {
+		return NewValidationError("row", fmt.Sprintf("row %d out of range (table has %d rows)", i, len(tbl.rows)))
+	}
+	if len(tbl.rows) == 1 {
+		return NewValidationError("row", "cannot delete the only row of a table")
+	}
+
+	row := tbl.rows[i-1]
+	relIDs := relationshipRefs(docXML[row.start:row.end])
+	updated := spliceBytes(docXML, nil, row.start, row.end)
+	if i < len(tbl.rows) {
+		// Merges that start in the deleted row restart in the next row
+		next := tbl.rows[i]
+		if repaired, ok := restartVerticalMerges(docXML, row, next); ok {
+			updated = spliceBytes(docXML, repaired, row.start, next.end)
+		}
+	}
+	if err := t.store(docXML, updated); err != nil {
+		return err
+	}
+	if err := t.r.u.releaseRelationships(updated, relIDs); err != nil {
+		return fmt.Errorf("remove row parts: %w", err)
+	}
+	return nil
+}
+
+// SetCell replaces the content of cell c in row r with text, keeping the
+// formatting of the cell's first paragraph and run. Newlines start new
+// paragraphs.
+func (t *Table) SetCell(r, c int, text string) error {
+	docXML, tbl, err := t.load()
+	if err != nil {
+		return err
+	}
+	if r < 1 || r > len(tbl.rows) {
+		return NewValidationError("row", fmt.Sprintf("row %d out of range (table has %d rows)", r, len(tbl.rows)))
+	}
+	cells := tbl.rows[r-1].cells
+	if c < 1 || c > len(cells) {
+		return NewValidationError("cell", fmt.Sprintf("cell %d out of range (row %d has %d cells)", c, r, len(cells)))
+	}
+
+	cell := cells[c-1]
+	relIDs := relationshipRefs(docXML[cell.start:cell.end])
+	updated := spliceBytes(docXML, setCellText(docXML[cell.start:cell.end], text), cell.start, cell.end)
+	if err := t.store(docXML, updated); err != nil {
+		return err
+	}
+	if err := t.r.u.releaseRelationships(updated, relIDs); err != nil {
+		return fmt.Errorf("remove cell parts: %w", err)
+	}
+	return nil
+}
+
+// load reads the document and returns the table with its rows
+func (t *Table) load() ([]byte, tableSpan, error) {
+	if t == nil || t.r == nil {
+		return nil, tableSpan{}, fmt.Errorf("table is nil")
+	}
+
+	docXML, err := t.r.load()
+	if err != nil {
+		return nil, tableSpan{}, err
+	}
+	for _, tbl := range scanStoryTables(docXML) {
+		if tbl.start == t.r.start {
+			return docXML, tbl, nil
+		}
+	}
+	return nil, tableSpan{}, NewStaleRangeError("table is no longer a top-level table")
+}
+
+// store writes the updated document, keeping the handle on the table
+func (t *Table) store(docXML, updated []byte) error {
+	return t.r.store(updated, t.r.start, t.r.end+len(updated)-len(docXML))
+}
+
+// fillRow sets the text of the cells of a row to values, in order
+func fillRow(rowXML []byte, values []string) ([]byte, error) {
+	openEnd := bytes.IndexByte(rowXML, '>') + 1
+	var cells []elementSpan
+	for _, child := range childElements(rowXML, openEnd, len(rowXML)-len("</w:tr>")) {
+		if child.name == "w:tc" {
+			cells = append(cells, child)
+		}
+	}
+	if len(values) > len(cells) {
+		return nil, NewValidationError("values", fmt.Sprintf("%d values for a row with %d cells", len(values), len(cells)))
+	}
+
+	// Replace from the end so earlier offsets stay valid
+	for i := len(cells) - 1; i >= 0; i-- {
+		var text string
+		if i < len(values) {
+			text = values[i]
+		}
+		cell := cells[i]
+		rowXML = spliceBytes(rowXML, setCellText(rowXML[cell.start:cell.end], text), cell.start, cell.end)
+	}
+	return rowXML, nil
+}
+
+// setCellText replaces the content of a cell with text, keeping the cell
+// properties and the formatting of its first paragraph and run
+func setCellText(cellXML []byte, text string) []byte {
+	openEnd := bytes.IndexByte(cellXML, '>') + 1
+	children := childElements(cellXML, openEnd, len(cellXML)-len("</w:tc>"))
+
+	var tcPr, pPr, rPr []byte
+	seenParagraph := false
+	for _, child := range children {
+		element := cellXML[child.start:child.end]
+		switch {
+		case child.name == "w:tcPr":
+			tcPr = element
+		case child.name == "w:p" && !seenParagraph:
+			seenParagraph = true
+			pPr = paragraphProperties(element)
+			if runs := paragraphRuns(element); len(runs) > 0 {
+				rPr = runProperties(element[runs[0].start:runs[0].end])
+			}
+		}
+	}
+
+	return slices.Concat(cellXML[:openEnd], tcPr, generatePlainParagraphsXML(text, pPr, rPr), []byte("</w:tc>"))
+}
+
+// restartVerticalMerges returns the next row with every merge that starts in
+// row continued below it promoted to a restart cell holding the content of
+// the deleted start cell. It reports false when no merge starts in row.
+func restartVerticalMerges(docXML []byte, row, next rowSpan) ([]byte, bool) {
+	starts := make(map[int]cellSpan)
+	col := 0
+	for _, cell := range row.cells {
+		span, vMerge := cellGridMerge(docXML[cell.start:cell.end])
+		if vMerge == "restart" {
+			starts[col] = cell
+		}
+		col += span
+	}
+	if len(starts) == 0 {
+		return nil, false
+	}
+
+	nextXML := docXML[next.start:next.end]
+	var repairs []cellSpan
+	var replacements [][]byte
+	col = 0
+	for _, cell := range next.cells {
+		cellXML := docXML[cell.start:cell.end]
+		span, vMerge := cellGridMerge(cellXML)
+		if start, ok := starts[col]; ok && vMerge == "continue" {
+			repairs = append(repairs, cellSpan{start: cell.start - next.start, end: cell.end - next.start})
+			replacements = append(replacements, restartCell(cellXML, docXML[start.start:start.end]))
+		}
+		col += span
+	}
+	if len(repairs) == 0 {
+		return nil, false
+	}
+
+	// Replace from the end so earlier offsets stay valid
+	repaired := slices.Clone(nextXML)
+	for k := len(repairs) - 1; k >= 0; k-- {
+		repaired = spliceBytes(repaired, replacements[k], repairs[k].start, repairs[k].end)
+	}
+	return repaired, true
+}
+
+// cellGridMerge returns the number of grid columns a cell spans and its
+// vertical merge state: "", "restart" or "continue"
+func cellGridMerge(cellXML []byte) (int, string) {
+	var tcPr []byte
+	openEnd := bytes.IndexByte(cellXML, '>') + 1
+	for _, child := range childElements(cellXML, openEnd, len(cellXML)-len("</w:tc>")) {
+		if child.name == "w:tcPr" {
+			tcPr = cellXML[child.start:child.end]
+			break
+		}
+	}
+
+	span := 1
+	if n, err := strconv.Atoi(xmlAttr(startTag(tcPr, "<w:gridSpan "), "w:val")); err == nil && n > 1 {
+		span = n
+	}
+	vMerge := ""
+	if bytes.Contains(tcPr, []byte("<w:vMerge/>")) {
+		vMerge = "continue"
+	} else if tag := startTag(tcPr, "<w:vMerge "); tag != nil {
+		vMerge = "continue"
+		if xmlAttr(tag, "w:val") == "restart" {
+			vMerge = "restart"
+		}
+	}
+	return span, vMerge
+}
+
+// restartCell turns a continuation cell into a merge start with the content
+// of the deleted start cell, keeping its own cell properties
+func restartCell(cellXML, startXML []byte) []byte {
+	openEnd := bytes.IndexByte(cellXML, '>') + 1
+	var tcPr []byte
+	for _, child := range childElements(cellXML, openEnd, len(cellXML)-len("</w:tc>")) {
+		if child.name == "w:tcPr" {
+			tcPr = cellXML[child.start:child.end]
+			break
+		}
+	}
+	if tag := startTag(tcPr, "<w:vMerge "); tag != nil {
+		end := bytes.Index(tcPr, tag) + len(tag) + 1
+		tcPr = slices.Concat(tcPr[:bytes.Index(tcPr, tag)], []byte(`<w:vMerge w:val="restart"/>`), tcPr[end:])
+	} else {
+		tcPr = bytes.Replace(tcPr, []byte("<w:vMerge/>"), []byte(`<w:vMerge w:val="restart"/>`), 1)
+	}
+
+	var content []byte
+	startOpenEnd := bytes.IndexByte(startXML, '>') + 1
+	for _, child := range childElements(startXML, startOpenEnd, len(startXML)-len("</w:tc>")) {
+		if child.name != "w:tcPr" {
+			content = append(content, startXML[child.start:child.end]...)
+		}
+	}
+	return slices.Concat(cellXML[:openEnd], tcPr, content, []byte("</w:tc>"))
+}
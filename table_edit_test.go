@@ -0,0 +1,181 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+const tableEditFixtureBody = `<w:p><w:r><w:t>Summary</w:t></w:r></w:p>` +
+	`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Other</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:p><w:r><w:t>Test results</w:t></w:r></w:p>` +
+	`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/></w:tblPr>` +
+	`<w:tr><w:trPr><w:tblHeader/></w:trPr><w:tc><w:p><w:r><w:rPr><w:b/></w:rPr><w:t>Name</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:rPr><w:b/></w:rPr><w:t>Status</w:t></w:r></w:p></w:tc></w:tr>` +
+	`<w:tr><w:trPr><w:cantSplit/></w:trPr><w:tc><w:tcPr><w:shd w:val="clear" w:fill="F2F2F2"/></w:tcPr><w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:i/></w:rPr><w:t>{name}</w:t></w:r></w:p></w:tc><w:tc><w:p><w:bookmarkStart w:id="3" w:name="Status"/><w:r><w:t>{status}</w:t></w:r><w:bookmarkEnd w:id="3"/></w:p></w:tc></w:tr>` +
+	`</w:tbl>` +
+	`<w:p><w:r><w:t>Closing</w:t></w:r></w:p>`
+
+func openTableEditFixture(t *testing.T) *godocx.Updater {
+	t.Helper()
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, tableEditFixtureBody, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+	return u
+}
+
+func TestTableAppendAndInsertRows(t *testing.T) {
+	u := openTableEditFixture(t)
+
+	tbl, err := u.TableAfter("Test results")
+	if err != nil {
+		t.Fatalf("TableAfter failed: %v", err)
+	}
+
+	if err := tbl.AppendRow([]string{"Login", "Passed"}); err != nil {
+		t.Fatalf("AppendRow failed: %v", err)
+	}
+	if err := tbl.AppendRow([]string{"Logout"}); err != nil {
+		t.Fatalf("AppendRow failed: %v", err)
+	}
+	if err := tbl.InsertRowAt(2, []string{"Setup", "Skipped"}); err != nil {
+		t.Fatalf("InsertRowAt failed: %v", err)
+	}
+	if err := tbl.DeleteRow(3); err != nil {
+		t.Fatalf("DeleteRow failed: %v", err)
+	}
+
+	count, err := tbl.RowCount()
+	if err != nil {
+		t.Fatalf("RowCount failed: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("expected 4 rows, got %d", count)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if strings.Contains(doc, "{name}") {
+		t.Error("expected the sample row to be deleted")
+	}
+	// New rows keep row, cell, paragraph and run formatting
+	if !strings.Contains(doc, `<w:tr><w:trPr><w:cantSplit/></w:trPr><w:tc><w:tcPr><w:shd w:val="clear" w:fill="F2F2F2"/></w:tcPr><w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:i/></w:rPr><w:t>Login</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Passed</w:t></w:r></w:p></w:tc></w:tr>`) {
+		t.Errorf("expected formatted appended row: %s", doc)
+	}
+	if !strings.Contains(doc, `<w:t>Logout</w:t></w:r></w:p></w:tc><w:tc><w:p></w:p></w:tc>`) {
+		t.Error("expected missing values to leave cells empty")
+	}
+	assertOrder(t, doc, "Other", "Name", "Setup", "Login", "Logout", "Closing")
+}
+
+func TestTableCloneRowAndSetCell(t *testing.T) {
+	u := openTableEditFixture(t)
+
+	tbl, err := u.Table(2)
+	if err != nil {
+		t.Fatalf("Table failed: %v", err)
+	}
+
+	if err := tbl.CloneRow(2, 2); err != nil {
+		t.Fatalf("CloneRow failed: %v", err)
+	}
+	if err := tbl.SetCell(3, 1, "Second"); err != nil {
+		t.Fatalf("SetCell failed: %v", err)
+	}
+	if err := tbl.SetCell(1, 2, "Line one\nLine two"); err != nil {
+		t.Fatalf("SetCell failed: %v", err)
+	}
+
+	// The handle follows the table when other content changes
+	if err := u.AddText("Intro", godocx.PositionBeginning); err != nil {
+		t.Fatalf("AddText failed: %v", err)
+	}
+	if err := tbl.SetCell(1, 1, "Test"); err != nil {
+		t.Fatalf("SetCell after edit failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if got := strings.Count(doc, "{name}"); got != 2 {
+		t.Errorf("expected 2 rows with the sample name, got %d", got)
+	}
+	if !strings.Contains(doc, `<w:rPr><w:i/></w:rPr><w:t>Second</w:t>`) || !strings.Contains(doc, `<w:rPr><w:b/></w:rPr><w:t>Test</w:t>`) {
+		t.Error("expected SetCell to keep run formatting")
+	}
+	if !strings.Contains(doc, `<w:p><w:r><w:rPr><w:b/></w:rPr><w:t>Line one</w:t></w:r></w:p><w:p><w:r><w:rPr><w:b/></w:rPr><w:t>Line two</w:t></w:r></w:p>`) {
+		t.Error("expected newline to start a new paragraph")
+	}
+	// Cloned bookmarks are renamed and renumbered
+	for _, want := range []string{`w:name="Status"`, `w:name="Status_2"`, `w:name="Status_3"`} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("expected bookmark %s", want)
+		}
+	}
+}
+
+func TestTableDeleteRowRestartsVerticalMerge(t *testing.T) {
+	body := `<w:tbl>` +
+		`<w:tr><w:tc><w:tcPr><w:gridSpan w:val="2"/></w:tcPr><w:p><w:r><w:t>Wide</w:t></w:r></w:p></w:tc><w:tc><w:tcPr><w:vMerge w:val="restart"/></w:tcPr><w:p><w:r><w:t>Group</w:t></w:r></w:p></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>a</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>b</w:t></w:r></w:p></w:tc><w:tc><w:tcPr><w:vMerge/></w:tcPr><w:p/></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>c</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>d</w:t></w:r></w:p></w:tc><w:tc><w:tcPr><w:vMerge w:val="continue"/></w:tcPr><w:p/></w:tc></w:tr>` +
+		`</w:tbl><w:p/>`
+	u, err := godocx.New(buildFixtureDocxWithBody(t, body, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	tbl, err := u.Table(1)
+	if err != nil {
+		t.Fatalf("Table failed: %v", err)
+	}
+	if err := tbl.DeleteRow(1); err != nil {
+		t.Fatalf("DeleteRow failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if strings.Contains(doc, "Wide") {
+		t.Error("expected the first row to be deleted")
+	}
+	if !strings.Contains(doc, `<w:tc><w:tcPr><w:vMerge w:val="restart"/></w:tcPr><w:p><w:r><w:t>Group</w:t></w:r></w:p></w:tc></w:tr>`) {
+		t.Errorf("expected the next row to restart the merge with its content: %s", doc)
+	}
+	if strings.Count(doc, `w:vMerge w:val="restart"`) != 1 || !strings.Contains(doc, `<w:vMerge w:val="continue"/>`) {
+		t.Error("expected the remaining continuation cell to be kept")
+	}
+}
+
+func TestTableEditValidation(t *testing.T) {
+	u := openTableEditFixture(t)
+
+	if _, err := u.Table(3); err == nil {
+		t.Error("expected error for missing table")
+	}
+	if _, err := u.TableAfter("Closing"); err == nil {
+		t.Error("expected error when no table follows the anchor")
+	}
+	if _, err := u.TableAfter("Missing"); err == nil {
+		t.Error("expected error for missing anchor")
+	}
+
+	tbl, err := u.Table(1)
+	if err != nil {
+		t.Fatalf("Table failed: %v", err)
+	}
+	if err := tbl.AppendRow([]string{"a", "b"}); err == nil {
+		t.Error("expected error for too many values")
+	}
+	if err := tbl.InsertRowAt(5, nil); err == nil {
+		t.Error("expected error for row out of range")
+	}
+	if err := tbl.SetCell(1, 2, "x"); err == nil {
+		t.Error("expected error for cell out of range")
+	}
+	if err := tbl.CloneRow(1, 0); err == nil {
+		t.Error("expected error for zero copies")
+	}
+	if err := tbl.DeleteRow(1); err == nil {
+		t.Error("expected error when deleting the only row")
+	}
+}
@@ -0,0 +1,323 @@
+package godocx
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TotalFunction is the calculation of a footer total
+type TotalFunction string
+
+const (
+	TotalSum     TotalFunction = "SUM"
+	TotalAverage TotalFunction = "AVERAGE"
+	TotalCount   TotalFunction = "COUNT"
+	TotalMin     TotalFunction = "MIN"
+	TotalMax     TotalFunction = "MAX"
+)
+
+// TableFooter defines a totals row placed after the data rows
+type TableFooter struct {
+	Label  string        // Text of the first cell, e.g. "Total"
+	Totals []ColumnTotal // Totals by column
+
+	// Style of the footer text and its background. The zero value gives
+	// bold text.
+	Style CellStyle
+
+	// TopBorder separates the footer from the data rows (default: double);
+	// BorderNone for no separator
+	TopBorder BorderStyle
+}
+
+// ColumnTotal defines the total of one column in a footer row
+type ColumnTotal struct {
+	Column   int           // Column index (0-based, as in TableOptions.Columns)
+	Function TotalFunction // Calculation over the numeric data cells of the column
+
+	// Field inserts a Word formula field such as { =SUM(ABOVE) } that Word
+	// recalculates when fields are updated. The computed value is stored as
+	// the field result, so it shows without an update. Like Word, a field
+	// covers only the unbroken run of numeric cells directly above the
+	// footer; a blank or text cell ends it.
+	Field bool
+
+	// Format is a Word numeric picture such as "#,##0.00" or "$#,##0",
+	// used for the computed value and as the field's \# switch. Sections
+	// separated by ";" format positive, negative and zero values, as in
+	// "#,##0;(#,##0);-". Values are shown as computed when empty.
+	Format string
+
+	Alignment CellAlignment // Optional: cell alignment (default: end)
+}
+
+// validateTableFooter validates a table footer
+func validateTableFooter(footer *TableFooter, columns int) error {
+	if footer == nil {
+		return nil
+	}
+
+	seen := make(map[int]bool, len(footer.Totals))
+	for i, total := range footer.Totals {
+		if total.Column < 0 || total.Column >= columns {
+			return fmt.Errorf("footer total %d: column %d out of range", i, total.Column)
+		}
+		if seen[total.Column] {
+			return fmt.Errorf("footer total %d: column %d already has a total", i, total.Column)
+		}
+		seen[total.Column] = true
+		if total.Column == 0 && footer.Label != "" {
+			return fmt.Errorf("footer total %d: column 0 holds the footer label", i)
+		}
+
+		switch total.Function {
+		case TotalSum, TotalAverage, TotalCount, TotalMin, TotalMax:
+		default:
+			return fmt.Errorf("footer total %d: invalid function %q", i, total.Function)
+		}
+		if total.Format != "" {
+			positive, _, _ := strings.Cut(total.Format, ";")
+			if !strings.ContainsAny(positive, "0#") {
+				return fmt.Errorf("footer total %d: format %q has no digit placeholder", i, total.Format)
+			}
+			if strings.Count(total.Format, ";") > 2 {
+				return fmt.Errorf("footer total %d: format %q has more than three sections", i, total.Format)
+			}
+			if strings.Contains(total.Format, `"`) {
+				return fmt.Errorf("footer total %d: format %q contains a double quote", i, total.Format)
+			}
+		}
+	}
+	return nil
+}
+
+// generateFooterRow creates the totals row of a table, computing each total
+// from the numeric data cells of its column
+func generateFooterRow(opts TableOptions, dataRows [][]layoutCell, widths []int) string {
+	footer := opts.Footer
+	style := footer.Style
+	if style == (CellStyle{}) {
+		style.Bold = true
+	}
+	border := footer.TopBorder
+	if border == "" {
+		border = BorderDouble
+	}
+
+	totals := make(map[int]ColumnTotal, len(footer.Totals))
+	for _, total := range footer.Totals {
+		totals[total.Column] = total
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<w:tr>")
+	for col := range opts.Columns {
+		text := ""
+		alignment := opts.RowAlignment
+		var blocks []byte
+
+		if col == 0 {
+			text = footer.Label
+		}
+		if total, ok := totals[col]; ok {
+			alignment = total.Alignment
+			if alignment == "" {
+				alignment = CellAlignRight
+			}
+			values := columnNumbers(dataRows, col)
+			if total.Field {
+				values = numbersAbove(dataRows, col)
+			}
+			text = formatNumberPicture(computeColumnTotal(total.Function, values), total.Format)
+			if total.Field {
+				blocks = generateFormulaParagraph(totalFieldInstruction(total), text, alignment, cellRunProperties(false, false, style), opts.RowStyleName)
+			}
+		}
+
+		cellXML := generateCell(text, alignment, opts.VerticalAlign, style.Background, false, false, style, opts.RowStyleName, cellMerge{width: widths[col], span: 1, fixed: len(opts.ColumnWidths) > 0}, blocks)
+		if border != BorderNone {
+			cellXML = addCellTopBorder(cellXML, fmt.Sprintf(`<w:top w:val="%s" w:sz="%d" w:color="%s"/>`, border, opts.BorderSize, opts.BorderColor))
+		}
+		buf.WriteString(cellXML)
+	}
+	buf.WriteString("</w:tr>")
+	return buf.String()
+}
+
+// columnNumbers returns the numeric values of the data cells starting in a
+// column
+func columnNumbers(rows [][]layoutCell, col int) []float64 {
+	var values []float64
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell.col != col || cell.span != 1 || cell.vMerge == "continue" {
+				continue
+			}
+			if value, ok := parseCellNumber(cell.cell.plainText()); ok {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// numbersAbove returns the numeric values a Word formula field in the footer
+// finds ABOVE it in a column: those of the unbroken run of numeric cells
+// directly above the footer. A blank, text or merged cell ends the run.
+func numbersAbove(rows [][]layoutCell, col int) []float64 {
+	var values []float64
+	for i := len(rows) - 1; i >= 0; i-- {
+		idx := slices.IndexFunc(rows[i], func(cell layoutCell) bool { return cell.col == col })
+		if idx == -1 {
+			break
+		}
+		cell := rows[i][idx]
+		if cell.span != 1 || cell.vMerge == "continue" {
+			break
+		}
+		value, ok := parseCellNumber(cell.cell.plainText())
+		if !ok {
+			break
+		}
+		values = append(values, value)
+	}
+	slices.Reverse(values)
+	return values
+}
+
+// computeColumnTotal applies a total function to values. The average, minimum
+// and maximum of no values are 0.
+func computeColumnTotal(function TotalFunction, values []float64) float64 {
+	if function == TotalCount {
+		return float64(len(values))
+	}
+	if len(values) == 0 {
+		return 0
+	}
+
+	result := values[0]
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+		switch function {
+		case TotalMin:
+			result = min(result, v)
+		case TotalMax:
+			result = max(result, v)
+		}
+	}
+	switch function {
+	case TotalSum:
+		return sum
+	case TotalAverage:
+		return sum / float64(len(values))
+	}
+	return result
+}
+
+// totalFieldInstruction returns the Word formula field instruction for a
+// total, e.g. = SUM(ABOVE) \# "#,##0.00"
+func totalFieldInstruction(total ColumnTotal) string {
+	instr := fmt.Sprintf(" =%s(ABOVE) ", total.Function)
+	if total.Format != "" {
+		instr += fmt.Sprintf(`\# "%s" `, total.Format)
+	}
+	return instr
+}
+
+// generateFormulaParagraph creates a cell paragraph holding a field with a
+// cached result
+func generateFormulaParagraph(instr, result string, align CellAlignment, rPr, styleName string) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("<w:p><w:pPr>")
+	if styleName != "" {
+		buf.WriteString(fmt.Sprintf(`<w:pStyle w:val="%s"/>`, xmlEscape(styleName)))
+	}
+	buf.WriteString(fmt.Sprintf(`<w:jc w:val="%s"/>`, align))
+	buf.WriteString("</w:pPr>")
+
+	buf.WriteString(`<w:r>` + rPr + `<w:fldChar w:fldCharType="begin"/></w:r>`)
+	buf.WriteString(`<w:r>` + rPr + `<w:instrText xml:space="preserve">` + xmlEscape(instr) + `</w:instrText></w:r>`)
+	buf.WriteString(`<w:r>` + rPr + `<w:fldChar w:fldCharType="separate"/></w:r>`)
+	buf.WriteString(`<w:r>` + rPr + `<w:t>` + xmlEscape(result) + `</w:t></w:r>`)
+	buf.WriteString(`<w:r>` + rPr + `<w:fldChar w:fldCharType="end"/></w:r>`)
+
+	buf.WriteString("</w:p>")
+	return buf.Bytes()
+}
+
+// addCellTopBorder adds a top border to generated cell XML, keeping the
+// schema order of the cell properties
+func addCellTopBorder(cellXML, top string) string {
+	borders := "<w:tcBorders>" + top + "</w:tcBorders>"
+	at := strings.Index(cellXML, "<w:vAlign ")
+	return cellXML[:at] + borders + cellXML[at:]
+}
+
+// formatNumberPicture formats a value with a Word numeric picture such as
+// "#,##0.00": the digit placeholders (0 for a required digit, # for an
+// optional one) set the decimals and leading zeros, a comma groups
+// thousands, and text around the placeholders is kept. A picture with
+// sections such as "#,##0;(#,##0);-" formats negative values with the
+// second section, without a minus sign, and zero with the third.
+func formatNumberPicture(value float64, picture string) string {
+	if picture == "" {
+		// Drop floating point noise such as 0.30000000000000004
+		return formatFloat(math.Round(value*1e9) / 1e9)
+	}
+
+	if positive, rest, ok := strings.Cut(picture, ";"); ok {
+		negative, zero, hasZero := strings.Cut(rest, ";")
+		switch {
+		case value == 0 && hasZero:
+			return formatNumberPicture(0, zero)
+		case value < 0 && negative != "":
+			return formatNumberPicture(-value, negative)
+		}
+		picture = positive
+	}
+
+	start := strings.IndexAny(picture, "#0")
+	if start == -1 {
+		// A section without placeholders is shown as is
+		return picture
+	}
+	end := strings.LastIndexAny(picture, "#0") + 1
+	prefix, number, suffix := picture[:start], picture[start:end], picture[end:]
+
+	intPart, fracPart, _ := strings.Cut(number, ".")
+	decimals := strings.Count(fracPart, "0") + strings.Count(fracPart, "#")
+	requiredDecimals := strings.Count(fracPart, "0")
+	minDigits := strings.Count(intPart, "0")
+
+	// Round half away from zero, as Word does
+	scale := math.Pow10(decimals)
+	digits := strconv.FormatFloat(math.Round(math.Abs(value)*scale)/scale, 'f', decimals, 64)
+	whole, frac, _ := strings.Cut(digits, ".")
+	for len(frac) > requiredDecimals && strings.HasSuffix(frac, "0") {
+		frac = frac[:len(frac)-1]
+	}
+	if len(whole) < minDigits {
+		whole = strings.Repeat("0", minDigits-len(whole)) + whole
+	}
+	if strings.Contains(intPart, ",") {
+		for i := len(whole) - 3; i > 0; i -= 3 {
+			whole = whole[:i] + "," + whole[i:]
+		}
+	}
+
+	out := whole
+	if frac != "" {
+		out += "." + frac
+	}
+	sign := ""
+	if value < 0 && strings.Trim(out, "0.,") != "" {
+		sign = "-"
+	}
+	return sign + prefix + out + suffix
+}
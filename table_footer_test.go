@@ -0,0 +1,205 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func TestInsertTableFooterTotals(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionEnd,
+		Columns:  []godocx.ColumnDefinition{{Title: "Item"}, {Title: "Amount"}, {Title: "Units"}, {Title: "Rate"}},
+		Rows: [][]string{
+			{"Licences", "$1,200.50", "3", "0.5"},
+			{"Support", "800", "n/a", "1"},
+			{"Training", "-50.25", "2", "0.25"},
+		},
+		Footer: &godocx.TableFooter{
+			Label: "Total",
+			Totals: []godocx.ColumnTotal{
+				{Column: 1, Function: godocx.TotalSum, Field: true, Format: "$#,##0.00"},
+				{Column: 2, Function: godocx.TotalCount},
+				{Column: 3, Function: godocx.TotalAverage, Format: "0.0##"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	footer := doc[strings.LastIndex(doc, "<w:tr>"):]
+
+	// The formula field carries the number format and a precomputed result
+	if !strings.Contains(footer, `<w:instrText xml:space="preserve"> =SUM(ABOVE) \# &quot;$#,##0.00&quot; </w:instrText>`) {
+		t.Errorf("expected SUM field with number format: %s", footer)
+	}
+	if !strings.Contains(footer, `<w:fldChar w:fldCharType="separate"/></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>$1,950.25</w:t>`) {
+		t.Error("expected cached sum result")
+	}
+
+	// Computed totals are plain text; non-numeric cells are skipped
+	if !strings.Contains(footer, `<w:jc w:val="end"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>2</w:t>`) {
+		t.Error("expected count of numeric units")
+	}
+	if !strings.Contains(footer, `<w:t>0.583</w:t>`) {
+		t.Error("expected formatted average")
+	}
+
+	// The footer is bold and separated by a double border
+	if !strings.Contains(footer, `<w:rPr><w:b/></w:rPr><w:t>Total</w:t>`) {
+		t.Error("expected bold footer label")
+	}
+	if got := strings.Count(footer, `<w:tcBorders><w:top w:val="double" w:sz="4" w:color="000000"/></w:tcBorders><w:vAlign`); got != 4 {
+		t.Errorf("expected a top border on 4 footer cells, got %d", got)
+	}
+}
+
+func TestInsertTableFooterFieldAbove(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionEnd,
+		Columns:  []godocx.ColumnDefinition{{Title: "Item"}, {Title: "Amount"}, {Title: "Units"}},
+		Rows: [][]string{
+			{"Licences", "100", "4"},
+			{"Support", "", "n/a"},
+			{"Training", "20", "5"},
+			{"Travel", "3", "6"},
+		},
+		Footer: &godocx.TableFooter{
+			Label: "Total",
+			Totals: []godocx.ColumnTotal{
+				{Column: 1, Function: godocx.TotalSum, Field: true},
+				{Column: 2, Function: godocx.TotalSum},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	footer := doc[strings.LastIndex(doc, "<w:tr>"):]
+
+	// The field result stops at the blank cell, as =SUM(ABOVE) does in Word
+	if !strings.Contains(footer, `<w:fldChar w:fldCharType="separate"/></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>23</w:t>`) {
+		t.Errorf("expected cached sum of the cells above the blank: %s", footer)
+	}
+	// A computed total skips text cells
+	if !strings.Contains(footer, `<w:t>15</w:t>`) {
+		t.Error("expected computed sum of all numeric units")
+	}
+}
+
+func TestInsertTableFooterStyle(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionEnd,
+		Columns:  []godocx.ColumnDefinition{{Title: "Region"}, {Title: "Sales"}},
+		Rows:     [][]string{{"North", "1500"}, {"South", "2500"}},
+		Footer: &godocx.TableFooter{
+			Totals:    []godocx.ColumnTotal{{Column: 1, Function: godocx.TotalMax, Format: "#,##0", Alignment: godocx.CellAlignCenter}},
+			Style:     godocx.CellStyle{Italic: true, Background: "D9E2F3"},
+			TopBorder: godocx.BorderNone,
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `<w:vAlign w:val="center"/><w:shd w:val="clear" w:color="auto" w:fill="D9E2F3"/></w:tcPr><w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:i/></w:rPr><w:t>2,500</w:t>`) {
+		t.Errorf("expected styled maximum: %s", doc)
+	}
+	if strings.Contains(doc, "<w:tcBorders>") {
+		t.Error("expected no footer border")
+	}
+}
+
+func TestInsertTableFooterFormatSections(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionEnd,
+		Columns:  []godocx.ColumnDefinition{{Title: "Region"}, {Title: "Change"}, {Title: "Returns"}, {Title: "Sales"}},
+		Rows:     [][]string{{"North", "-1500", "0", "1500"}, {"South", "-2500", "0", "2500"}},
+		Footer: &godocx.TableFooter{
+			Totals: []godocx.ColumnTotal{
+				{Column: 1, Function: godocx.TotalSum, Field: true, Format: "#,##0;(#,##0);-"},
+				{Column: 2, Function: godocx.TotalSum, Format: "#,##0;(#,##0);-"},
+				{Column: 3, Function: godocx.TotalSum, Format: "#,##0;(#,##0);-"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if !strings.Contains(doc, `=SUM(ABOVE) \# &quot;#,##0;(#,##0);-&quot; `) {
+		t.Errorf("expected the sections in the field switch: %s", doc)
+	}
+	for _, want := range []string{"<w:t>(4,000)</w:t>", "<w:t>-</w:t>", "<w:t>4,000</w:t>"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("expected %s in the footer", want)
+		}
+	}
+	if strings.Contains(doc, "-(4,000)") {
+		t.Error("expected the negative section without a minus sign")
+	}
+}
+
+func TestInsertTableFooterValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	tests := []struct {
+		name   string
+		footer godocx.TableFooter
+	}{
+		{"column out of range", godocx.TableFooter{Totals: []godocx.ColumnTotal{{Column: 2, Function: godocx.TotalSum}}}},
+		{"duplicate column", godocx.TableFooter{Totals: []godocx.ColumnTotal{{Column: 1, Function: godocx.TotalSum}, {Column: 1, Function: godocx.TotalMax}}}},
+		{"label column", godocx.TableFooter{Label: "Total", Totals: []godocx.ColumnTotal{{Column: 0, Function: godocx.TotalSum}}}},
+		{"invalid function", godocx.TableFooter{Totals: []godocx.ColumnTotal{{Column: 1, Function: "MEDIAN"}}}},
+		{"invalid format", godocx.TableFooter{Totals: []godocx.ColumnTotal{{Column: 1, Function: godocx.TotalSum, Format: "USD"}}}},
+		{"quoted format", godocx.TableFooter{Totals: []godocx.ColumnTotal{{Column: 1, Function: godocx.TotalSum, Format: `#,##0 "units"`}}}},
+		{"too many sections", godocx.TableFooter{Totals: []godocx.ColumnTotal{{Column: 1, Function: godocx.TotalSum, Format: "0;(0);-;?"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.InsertTable(godocx.TableOptions{
+				Columns: []godocx.ColumnDefinition{{Title: "A"}, {Title: "B"}},
+				Rows:    [][]string{{"a", "1"}},
+				Footer:  &tt.footer,
+			})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
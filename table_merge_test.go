@@ -0,0 +1,158 @@
+package godocx_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func TestInsertTableMergedCells(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p><w:r><w:t>Statement</w:t></w:r></w:p>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position:     godocx.PositionEnd,
+		ColumnWidths: []int{2000, 1000, 1000, 1500, 1500},
+		Columns: []godocx.ColumnDefinition{
+			{}, {Title: "Q1"}, {Title: "Q2"}, {Title: "Q3"}, {Title: "Q4"},
+		},
+		HeaderRows: [][]godocx.TableCell{
+			{{Text: "Region", RowSpan: 2}, {Text: "H1", ColSpan: 2, Alignment: godocx.CellAlignCenter}, {Text: "H2", ColSpan: 2}},
+		},
+		CellRows: [][]godocx.TableCell{
+			{{Text: "North", RowSpan: 2}, {Text: "1"}, {Text: "2"}, {Text: "3"}, {Text: "4"}},
+			{{Text: "5"}, {Text: "6"}, {Text: "7 and 8", ColSpan: 2}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	for _, want := range []string{
+		`<w:tcW w:w="2000" w:type="dxa"/><w:vMerge w:val="restart"/>`,
+		`<w:tcW w:w="2000" w:type="dxa"/><w:gridSpan w:val="2"/><w:vAlign w:val="center"/></w:tcPr><w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:t>H1</w:t>`,
+		`<w:tcW w:w="3000" w:type="dxa"/><w:gridSpan w:val="2"/>`,
+		`<w:tcW w:w="3000" w:type="dxa"/><w:gridSpan w:val="2"/><w:vAlign w:val="center"/></w:tcPr><w:p><w:pPr><w:jc w:val="start"/></w:pPr><w:r><w:t>7 and 8</w:t>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("expected %s in document", want)
+		}
+	}
+
+	// The title row and the second data row continue the merged first column
+	if got := strings.Count(doc, `<w:vMerge/>`); got != 2 {
+		t.Errorf("expected 2 continued merges, got %d", got)
+	}
+	if got := strings.Count(doc, "<w:tr>"); got != 4 {
+		t.Errorf("expected 4 rows, got %d", got)
+	}
+	assertOrder(t, doc, "Region", "H1", "H2", "Q1", "Q4", "North", "5", "7 and 8")
+}
+
+func TestInsertTableCellWidthsOnlyWhenNeeded(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	// Without merges or fixed widths the cells follow the table grid
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionEnd,
+		Columns:  []godocx.ColumnDefinition{{Title: "A"}, {Title: "B"}},
+		CellRows: [][]godocx.TableCell{{{Text: "1"}, {Text: "2"}}},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+	if doc := saveAndReadDocument(t, u); strings.Contains(doc, "<w:tcW ") {
+		t.Error("expected no cell widths in a table without merges")
+	}
+
+	// Fixed column widths are repeated on every cell
+	err = u.InsertTable(godocx.TableOptions{
+		Position:     godocx.PositionEnd,
+		ColumnWidths: []int{1200, 2400},
+		Columns:      []godocx.ColumnDefinition{{Title: "A"}, {Title: "B"}},
+		Rows:         [][]string{{"1", "2"}},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+	doc := saveAndReadDocument(t, u)
+	if strings.Count(doc, `<w:tcW w:w="1200" w:type="dxa"/>`) != 2 || strings.Count(doc, `<w:tcW w:w="2400" w:type="dxa"/>`) != 2 {
+		t.Error("expected fixed widths on every cell")
+	}
+}
+
+func TestInsertTableMergedProportionalWidths(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position:                 godocx.PositionEnd,
+		ProportionalColumnWidths: true,
+		TableWidthType:           godocx.TableWidthFixed,
+		TableWidth:               6000,
+		Columns:                  []godocx.ColumnDefinition{{Title: "AB"}, {Title: "CD"}, {Title: "EF"}},
+		CellRows: [][]godocx.TableCell{
+			// The merged text only widens the columns it spans
+			{{Text: "A long merged description", ColSpan: 2}, {Text: "GH"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	widths := extractGridColumnWidths(doc)
+	if len(widths) != 3 {
+		t.Fatalf("expected 3 grid columns, got %d", len(widths))
+	}
+	if widths[0]+widths[1]+widths[2] != 6000 {
+		t.Errorf("expected widths to total 6000, got %v", widths)
+	}
+	if widths[0] <= widths[2] || widths[1] <= widths[2] {
+		t.Errorf("expected merged columns to be wider than the last column, got %v", widths)
+	}
+	if !strings.Contains(doc, `<w:tcW w:w="`+strconv.Itoa(widths[0]+widths[1])+`" w:type="dxa"/><w:gridSpan w:val="2"/>`) {
+		t.Error("expected merged cell width to be the sum of its columns")
+	}
+}
+
+func TestInsertTableMergedCellsValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	columns := []godocx.ColumnDefinition{{Title: "A"}, {Title: "B"}}
+	tests := []struct {
+		name string
+		opts godocx.TableOptions
+	}{
+		{"too few cells", godocx.TableOptions{Columns: columns, CellRows: [][]godocx.TableCell{{{Text: "1"}}}}},
+		{"too many cells", godocx.TableOptions{Columns: columns, CellRows: [][]godocx.TableCell{{{Text: "1", ColSpan: 2}, {Text: "2"}}}}},
+		{"span past last column", godocx.TableOptions{Columns: columns, CellRows: [][]godocx.TableCell{{{Text: "1"}, {Text: "2", ColSpan: 2}}}}},
+		{"span past last row", godocx.TableOptions{Columns: columns, CellRows: [][]godocx.TableCell{{{Text: "1", RowSpan: 2}, {Text: "2"}}}}},
+		{"header spans into data", godocx.TableOptions{Columns: columns, HeaderRows: [][]godocx.TableCell{{{Text: "1", RowSpan: 3}, {Text: "2"}}}}},
+		{"rows and cell rows", godocx.TableOptions{Columns: columns, Rows: [][]string{{"1", "2"}}, CellRows: [][]godocx.TableCell{{{Text: "1"}, {Text: "2"}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := u.InsertTable(tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
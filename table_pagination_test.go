@@ -0,0 +1,140 @@
+package godocx_test
+
+import (
+	"strings"
+	"testing"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+func TestInsertTablePagination(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionEnd,
+		Columns:  []godocx.ColumnDefinition{{Title: "Q1"}, {Title: "Q2"}},
+		HeaderRows: [][]godocx.TableCell{
+			{{Text: "2024", ColSpan: 2}},
+		},
+		Rows:             [][]string{{"1", "2"}, {"3", "4"}},
+		CantSplit:        true,
+		KeepTogether:     true,
+		RepeatHeaderRows: 2,
+		KeepWithCaption:  true,
+		Caption:          &godocx.CaptionOptions{Type: godocx.CaptionTable, Position: godocx.CaptionBefore, Description: "Quarterly results"},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	rows := strings.Split(doc[strings.Index(doc, "<w:tr>"):strings.Index(doc, "</w:tbl>")], "<w:tr>")[1:]
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+
+	for i, row := range rows {
+		if !strings.Contains(row, "<w:cantSplit/>") {
+			t.Errorf("row %d: expected cantSplit", i+1)
+		}
+		if repeated := strings.Contains(row, "<w:tblHeader/>"); repeated != (i < 2) {
+			t.Errorf("row %d: expected tblHeader only on the first 2 rows", i+1)
+		}
+		// Every row but the last is kept with the next
+		want := strings.Count(row, "<w:p>")
+		if i == len(rows)-1 {
+			want = 0
+		}
+		if got := strings.Count(row, "<w:keepNext/>"); got != want {
+			t.Errorf("row %d: expected %d keepNext paragraphs, got %d", i+1, want, got)
+		}
+	}
+	if !strings.Contains(doc, `<w:pStyle w:val="Caption"/><w:keepNext/>`) {
+		t.Error("expected the caption to be kept with the table")
+	}
+	assertOrder(t, doc, "Quarterly results", "2024")
+}
+
+func TestInsertTableRepeatHeaderRowsOverridesRepeatHeader(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position: godocx.PositionEnd,
+		Columns:  []godocx.ColumnDefinition{{Title: "Q1"}, {Title: "Q2"}},
+		HeaderRows: [][]godocx.TableCell{
+			{{Text: "2024", ColSpan: 2}},
+		},
+		Rows:             [][]string{{"1", "2"}},
+		RepeatHeader:     true,
+		RepeatHeaderRows: 1,
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	rows := strings.Split(doc[strings.Index(doc, "<w:tr>"):strings.Index(doc, "</w:tbl>")], "<w:tr>")[1:]
+	for i, row := range rows {
+		want := 0
+		if i == 0 {
+			want = 1
+		}
+		if got := strings.Count(row, "<w:tblHeader/>"); got != want {
+			t.Errorf("row %d: expected %d tblHeader, got %d", i+1, want, got)
+		}
+	}
+}
+
+func TestInsertTableKeepWithCaptionAfter(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertTable(godocx.TableOptions{
+		Position:        godocx.PositionEnd,
+		Columns:         []godocx.ColumnDefinition{{Title: "A"}},
+		Rows:            [][]string{{"first"}, {"last"}},
+		KeepWithCaption: true,
+		Caption:         &godocx.CaptionOptions{Type: godocx.CaptionTable, Position: godocx.CaptionAfter, Description: "After"},
+	})
+	if err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+
+	doc := saveAndReadDocument(t, u)
+	if got := strings.Count(doc, "<w:keepNext/>"); got != 1 {
+		t.Errorf("expected only the last row to be kept with the caption, got %d", got)
+	}
+	if !strings.Contains(doc, `<w:keepNext/><w:jc w:val="start"/></w:pPr><w:r><w:t>last</w:t>`) {
+		t.Error("expected the last row kept with the next paragraph")
+	}
+}
+
+func TestInsertTablePaginationValidation(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	columns := []godocx.ColumnDefinition{{Title: "A"}}
+	if err := u.InsertTable(godocx.TableOptions{Columns: columns, Rows: [][]string{{"1"}}, RepeatHeaderRows: 3}); err == nil {
+		t.Error("expected error for more repeated rows than rows")
+	}
+	if err := u.InsertTable(godocx.TableOptions{Columns: columns, RepeatHeaderRows: -1}); err == nil {
+		t.Error("expected error for negative repeated rows")
+	}
+	if err := u.InsertTable(godocx.TableOptions{Columns: columns, KeepWithCaption: true}); err == nil {
+		t.Error("expected error for keep with caption without a caption")
+	}
+}
@@ -0,0 +1,396 @@
+package godocx
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TableFromStructs builds table columns and rows from a slice of structs (or
+// pointers to structs). Each exported field becomes a column, configured by
+// a docx struct tag:
+//
+//	Price float64 `docx:"Unit price,width=2000,align=end,format=%.2f"`
+//
+// The first tag value is the column title (the field name when empty). The
+// options are width (twips), align (start, center or end, applied to the
+// title and the values) and format, a fmt verb for the value or a layout for
+// time.Time values. format must come last as it may contain commas. A tag of
+// "-" skips the field. time.Time values default to the 2006-01-02 layout and
+// nil pointers give empty cells. Widths set ColumnWidths, so they must be
+// given for every column or none.
+//
+// The result sets Columns and CellRows, and ColumnWidths when the tags set
+// widths; set the remaining TableOptions before passing it to InsertTable.
+func TableFromStructs[T any](items []T) (TableOptions, error) {
+	elemType := reflect.TypeFor[T]()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return TableOptions{}, NewValidationError("items", fmt.Sprintf("element type %s is not a struct", elemType))
+	}
+
+	type structColumn struct {
+		index  int
+		format string
+	}
+	var opts TableOptions
+	var columns []structColumn
+
+	for i := range elemType.NumField() {
+		field := elemType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("docx")
+		if ok && tag == "-" {
+			continue
+		}
+
+		col, format, err := parseTableTag(tag)
+		if err != nil {
+			return TableOptions{}, NewValidationError("items", fmt.Sprintf("field %s: %v", field.Name, err))
+		}
+		if col.Title == "" {
+			col.Title = field.Name
+		}
+		opts.Columns = append(opts.Columns, col)
+		columns = append(columns, structColumn{index: i, format: format})
+	}
+	if len(columns) == 0 {
+		return TableOptions{}, NewValidationError("items", fmt.Sprintf("%s has no exported fields", elemType))
+	}
+
+	for _, col := range opts.Columns {
+		if col.Width > 0 {
+			opts.ColumnWidths = append(opts.ColumnWidths, col.Width)
+		}
+	}
+	if len(opts.ColumnWidths) > 0 && len(opts.ColumnWidths) != len(opts.Columns) {
+		return TableOptions{}, NewValidationError("items", fmt.Sprintf("width is set on %d of %d columns; set it on every column or none", len(opts.ColumnWidths), len(opts.Columns)))
+	}
+
+	for _, item := range items {
+		value := reflect.ValueOf(item)
+		if value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+
+		row := make([]TableCell, len(columns))
+		for i, col := range columns {
+			row[i].Alignment = opts.Columns[i].Alignment
+			if value.IsValid() {
+				row[i].Text = formatTableValue(value.Field(col.index), col.format)
+			}
+		}
+		opts.CellRows = append(opts.CellRows, row)
+	}
+
+	return opts, nil
+}
+
+// parseTableTag parses a docx struct tag into a column definition and a
+// value format
+func parseTableTag(tag string) (ColumnDefinition, string, error) {
+	title, options, _ := strings.Cut(tag, ",")
+	col := ColumnDefinition{Title: title}
+
+	for options != "" {
+		var option string
+		if strings.HasPrefix(options, "format=") {
+			// The format takes the rest of the tag
+			option, options = options, ""
+		} else {
+			option, options, _ = strings.Cut(options, ",")
+		}
+
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "width":
+			width, err := strconv.Atoi(value)
+			if err != nil || width <= 0 {
+				return ColumnDefinition{}, "", fmt.Errorf("invalid width %q", value)
+			}
+			col.Width = width
+		case "align":
+			switch value {
+			case "start", "left":
+				col.Alignment = CellAlignLeft
+			case "center":
+				col.Alignment = CellAlignCenter
+			case "end", "right":
+				col.Alignment = CellAlignRight
+			default:
+				return ColumnDefinition{}, "", fmt.Errorf("invalid align %q", value)
+			}
+		case "format":
+			return col, value, nil
+		default:
+			return ColumnDefinition{}, "", fmt.Errorf("unknown option %q", key)
+		}
+	}
+
+	return col, "", nil
+}
+
+// formatTableValue formats a struct field value for a table cell
+func formatTableValue(value reflect.Value, format string) string {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+
+	if t, ok := value.Interface().(time.Time); ok {
+		if format == "" {
+			format = time.DateOnly
+		}
+		return t.Format(format)
+	}
+	if format != "" {
+		return fmt.Sprintf(format, value.Interface())
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// TableFromCSV builds table columns and rows from CSV data. The first record
+// holds the column titles; every record must have the same number of fields.
+//
+// The result sets Columns and Rows; set the remaining TableOptions before
+// passing it to InsertTable.
+func TableFromCSV(r io.Reader) (TableOptions, error) {
+	if r == nil {
+		return TableOptions{}, NewValidationError("reader", "reader cannot be nil")
+	}
+
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return TableOptions{}, fmt.Errorf("read csv: %w", err)
+	}
+	if len(records) == 0 {
+		return TableOptions{}, NewValidationError("csv", "csv data has no header record")
+	}
+
+	return tableFromRecords(records), nil
+}
+
+// TableFromXLSX builds table columns and rows from a range of an xlsx
+// workbook. sheet names the worksheet (the first worksheet when empty) and
+// cellRange is a range such as "A1:D10" (the used range when empty). The
+// first row of the range holds the column titles.
+//
+// Cells hold their stored value: numbers are not formatted with the cell
+// number format and formulas give their cached result.
+func TableFromXLSX(xlsx []byte, sheet, cellRange string) (TableOptions, error) {
+	entries, names, err := readWorkbookEntries(xlsx)
+	if err != nil {
+		return TableOptions{}, err
+	}
+
+	worksheetPath, err := resolveWorksheetPath(entries, names, sheet)
+	if err != nil {
+		return TableOptions{}, err
+	}
+	if worksheetPath == "" {
+		return TableOptions{}, fmt.Errorf("no worksheet found in workbook")
+	}
+
+	var sharedStrings []string
+	if raw, ok := entries["xl/sharedStrings.xml"]; ok {
+		var table sharedStringTable
+		if err := xml.Unmarshal(raw, &table); err != nil {
+			return TableOptions{}, fmt.Errorf("parse sharedStrings.xml: %w", err)
+		}
+		for _, item := range table.SI {
+			sharedStrings = append(sharedStrings, item.text())
+		}
+	}
+
+	cells, err := readWorksheetCells(entries[worksheetPath], sharedStrings)
+	if err != nil {
+		return TableOptions{}, err
+	}
+
+	first, last, err := resolveCellRange(cellRange, cells)
+	if err != nil {
+		return TableOptions{}, err
+	}
+
+	// Rows and columns past the used range hold no cells, so a range such as
+	// A1:XFD1048576 is read up to the used range. The first row and column
+	// of the range are kept for the column titles.
+	var used cellPosition
+	for pos := range cells {
+		used.col, used.row = max(used.col, pos.col), max(used.row, pos.row)
+	}
+	last.col = max(first.col, min(last.col, used.col))
+	last.row = max(first.row, min(last.row, used.row))
+
+	var records [][]string
+	for row := first.row; row <= last.row; row++ {
+		record := make([]string, last.col-first.col+1)
+		for col := first.col; col <= last.col; col++ {
+			record[col-first.col] = cells[cellPosition{col: col, row: row}]
+		}
+		records = append(records, record)
+	}
+
+	return tableFromRecords(records), nil
+}
+
+// TableFromChartWorkbook builds table columns and rows from a range of the
+// workbook embedded in a chart (1-based index, as in UpdateChart). sheet and
+// cellRange are used as in TableFromXLSX.
+func (u *Updater) TableFromChartWorkbook(chartIndex int, sheet, cellRange string) (TableOptions, error) {
+	if u == nil {
+		return TableOptions{}, fmt.Errorf("updater is nil")
+	}
+	if chartIndex < 1 {
+		return TableOptions{}, NewValidationError("chartIndex", "chart index must be at least 1")
+	}
+
+	xlsxPath, err := u.findWorkbookPathForChart(chartIndex)
+	if err != nil {
+		return TableOptions{}, err
+	}
+	xlsxRaw, err := os.ReadFile(xlsxPath)
+	if err != nil {
+		return TableOptions{}, fmt.Errorf("read embedded workbook: %w", err)
+	}
+
+	return TableFromXLSX(xlsxRaw, sheet, cellRange)
+}
+
+// tableFromRecords uses the first record as column titles and the rest as
+// data rows
+func tableFromRecords(records [][]string) TableOptions {
+	opts := TableOptions{Rows: records[1:]}
+	for _, title := range records[0] {
+		opts.Columns = append(opts.Columns, ColumnDefinition{Title: title})
+	}
+	return opts
+}
+
+// Worksheet limits: column XFD and row 1048576
+const (
+	maxSheetColumns = 16384
+	maxSheetRows    = 1048576
+)
+
+// cellPosition is a 1-based worksheet cell position
+type cellPosition struct {
+	col, row int
+}
+
+// worksheetCells is the subset of a worksheet used to read cell values
+type worksheetCells struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string           `xml:"r,attr"`
+			Type   string           `xml:"t,attr"`
+			Value  string           `xml:"v"`
+			Inline sharedStringItem `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readWorksheetCells returns the text of the non-empty cells of a worksheet
+// by position
+func readWorksheetCells(sheetXML []byte, sharedStrings []string) (map[cellPosition]string, error) {
+	var sheet worksheetCells
+	if err := xml.Unmarshal(sheetXML, &sheet); err != nil {
+		return nil, fmt.Errorf("parse worksheet: %w", err)
+	}
+
+	cells := make(map[cellPosition]string)
+	for _, row := range sheet.Rows {
+		for _, c := range row.Cells {
+			pos, err := parseCellRef(c.Ref)
+			if err != nil {
+				return nil, fmt.Errorf("parse worksheet: %w", err)
+			}
+
+			value := c.Value
+			switch c.Type {
+			case "s":
+				index, err := strconv.Atoi(value)
+				if err != nil || index < 0 || index >= len(sharedStrings) {
+					return nil, fmt.Errorf("cell %s: invalid shared string index %q", c.Ref, value)
+				}
+				value = sharedStrings[index]
+			case "inlineStr":
+				value = c.Inline.text()
+			case "b":
+				value = strings.ToUpper(strconv.FormatBool(value == "1"))
+			}
+			if value != "" {
+				cells[pos] = value
+			}
+		}
+	}
+	return cells, nil
+}
+
+// resolveCellRange parses a range such as "A1:D10" or a single cell, or
+// returns the range used by cells when cellRange is empty
+func resolveCellRange(cellRange string, cells map[cellPosition]string) (cellPosition, cellPosition, error) {
+	if cellRange == "" {
+		if len(cells) == 0 {
+			return cellPosition{}, cellPosition{}, NewValidationError("cellRange", "worksheet has no cells")
+		}
+		first := cellPosition{col: int(^uint(0) >> 1), row: int(^uint(0) >> 1)}
+		var last cellPosition
+		for pos := range cells {
+			first.col, first.row = min(first.col, pos.col), min(first.row, pos.row)
+			last.col, last.row = max(last.col, pos.col), max(last.row, pos.row)
+		}
+		return first, last, nil
+	}
+
+	from, to, isRange := strings.Cut(strings.ReplaceAll(cellRange, "$", ""), ":")
+	if !isRange {
+		to = from
+	}
+	first, err := parseCellRef(from)
+	if err != nil {
+		return cellPosition{}, cellPosition{}, NewValidationError("cellRange", err.Error())
+	}
+	last, err := parseCellRef(to)
+	if err != nil {
+		return cellPosition{}, cellPosition{}, NewValidationError("cellRange", err.Error())
+	}
+	if last.col < first.col || last.row < first.row {
+		return cellPosition{}, cellPosition{}, NewValidationError("cellRange", fmt.Sprintf("range %q is reversed", cellRange))
+	}
+	return first, last, nil
+}
+
+// parseCellRef parses an Excel cell reference such as "B12", the inverse of
+// cellRef. References past XFD1048576, the last cell of a worksheet, are
+// rejected.
+func parseCellRef(ref string) (cellPosition, error) {
+	var pos cellPosition
+	i := 0
+	for ; i < len(ref) && pos.col <= maxSheetColumns; i++ {
+		ch := ref[i] &^ 0x20 // upper case
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		pos.col = pos.col*26 + int(ch-'A'+1)
+	}
+
+	row, err := strconv.Atoi(ref[i:])
+	if i == 0 || err != nil || row < 1 || row > maxSheetRows || pos.col > maxSheetColumns {
+		return cellPosition{}, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	pos.row = row
+	return pos, nil
+}
@@ -0,0 +1,271 @@
+package godocx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	godocx "github.com/falcomza/go-docx"
+)
+
+type sourceProduct struct {
+	Name     string    `docx:"Product,width=3000"`
+	Price    float64   `docx:"Unit price,width=2000,align=end,format=%.2f"`
+	Released time.Time `docx:",width=2000,format=Jan 2, 2006"`
+	Stock    *int      `docx:",width=1500"`
+	Internal string    `docx:"-"`
+	sku      string
+}
+
+func TestTableFromStructs(t *testing.T) {
+	stock := 12
+	items := []*sourceProduct{
+		{Name: "Widget", Price: 4.5, Released: time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), Stock: &stock, Internal: "x", sku: "W1"},
+		{Name: "Gadget", Price: 12},
+		nil,
+	}
+
+	opts, err := godocx.TableFromStructs(items)
+	if err != nil {
+		t.Fatalf("TableFromStructs failed: %v", err)
+	}
+
+	wantColumns := []godocx.ColumnDefinition{
+		{Title: "Product", Width: 3000},
+		{Title: "Unit price", Width: 2000, Alignment: godocx.CellAlignRight},
+		{Title: "Released", Width: 2000},
+		{Title: "Stock", Width: 1500},
+	}
+	if !reflect.DeepEqual(opts.Columns, wantColumns) {
+		t.Errorf("unexpected columns: %+v", opts.Columns)
+	}
+
+	var rows [][]string
+	for _, row := range opts.CellRows {
+		var values []string
+		for _, cell := range row {
+			values = append(values, cell.Text)
+		}
+		rows = append(rows, values)
+	}
+	wantRows := [][]string{
+		{"Widget", "4.50", "Mar 7, 2024", "12"},
+		{"Gadget", "12.00", "Jan 1, 0001", ""},
+		{"", "", "", ""},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+	if opts.CellRows[0][1].Alignment != godocx.CellAlignRight {
+		t.Error("expected aligned column values to carry the alignment")
+	}
+
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	opts.Position = godocx.PositionEnd
+	opts.TableWidthType = godocx.TableWidthFixed
+	opts.TableWidth = 9000
+	if err := u.InsertTable(opts); err != nil {
+		t.Fatalf("InsertTable failed: %v", err)
+	}
+	doc := saveAndReadDocument(t, u)
+	if got := extractGridColumnWidths(doc); !reflect.DeepEqual(got, []int{3000, 2000, 2000, 1500}) {
+		t.Errorf("expected the tagged grid widths, got %v", got)
+	}
+	if !strings.Contains(doc, `<w:jc w:val="end"/></w:pPr><w:r><w:t>4.50</w:t>`) {
+		t.Error("expected right-aligned price")
+	}
+}
+
+func TestTableFromStructsValidation(t *testing.T) {
+	if _, err := godocx.TableFromStructs([]int{1}); err == nil {
+		t.Error("expected error for non-struct elements")
+	}
+	if _, err := godocx.TableFromStructs([]struct{ hidden int }{}); err == nil {
+		t.Error("expected error for struct without exported fields")
+	}
+	if _, err := godocx.TableFromStructs([]struct {
+		A int `docx:"A,width=wide"`
+	}{}); err == nil {
+		t.Error("expected error for invalid width")
+	}
+	if _, err := godocx.TableFromStructs([]struct {
+		A int `docx:"A,align=justify"`
+	}{}); err == nil {
+		t.Error("expected error for invalid alignment")
+	}
+	if _, err := godocx.TableFromStructs([]struct {
+		A int `docx:"A,width=2000"`
+		B int
+	}{}); err == nil {
+		t.Error("expected error for widths on some columns only")
+	}
+}
+
+func TestTableFromCSV(t *testing.T) {
+	opts, err := godocx.TableFromCSV(strings.NewReader("Name,Score\nAda,\"1,200\"\nGrace,950\n"))
+	if err != nil {
+		t.Fatalf("TableFromCSV failed: %v", err)
+	}
+	if len(opts.Columns) != 2 || opts.Columns[0].Title != "Name" || opts.Columns[1].Title != "Score" {
+		t.Errorf("unexpected columns: %+v", opts.Columns)
+	}
+	if !reflect.DeepEqual(opts.Rows, [][]string{{"Ada", "1,200"}, {"Grace", "950"}}) {
+		t.Errorf("unexpected rows: %v", opts.Rows)
+	}
+
+	if _, err := godocx.TableFromCSV(strings.NewReader("")); err == nil {
+		t.Error("expected error for empty csv")
+	}
+	if _, err := godocx.TableFromCSV(strings.NewReader("a,b\n1\n")); err == nil {
+		t.Error("expected error for ragged records")
+	}
+}
+
+// buildTestWorkbook builds an xlsx with a "Notes" sheet and a "Data" sheet
+func buildTestWorkbook(t *testing.T) []byte {
+	t.Helper()
+
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Notes" sheetId="1" r:id="rId1"/><sheet name="Data" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>Region</t></si><si><r><t>Sales </t></r><r><t>2024</t></r></si><si><t>North</t></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>Ignore me</t></is></c></row></sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="2"><c r="B2" t="s"><v>0</v></c><c r="C2" t="s"><v>1</v></c><c r="D2" t="inlineStr"><is><t>Target met</t></is></c></row>` +
+			`<row r="3"><c r="B3" t="s"><v>2</v></c><c r="C3"><v>1250.5</v></c><c r="D3" t="b"><v>1</v></c></row>` +
+			`<row r="4"><c r="B4" t="inlineStr"><is><t>South</t></is></c><c r="D4" t="b"><v>0</v></c></row>` +
+			`</sheetData></worksheet>`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close workbook: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestTableFromXLSX(t *testing.T) {
+	xlsx := buildTestWorkbook(t)
+
+	// The used range of the named sheet
+	opts, err := godocx.TableFromXLSX(xlsx, "Data", "")
+	if err != nil {
+		t.Fatalf("TableFromXLSX failed: %v", err)
+	}
+	var titles []string
+	for _, col := range opts.Columns {
+		titles = append(titles, col.Title)
+	}
+	if !reflect.DeepEqual(titles, []string{"Region", "Sales 2024", "Target met"}) {
+		t.Errorf("unexpected titles: %v", titles)
+	}
+	if !reflect.DeepEqual(opts.Rows, [][]string{{"North", "1250.5", "TRUE"}, {"South", "", "FALSE"}}) {
+		t.Errorf("unexpected rows: %v", opts.Rows)
+	}
+
+	// An explicit range
+	opts, err = godocx.TableFromXLSX(xlsx, "Data", "$B$2:C3")
+	if err != nil {
+		t.Fatalf("TableFromXLSX with range failed: %v", err)
+	}
+	if len(opts.Columns) != 2 || !reflect.DeepEqual(opts.Rows, [][]string{{"North", "1250.5"}}) {
+		t.Errorf("unexpected range table: %+v", opts)
+	}
+
+	// The first sheet by default
+	opts, err = godocx.TableFromXLSX(xlsx, "", "A1")
+	if err != nil {
+		t.Fatalf("TableFromXLSX first sheet failed: %v", err)
+	}
+	if opts.Columns[0].Title != "Ignore me" || len(opts.Rows) != 0 {
+		t.Errorf("unexpected first sheet table: %+v", opts)
+	}
+
+	// A whole-sheet range is read up to the used range
+	opts, err = godocx.TableFromXLSX(xlsx, "Data", "B2:XFD1048576")
+	if err != nil {
+		t.Fatalf("TableFromXLSX with whole-sheet range failed: %v", err)
+	}
+	if len(opts.Columns) != 3 || len(opts.Rows) != 2 {
+		t.Errorf("expected the used range, got %d columns and %d rows", len(opts.Columns), len(opts.Rows))
+	}
+
+	if _, err := godocx.TableFromXLSX(xlsx, "Data", "A1:XFE1"); err == nil {
+		t.Error("expected error for a column past XFD")
+	}
+	if _, err := godocx.TableFromXLSX(xlsx, "Data", "A1048577"); err == nil {
+		t.Error("expected error for a row past the last row")
+	}
+	if _, err := godocx.TableFromXLSX(xlsx, "Data", "AAAAAAAAAAAAAAAAAAAAAAAA1"); err == nil {
+		t.Error("expected error for an overlong column reference")
+	}
+	if _, err := godocx.TableFromXLSX(xlsx, "Missing", ""); err == nil {
+		t.Error("expected error for missing sheet")
+	}
+	if _, err := godocx.TableFromXLSX(xlsx, "Data", "D4:A1"); err == nil {
+		t.Error("expected error for reversed range")
+	}
+	if _, err := godocx.TableFromXLSX(xlsx, "Data", "1A"); err == nil {
+		t.Error("expected error for invalid range")
+	}
+	if _, err := godocx.TableFromXLSX([]byte("not a zip"), "", ""); err == nil {
+		t.Error("expected error for invalid workbook")
+	}
+}
+
+func TestTableFromChartWorkbook(t *testing.T) {
+	u, err := godocx.New(buildFixtureDocxWithBody(t, `<w:p/>`, nil))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { _ = u.Cleanup() })
+
+	err = u.InsertChart(godocx.ChartOptions{
+		Position:   godocx.PositionEnd,
+		Categories: []string{"Q1", "Q2"},
+		Series: []godocx.SeriesData{
+			{Name: "Revenue", Values: []float64{100, 150}},
+			{Name: "Profit", Values: []float64{20, 30}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InsertChart failed: %v", err)
+	}
+
+	opts, err := u.TableFromChartWorkbook(1, "", "")
+	if err != nil {
+		t.Fatalf("TableFromChartWorkbook failed: %v", err)
+	}
+	if len(opts.Columns) != 3 || opts.Columns[1].Title != "Revenue" || opts.Columns[2].Title != "Profit" {
+		t.Errorf("unexpected columns: %+v", opts.Columns)
+	}
+	if !reflect.DeepEqual(opts.Rows, [][]string{{"Q1", "100", "20"}, {"Q2", "150", "30"}}) {
+		t.Errorf("unexpected rows: %v", opts.Rows)
+	}
+
+	if _, err := u.TableFromChartWorkbook(2, "", ""); err == nil {
+		t.Error("expected error for missing chart")
+	}
+}
@@ -1,13 +1,33 @@
 package godocx
 
+import "time"
+
 // ChartData defines chart categories and series values.
 type ChartData struct {
 	Categories []string
 	Series     []SeriesData
+
+	// Multi-level, date and numeric categories, as in ExtendedChartOptions
+	CategoryLevels  [][]string
+	CategoryDates   []time.Time
+	CategoryNumbers []float64
+	CategoryFormat  string
+
 	// Optional titles
 	ChartTitle        string // Main chart title
 	CategoryAxisTitle string // X-axis title
 	ValueAxisTitle    string // Y-axis title
+
+	// PreserveFormatting updates the existing series in place: only the
+	// name, category and value caches and formulas are rewritten, so fills,
+	// markers, point overrides, data labels and trendlines are kept. Added
+	// series copy the formatting of the last series; surplus series are
+	// removed.
+	PreserveFormatting bool
+
+	// DisplayBlanksAs sets how the chart draws Missing values: "gap", "zero"
+	// or "span". Empty keeps the setting of the chart.
+	DisplayBlanksAs string
 }
 
 // SeriesData defines one chart series.
@@ -15,6 +35,10 @@ type SeriesData struct {
 	Name   string
 	Values []float64
 	Color  string // Hex color code (e.g., "FF0000" for red) - optional
+
+	// Scatter and bubble charts (chart insertion and UpdateChart)
+	XValues     []float64 // X values, one per value (required for scatter and bubble charts)
+	BubbleSizes []float64 // Bubble sizes, one per value (required for bubble charts)
 }
 
 // ImageOptions defines options for image insertion
@@ -34,9 +58,20 @@ type ImageOptions struct {
 	// Position where to insert the image
 	Position InsertPosition
 
-	// Anchor text for position-based insertion (for PositionAfterText/PositionBeforeText)
+	// Target overrides Position and Anchor with an insertion point such as
+	// a FindText match, a table cell or a Range
+	Target *Target
+
+	// Anchor text for position-based insertion (for PositionAfterText/PositionBeforeText),
+	// or bookmark name for PositionAfterBookmark/PositionBeforeBookmark
 	Anchor string
 
+	// Which paragraph containing Anchor to use (1-based, default: first)
+	Occurrence int
+
+	// Treat Anchor as a regular expression
+	AnchorRegex bool
+
 	// Caption options (nil for no caption)
 	Caption *CaptionOptions
 }
@@ -66,9 +101,20 @@ type BreakOptions struct {
 	// Position where to insert the break
 	Position InsertPosition
 
-	// Anchor text for position-based insertion (for PositionAfterText/PositionBeforeText)
+	// Target overrides Position and Anchor with an insertion point such as
+	// a FindText match, a table cell or a Range
+	Target *Target
+
+	// Anchor text for position-based insertion (for PositionAfterText/PositionBeforeText),
+	// or bookmark name for PositionAfterBookmark/PositionBeforeBookmark
 	Anchor string
 
+	// Which paragraph containing Anchor to use (1-based, default: first)
+	Occurrence int
+
+	// Treat Anchor as a regular expression
+	AnchorRegex bool
+
 	// Type of section break (only used for section breaks)
 	SectionType SectionBreakType
 